@@ -19,12 +19,30 @@ package bizflycloud
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/agext/levenshtein"
 	"github.com/bizflycloud/gobizfly"
+	gobizflyErr "github.com/bizflycloud/gobizfly/errors"
 )
 
+const (
+	authMethodPassword              = "password"
+	authMethodApplicationCredential = "application_credential"
+
+	// defaultRegionName is used when no region is configured, and to reach the
+	// account API when the configured region cannot be parsed so that the
+	// error can list the allowed regions.
+	defaultRegionName = "HaNoi"
+)
+
+var authMethods = []string{authMethodPassword, authMethodApplicationCredential}
+
 // Config is define a client struct
 type Config struct {
 	AuthMethod          string
@@ -36,58 +54,195 @@ type Config struct {
 	APIEndpoint         string
 	TerraformVersion    string
 	ProjectID           string
+
+	// MaxRetries, RetryWaitMin, RetryWaitMax and RetryableStatusCodes
+	// configure how failed API requests are retried.
+	MaxRetries           int
+	RetryWaitMin         time.Duration
+	RetryWaitMax         time.Duration
+	RetryableStatusCodes []int
+
+	// DefaultTags are added to the tags of every resource which has tags.
+	DefaultTags []string
 }
 
 // CombinedConfig is ...
 type CombinedConfig struct {
-	client *gobizfly.Client
+	client      *gobizfly.Client
+	defaultTags []string
 }
 
 func (c *CombinedConfig) gobizflyClient() *gobizfly.Client { return c.client }
 
-// Client is interface to connect plugin provider
-func (c *Config) Client() (*CombinedConfig, error) {
-	client, err := gobizfly.NewClient(gobizfly.WithProjectID(c.ProjectID),
-		gobizfly.WithRegionName(c.RegionName),
-		gobizfly.WithAPIURL(c.APIEndpoint)) // nolint
+// ConfigError is returned when the provider configuration is invalid. Attribute
+// is the name of the provider argument the error refers to.
+type ConfigError struct {
+	Attribute string
+	Message   string
+}
 
-	if err != nil {
-		log.Fatal(err)
+func (e *ConfigError) Error() string {
+	return e.Message
+}
+
+// RegionError is returned when the configured region is unknown or the account
+// is not allowed to access it.
+type RegionError struct {
+	Region     string
+	Allowed    []gobizfly.UserRegion
+	Suggestion string
+}
+
+func (e *RegionError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "region %q is not available for this account", e.Region)
+	if len(e.Allowed) > 0 {
+		regions := make([]string, 0, len(e.Allowed))
+		for _, region := range e.Allowed {
+			regions = append(regions, fmt.Sprintf("%s (%s)", region.Code, region.ShortName))
+		}
+		fmt.Fprintf(&b, "; available regions: %s", strings.Join(regions, ", "))
 	}
-	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second*10)
-	defer cancelFunc()
-	log.Println("[INFO] Authenticating with Bizfly Cloud API")
-	tok, err := client.Token.Create(ctx, &gobizfly.TokenCreateRequest{
+	if e.Suggestion != "" {
+		fmt.Fprintf(&b, "; did you mean %q?", e.Suggestion)
+	}
+	return b.String()
+}
+
+// Client is interface to connect plugin provider
+func (c *Config) Client(ctx context.Context) (*CombinedConfig, error) {
+	if err := c.validateAuth(); err != nil {
+		return nil, err
+	}
+
+	tokenRequest := gobizfly.TokenCreateRequest{
 		AuthMethod:    c.AuthMethod,
 		Username:      c.Email,
 		Password:      c.Password,
 		AppCredID:     c.AppCredentialID,
 		AppCredSecret: c.AppCredentialSecret,
 		ProjectID:     c.ProjectID,
-	},
-	)
+	}
+	statusCodes := c.RetryableStatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = defaultRetryableStatusCodes
+	}
+	retries := newRetryTransport(http.DefaultTransport, c.MaxRetries, c.RetryWaitMin, c.RetryWaitMax, statusCodes)
+	transport := newTokenTransport(retries, tokenRequest)
+	newClient := func(regionName string) (*gobizfly.Client, error) {
+		return gobizfly.NewClient(gobizfly.WithProjectID(c.ProjectID),
+			gobizfly.WithRegionName(regionName),
+			gobizfly.WithAPIURL(c.APIEndpoint),
+			gobizfly.WithHTTPClient(&http.Client{Transport: transport})) // nolint
+	}
+
+	regionName := c.RegionName
+	var regionErr error
+	client, err := newClient(regionName)
+	var gErr gobizflyErr.GobizflyErr
+	if errors.As(err, &gErr) && gErr.Code == gobizflyErr.InvalidRegion.Code {
+		// Keep going with the default region so that the allowed regions can
+		// be looked up and reported to the user.
+		log.Printf("[WARN] Unknown region %q, looking up the allowed regions", regionName)
+		regionErr = err
+		client, err = newClient(defaultRegionName)
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error creating Bizfly Cloud client: %w", err)
 	}
+	transport.tokenURL = client.GetServiceURL("auth") + "/token"
 
+	ctx, cancelFunc := context.WithTimeout(ctx, time.Second*10)
+	defer cancelFunc()
+	log.Println("[INFO] Authenticating with Bizfly Cloud API")
+	tok, err := client.Token.Create(ctx, &tokenRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error authenticating with Bizfly Cloud API using %s: %w", c.AuthMethod, err)
+	}
+
+	transport.setToken(tok)
 	client.SetKeystoneToken(tok)
 	userInfo, err := client.Account.GetUserInfo(ctx)
 	if err != nil {
-		return nil, err
+		if regionErr != nil {
+			return nil, &RegionError{Region: regionName}
+		}
+		return nil, fmt.Errorf("error retrieving Bizfly Cloud user info: %w", err)
 	}
-	allowedToRegion := false
 	log.Println("[DEBUG] Checking if you are allowed to access this region")
 	log.Println("[DEBUG] Allowed Region: ", userInfo.UserRegions)
-	for _, region := range userInfo.UserRegions {
-		if c.RegionName == region.Code || c.RegionName == region.ShortName {
-			allowedToRegion = true
+	if regionErr != nil || !regionAllowed(regionName, userInfo.UserRegions) {
+		return nil, &RegionError{
+			Region:     regionName,
+			Allowed:    userInfo.UserRegions,
+			Suggestion: suggestRegion(regionName, userInfo.UserRegions),
 		}
 	}
-	if !allowedToRegion {
-		log.Fatal("You are not allowed to access this region")
-	}
 
 	return &CombinedConfig{
-		client: client,
+		client:      client,
+		defaultTags: c.DefaultTags,
 	}, nil
 }
+
+// validateAuth checks the authentication method and its credentials before
+// any request is sent to the API.
+func (c *Config) validateAuth() error {
+	switch c.AuthMethod {
+	case authMethodPassword:
+		if c.Email == "" || c.Password == "" {
+			return &ConfigError{
+				Attribute: "email",
+				Message:   "email and password are required when auth_method is \"password\"",
+			}
+		}
+	case authMethodApplicationCredential:
+		if c.AppCredentialID == "" || c.AppCredentialSecret == "" {
+			return &ConfigError{
+				Attribute: "application_credential_id",
+				Message: "application_credential_id and application_credential_secret are required " +
+					"when auth_method is \"application_credential\"",
+			}
+		}
+	default:
+		return &ConfigError{
+			Attribute: "auth_method",
+			Message: fmt.Sprintf("invalid auth_method %q, expected one of: %s",
+				c.AuthMethod, strings.Join(authMethods, ", ")),
+		}
+	}
+	return nil
+}
+
+// regionAllowed reports whether name refers to one of the user regions. The
+// region code, short name and display name are accepted case-insensitively.
+func regionAllowed(name string, regions []gobizfly.UserRegion) bool {
+	for _, region := range regions {
+		if strings.EqualFold(name, region.Code) || strings.EqualFold(name, region.ShortName) ||
+			strings.EqualFold(name, region.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestRegion returns the code of the user region closest to name, or an
+// empty string if none of them is close enough to be a likely typo.
+func suggestRegion(name string, regions []gobizfly.UserRegion) string {
+	name = strings.ToLower(strings.ReplaceAll(name, " ", ""))
+	suggestion := ""
+	best := len(name)/3 + 1
+	for _, region := range regions {
+		for _, candidate := range []string{region.Code, region.ShortName, region.Name} {
+			candidate = strings.ToLower(strings.ReplaceAll(candidate, " ", ""))
+			if candidate == "" {
+				continue
+			}
+			if distance := levenshtein.Distance(name, candidate, nil); distance < best {
+				best = distance
+				suggestion = region.Code
+			}
+		}
+	}
+	return suggestion
+}
@@ -0,0 +1,117 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfigClient_Region(t *testing.T) {
+	api := newFakeBizflyAPI(t)
+
+	cases := []struct {
+		region     string
+		wantErr    bool
+		suggestion string
+	}{
+		{region: "HaNoi"},
+		{region: "Hanoi"},
+		{region: "HCM"},
+		{region: "Ha Noi", wantErr: true, suggestion: "HaNoi"},
+		{region: "HoChiMin", wantErr: true, suggestion: "HoChiMinh"},
+		{region: "VC-HaNoi", wantErr: true},
+		{region: "Singapore", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.region, func(t *testing.T) {
+			config := Config{
+				APIEndpoint: api.URL + "/api",
+				AuthMethod:  authMethodPassword,
+				Email:       fakeAPIEmail,
+				Password:    fakeAPIPassword,
+				RegionName:  tc.region,
+				ProjectID:   fakeAPIProjectID,
+			}
+			client, err := config.Client(context.Background())
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if client.gobizflyClient() == nil {
+					t.Fatal("expected a configured client")
+				}
+				return
+			}
+			var regionErr *RegionError
+			if !errors.As(err, &regionErr) {
+				t.Fatalf("expected a RegionError, got %v", err)
+			}
+			if regionErr.Suggestion != tc.suggestion {
+				t.Errorf("expected suggestion %q, got %q", tc.suggestion, regionErr.Suggestion)
+			}
+			if msg := err.Error(); !strings.Contains(msg, "HaNoi (HN)") || !strings.Contains(msg, "HoChiMinh (HCM)") {
+				t.Errorf("expected the allowed regions to be listed, got %q", msg)
+			}
+		})
+	}
+}
+
+func TestConfigClient_AuthMethod(t *testing.T) {
+	cases := []struct {
+		name      string
+		config    Config
+		attribute string
+	}{
+		{
+			name:      "unknown method",
+			config:    Config{AuthMethod: "token", Email: fakeAPIEmail, Password: fakeAPIPassword},
+			attribute: "auth_method",
+		},
+		{
+			name:      "missing password",
+			config:    Config{AuthMethod: authMethodPassword, Email: fakeAPIEmail},
+			attribute: "email",
+		},
+		{
+			name:      "missing application credential secret",
+			config:    Config{AuthMethod: authMethodApplicationCredential, AppCredentialID: "app-id"},
+			attribute: "application_credential_id",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// No API endpoint is configured: validation must fail before any
+			// request is sent.
+			_, err := tc.config.Client(context.Background())
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("expected a ConfigError, got %v", err)
+			}
+			if configErr.Attribute != tc.attribute {
+				t.Errorf("expected attribute %q, got %q", tc.attribute, configErr.Attribute)
+			}
+			diags := configureDiagnostics(err)
+			if !diags.HasError() || diags[0].AttributePath == nil {
+				t.Errorf("expected an error diagnostic with an attribute path, got %#v", diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultProfile               = "default"
+	defaultSharedCredentialsFile = "~/.bizflycloud/credentials"
+	defaultAPIEndpoint           = "https://manage.bizflycloud.vn/api"
+)
+
+// profileKeys are the provider arguments a credentials profile may set.
+var profileKeys = []string{
+	"api_endpoint",
+	"auth_method",
+	"email",
+	"password",
+	"application_credential_id",
+	"application_credential_secret",
+	"region_name",
+	"project_id",
+}
+
+// errProfileNotFound is returned when the credentials file or the requested
+// profile does not exist.
+var errProfileNotFound = errors.New("profile not found")
+
+// loadCredentialsProfile reads the named profile from an INI style shared
+// credentials file:
+//
+//	[default]
+//	auth_method = password
+//	email       = user@example.com
+//	password    = secret
+//	region_name = HaNoi
+//
+// Keys are provider argument names. Lines starting with '#' or ';' are
+// comments.
+func loadCredentialsProfile(path, profile string) (map[string]string, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("shared credentials file %s does not exist: %w", path, errProfileNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening shared credentials file: %w", err)
+	}
+	defer f.Close()
+
+	profiles := make(map[string]map[string]string)
+	var section map[string]string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+			continue
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if profiles[name] == nil {
+				profiles[name] = make(map[string]string)
+			}
+			section = profiles[name]
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok || section == nil {
+			return nil, fmt.Errorf("%s:%d: expected a [profile] header or a key = value pair", path, line)
+		}
+		key = strings.TrimSpace(key)
+		if !isProfileKey(key) {
+			return nil, fmt.Errorf("%s:%d: unknown key %q, expected one of: %s",
+				path, line, key, strings.Join(profileKeys, ", "))
+		}
+		section[key] = unquote(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading shared credentials file: %w", err)
+	}
+
+	values, ok := profiles[profile]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("profile %q not found in %s (available profiles: %s): %w",
+			profile, path, strings.Join(names, ", "), errProfileNotFound)
+	}
+	return values, nil
+}
+
+func isProfileKey(key string) bool {
+	for _, k := range profileKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error expanding %s: %w", path, err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
@@ -0,0 +1,159 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const testCredentialsFile = `
+# Shared credentials for tests
+[default]
+auth_method = password
+email       = default@example.com
+password    = "default-password"
+region_name = HaNoi
+
+[staging]
+auth_method                   = application_credential
+application_credential_id     = staging-id
+application_credential_secret = 'staging-secret'
+region_name                   = HoChiMinh
+project_id                    = staging-project
+api_endpoint                  = https://staging.example.com/api
+`
+
+func writeTestCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+
+	profile, err := loadCredentialsProfile(path, "staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"auth_method":                   "application_credential",
+		"application_credential_id":     "staging-id",
+		"application_credential_secret": "staging-secret",
+		"region_name":                   "HoChiMinh",
+		"project_id":                    "staging-project",
+		"api_endpoint":                  "https://staging.example.com/api",
+	}
+	for k, v := range expected {
+		if profile[k] != v {
+			t.Errorf("expected %s = %q, got %q", k, v, profile[k])
+		}
+	}
+
+	_, err = loadCredentialsProfile(path, "production")
+	if !errors.Is(err, errProfileNotFound) || !strings.Contains(err.Error(), "default, staging") {
+		t.Errorf("expected a profile not found error listing the profiles, got %v", err)
+	}
+	_, err = loadCredentialsProfile(filepath.Join(t.TempDir(), "missing"), defaultProfile)
+	if !errors.Is(err, errProfileNotFound) {
+		t.Errorf("expected a profile not found error for a missing file, got %v", err)
+	}
+	_, err = loadCredentialsProfile(writeTestCredentialsFile(t, "[default]\nusername = me\n"), defaultProfile)
+	if err == nil || !strings.Contains(err.Error(), `unknown key "username"`) {
+		t.Errorf("expected an unknown key error, got %v", err)
+	}
+}
+
+func TestProviderConfig_Precedence(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+	for _, env := range []string{
+		"BIZFLYCLOUD_API_ENDPOINT", "BIZFLYCLOUD_AUTH_METHOD", "BIZFLYCLOUD_EMAIL", "BIZFLYCLOUD_PASSWORD",
+		"BIZFLYCLOUD_APPLICATION_CREDENTIAL_ID", "BIZFLYCLOUD_APPLICATION_CREDENTIAL_SECRET",
+		"BIZFLYCLOUD_REGION_NAME", "BIZFLYCLOUD_PROJECT_ID", "BIZFLYCLOUD_PROFILE",
+	} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("BIZFLYCLOUD_SHARED_CREDENTIALS_FILE", path)
+
+	t.Run("default profile", func(t *testing.T) {
+		config := testProviderConfig(t, map[string]interface{}{})
+		if config.Email != "default@example.com" || config.Password != "default-password" {
+			t.Errorf("expected credentials from the default profile, got %q/%q", config.Email, config.Password)
+		}
+		if config.APIEndpoint != defaultAPIEndpoint {
+			t.Errorf("expected the built-in API endpoint, got %q", config.APIEndpoint)
+		}
+	})
+
+	t.Run("environment over profile", func(t *testing.T) {
+		t.Setenv("BIZFLYCLOUD_PROFILE", "staging")
+		t.Setenv("BIZFLYCLOUD_REGION_NAME", "HN")
+		config := testProviderConfig(t, map[string]interface{}{})
+		if config.AppCredentialID != "staging-id" || config.ProjectID != "staging-project" {
+			t.Errorf("expected settings from the staging profile, got %+v", config)
+		}
+		if config.RegionName != "HN" {
+			t.Errorf("expected the region from the environment, got %q", config.RegionName)
+		}
+	})
+
+	t.Run("argument over environment", func(t *testing.T) {
+		t.Setenv("BIZFLYCLOUD_REGION_NAME", "HN")
+		config := testProviderConfig(t, map[string]interface{}{
+			"profile":     "staging",
+			"region_name": "HCM",
+		})
+		if config.RegionName != "HCM" {
+			t.Errorf("expected the region from the argument, got %q", config.RegionName)
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"profile": "production"})
+		if _, diags := providerConfig(d, "0.12+compatible"); !diags.HasError() {
+			t.Error("expected an error for an unknown profile")
+		}
+	})
+
+	t.Run("no credentials file", func(t *testing.T) {
+		t.Setenv("BIZFLYCLOUD_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "missing"))
+		config := testProviderConfig(t, map[string]interface{}{"email": "me@example.com"})
+		if config.Email != "me@example.com" || config.AuthMethod != authMethodPassword || config.RegionName != defaultRegionName {
+			t.Errorf("expected the argument and built-in defaults, got %+v", config)
+		}
+	})
+}
+
+func testProviderConfig(t *testing.T, raw map[string]interface{}) Config {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+	config, diags := providerConfig(d, "0.12+compatible")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diagnosticsError(diags))
+	}
+	return config
+}
@@ -2,15 +2,15 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudContainerRegistry() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBizflyCloudContainerRegistryRead,
+		ReadContext: dataSourceBizflyCloudContainerRegistryRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -24,31 +24,31 @@ func dataSourceBizflyCloudContainerRegistry() *schema.Resource {
 	}
 }
 
-func dataSourceBizflyCloudContainerRegistryRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*gobizfly.Client)
+func dataSourceBizflyCloudContainerRegistryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
 	name := d.Get("name").(string)
 
 	opts := &gobizfly.ListOptions{}
-	registries, err := client.ContainerRegistry.List(context.Background(), opts)
+	registries, err := client.ContainerRegistry.List(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("error retrieving container registries: %v", err)
+		return diag.Errorf("error retrieving container registries: %v", err)
 	}
 
 	for _, registry := range registries {
 		if registry.Name == name {
 			d.SetId(registry.Name)
 			if err := d.Set("name", registry.Name); err != nil {
-				return fmt.Errorf("error setting name: %v", err)
+				return diag.Errorf("error setting name: %v", err)
 			}
 			if err := d.Set("public", registry.Public); err != nil {
-				return fmt.Errorf("error setting public: %v", err)
+				return diag.Errorf("error setting public: %v", err)
 			}
 			if err := d.Set("created_at", registry.CreatedAt); err != nil {
-				return fmt.Errorf("error setting created_at: %v", err)
+				return diag.Errorf("error setting created_at: %v", err)
 			}
 			return nil
 		}
 	}
 
-	return fmt.Errorf("no container registry found with name: %s", name)
+	return diag.Errorf("no container registry found with name: %s", name)
 }
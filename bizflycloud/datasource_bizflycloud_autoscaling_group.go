@@ -19,21 +19,21 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudAutoScalingGroup() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudAutoScalingGroupRead,
-		Schema: dataAutoScalingGroupSchema(),
+		ReadContext: dataSourceBizflyCloudAutoScalingGroupRead,
+		Schema:      dataAutoScalingGroupSchema(),
 	}
 }
 
-func dataSourceBizflyCloudAutoScalingGroupRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudAutoScalingGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	if v, ok := d.GetOk("id"); ok {
@@ -43,16 +43,21 @@ func dataSourceBizflyCloudAutoScalingGroupRead(d *schema.ResourceData, meta inte
 	groupID := d.Id()
 
 	log.Printf("[DEBUG] Reading Autoscaling Group: %s", groupID)
-	group, err := client.AutoScaling.AutoScalingGroups().Get(context.Background(), groupID)
+	group, err := client.AutoScaling.AutoScalingGroups().Get(ctx, groupID)
 
 	log.Printf("[DEBUG] Checking for error: %s", err)
 	if err != nil {
-		return fmt.Errorf("error describing AutoScaling Groups: %w", err)
+		return diag.Errorf("error describing AutoScaling Groups: %v", err)
 	}
 
 	log.Printf("[DEBUG] Found Autoscaling Group: %s", groupID)
 	log.Printf("[DEBUG] bizflycloud_autoscaling_group - Single Auto Scaling Group found: %s", group.Name)
 
+	return setAutoScalingGroupAttributes(d, group)
+}
+
+// setAutoScalingGroupAttributes copies the autoscaling group into d.
+func setAutoScalingGroupAttributes(d *schema.ResourceData, group *gobizfly.AutoScalingGroup) diag.Diagnostics {
 	d.SetId(group.ID)
 	_ = d.Set("desired_capacity", group.DesiredCapacity)
 	_ = d.Set("launch_configuration_id", group.ProfileID)
@@ -64,7 +69,7 @@ func dataSourceBizflyCloudAutoScalingGroupRead(d *schema.ResourceData, meta inte
 	_ = d.Set("status", group.Status)
 
 	if err := d.Set("load_balancers", readLoadBalancerInformation(group.LoadBalancerPolicies)); err != nil {
-		return fmt.Errorf("error setting load_balancers: %w", err)
+		return diag.Errorf("error setting load_balancers: %v", err)
 	}
 
 	return nil
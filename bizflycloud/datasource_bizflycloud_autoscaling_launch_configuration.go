@@ -19,21 +19,21 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudLaunchConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataBizflyCloudAutoScalingLaunchConfigurationRead,
-		Schema: dataLaunchConfigurationSchema(),
+		ReadContext: dataBizflyCloudAutoScalingLaunchConfigurationRead,
+		Schema:      dataLaunchConfigurationSchema(),
 	}
 }
 
-func dataBizflyCloudAutoScalingLaunchConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+func dataBizflyCloudAutoScalingLaunchConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	if v, ok := d.GetOk("id"); ok {
@@ -43,16 +43,21 @@ func dataBizflyCloudAutoScalingLaunchConfigurationRead(d *schema.ResourceData, m
 
 	log.Printf("[DEBUG] Reading Launch Configuration: %s", profileID)
 
-	profile, err := client.AutoScaling.LaunchConfigurations().Get(context.Background(), profileID)
+	profile, err := client.AutoScaling.LaunchConfigurations().Get(ctx, profileID)
 
 	log.Printf("[DEBUG] Checking for error: %s", err)
 	if err != nil {
-		return fmt.Errorf("error describing AutoScaling Groups: %w", err)
+		return diag.Errorf("error describing AutoScaling Groups: %v", err)
 	}
 
 	log.Printf("[DEBUG] Found Launch Configuration: %s", profileID)
 	log.Printf("[DEBUG] bizflycloud_autoscaling_launch_configuration - Single Launch Configuration found: %s", profile.Name)
 
+	return setLaunchConfigurationAttributes(d, profile)
+}
+
+// setLaunchConfigurationAttributes copies the launch configuration into d.
+func setLaunchConfigurationAttributes(d *schema.ResourceData, profile *gobizfly.LaunchConfiguration) diag.Diagnostics {
 	d.SetId(profile.ID)
 	_ = d.Set("availability_zone", profile.AvailabilityZone)
 	_ = d.Set("flavor", profile.Flavor)
@@ -64,19 +69,19 @@ func dataBizflyCloudAutoScalingLaunchConfigurationRead(d *schema.ResourceData, m
 	_ = d.Set("user_data", profile.UserData)
 
 	if err := d.Set("data_disks", getDataDisks(profile.DataDisks)); err != nil {
-		return fmt.Errorf("error setting data_disks: %w", err)
+		return diag.Errorf("error setting data_disks: %v", err)
 	}
 
 	if err := d.Set("networks", getNetworks(profile.Networks)); err != nil {
-		return fmt.Errorf("error setting networks: %w", err)
+		return diag.Errorf("error setting networks: %v", err)
 	}
 
 	if err := d.Set("os", getOperatingSystem(profile.OperatingSystem)); err != nil {
-		return fmt.Errorf("error setting os: %w", err)
+		return diag.Errorf("error setting os: %v", err)
 	}
 
 	if err := d.Set("rootdisk", getRootDisk(profile.RootDisk)); err != nil {
-		return fmt.Errorf("error setting rootdisk: %w", err)
+		return diag.Errorf("error setting rootdisk: %v", err)
 	}
 
 	return nil
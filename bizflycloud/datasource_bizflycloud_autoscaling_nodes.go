@@ -19,14 +19,14 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudAutoscalingNodes() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBizflyCloudNodesRead,
+		ReadContext: dataSourceBizflyCloudNodesRead,
 		Schema: map[string]*schema.Schema{
 			"cluster_id": {
 				Type:     schema.TypeString,
@@ -44,12 +44,12 @@ func datasourceBizflyCloudAutoscalingNodes() *schema.Resource {
 	}
 }
 
-func dataSourceBizflyCloudNodesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudNodesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID, okID := d.GetOk("cluster_id")
-	osNodes, err := client.AutoScaling.Nodes().List(context.Background(), clusterID.(string), true)
+	osNodes, err := client.AutoScaling.Nodes().List(ctx, clusterID.(string), true)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if okID {
@@ -70,7 +70,7 @@ func dataSourceBizflyCloudNodesRead(d *schema.ResourceData, meta interface{}) er
 		d.SetId(clusterID.(string))
 		_ = d.Set("nodes", nodesResult)
 	} else {
-		return fmt.Errorf("nodes ID must be set")
+		return diag.Errorf("nodes ID must be set")
 	}
 
 	return nil
@@ -0,0 +1,62 @@
+package bizflycloud
+
+import (
+	"context"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBizflyCloudCDN() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBizflyCloudCDNRead,
+		Schema:      dataCDNSchema(),
+	}
+}
+
+func dataSourceBizflyCloudCDNRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+
+	domainID := d.Get("domain_id").(string)
+	if domainID == "" {
+		name := d.Get("domain").(string)
+		var err error
+		domainID, err = findCDNDomainID(ctx, client, name)
+		if err != nil {
+			return diag.Errorf("error listing cdn domains: %v", err)
+		}
+		if domainID == "" {
+			return diag.Errorf("no cdn domain found with domain %s", name)
+		}
+	}
+
+	domain, err := getCDNDomain(ctx, client, domainID)
+	if err != nil {
+		return diag.Errorf("error when get cdn resource %s: %v", domainID, err)
+	}
+	d.SetId(domain.DomainID)
+	if err := setCDNDomainAttributes(d, domain); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// findCDNDomainID looks the domain up through every page of the CDN domains
+// and returns its ID, or an empty string if there is no such domain.
+func findCDNDomainID(ctx context.Context, client *gobizfly.Client, name string) (string, error) {
+	for page := 1; ; page++ {
+		domains, err := client.CDN.List(ctx, &gobizfly.ListOptions{Page: page, Limit: 50})
+		if err != nil {
+			return "", err
+		}
+		for _, domain := range domains.Domains {
+			if dnsNameEqual(domain.Domain, name) {
+				return domain.DomainID, nil
+			}
+		}
+		if page >= domains.Pages {
+			return "", nil
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package bizflycloud
+
+import "testing"
+
+func TestBizflyCloudCDNDataSource_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+
+	domain := testResourceCreate(t, resourceBizflyCloudCDN(), map[string]interface{}{
+		"domain": "static.example.vn",
+		"origin": []interface{}{map[string]interface{}{
+			"upstream_addrs": "203.0.113.10",
+			"upstream_host":  "static.example.vn",
+		}},
+	}, meta)
+	testResourceCreate(t, resourceBizflyCloudCDN(), map[string]interface{}{
+		"domain": "other.example.vn",
+		"origin": []interface{}{map[string]interface{}{
+			"upstream_addrs": "203.0.113.20",
+		}},
+	}, meta)
+
+	byName := testDataSourceRead(t, dataSourceBizflyCloudCDN(), map[string]interface{}{
+		"domain": "static.example.vn",
+	}, meta)
+	if byName.Id() != domain.Id() {
+		t.Errorf("expected cdn domain %s, got %s", domain.Id(), byName.Id())
+	}
+	testCheckResourceDataAttr(t, byName, "domain_cdn", domain.Id()+".cdn.bizflycloud.vn")
+	testCheckResourceDataAttr(t, byName, "origin.0.upstream_addrs", "203.0.113.10")
+
+	byID := testDataSourceRead(t, dataSourceBizflyCloudCDN(), map[string]interface{}{
+		"domain_id": domain.Id(),
+	}, meta)
+	testCheckResourceDataAttr(t, byID, "domain", "static.example.vn")
+
+	r := dataSourceBizflyCloudCDN()
+	d := r.TestResourceData()
+	_ = d.Set("domain", "missing.example.vn")
+	if diags := r.ReadContext(testContext(), d, meta); !diags.HasError() {
+		t.Error("expected an error looking up a missing cdn domain")
+	}
+}
@@ -19,25 +19,26 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudDatabaseDatastore() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudDatabaseDatastoreRead,
-		Schema: dataCloudDatabaseDatastoreSchema(),
+		ReadContext: dataSourceBizflyCloudDatabaseDatastoreRead,
+		Schema:      dataCloudDatabaseDatastoreSchema(),
 	}
 }
 
-func dataSourceBizflyCloudDatabaseDatastoreRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudDatabaseDatastoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	log.Println("[DEBUG] Reading list datastore")
-	engines, err := client.CloudDatabase.Engines().List(context.Background())
+	engines, err := client.CloudDatabase.Engines().List(ctx)
 	if err != nil {
-		return fmt.Errorf("error describing datastore: %w", err)
+		return diag.Errorf("error describing datastore: %v", err)
 	}
 
 	dsType := d.Get("type").(string)
@@ -19,19 +19,21 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudDatabaseBackup() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudDatabaseBackupRead,
-		Schema: dataCloudDatabaseBackupSchema(),
+		ReadContext: dataSourceBizflyCloudDatabaseBackupRead,
+		Schema:      dataCloudDatabaseBackupSchema(),
 	}
 }
 
-func dataSourceBizflyCloudDatabaseBackupRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudDatabaseBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	if v, ok := d.GetOk("id"); ok {
@@ -41,16 +43,21 @@ func dataSourceBizflyCloudDatabaseBackupRead(d *schema.ResourceData, meta interf
 	backupID := d.Id()
 
 	log.Printf("[DEBUG] Reading database backup: %s", backupID)
-	backup, err := client.CloudDatabase.Backups().Get(context.Background(), backupID)
+	backup, err := client.CloudDatabase.Backups().Get(ctx, backupID)
 
 	log.Printf("[DEBUG] Checking for error: %s", err)
 	if err != nil {
-		return fmt.Errorf("error describing database backup: %w", err)
+		return diag.Errorf("error describing database backup: %v", err)
 	}
 
 	log.Printf("[DEBUG] Found database backup: %s", backupID)
 	log.Printf("[DEBUG] bizflycloud_cloud_database_backup - Single database backup found: %s", backup.Name)
 
+	return setCloudDatabaseBackupAttributes(d, backup)
+}
+
+// setCloudDatabaseBackupAttributes copies the backup into d.
+func setCloudDatabaseBackupAttributes(d *schema.ResourceData, backup *gobizfly.CloudDatabaseBackup) diag.Diagnostics {
 	d.SetId(backup.ID)
 	_ = d.Set("created", backup.Created)
 	_ = d.Set("description", backup.Description)
@@ -63,7 +70,7 @@ func dataSourceBizflyCloudDatabaseBackupRead(d *schema.ResourceData, meta interf
 	_ = d.Set("updated", backup.Updated)
 
 	if err := d.Set("datastore", FlattenStruct(backup.Datastore)); err != nil {
-		return fmt.Errorf("error setting datastore for backup %s: %s", d.Id(), err)
+		return diag.Errorf("error setting datastore for backup %s: %s", d.Id(), err)
 	}
 	return nil
 }
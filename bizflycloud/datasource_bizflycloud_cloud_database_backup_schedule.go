@@ -19,13 +19,14 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func dataSourceBizflyCloudDatabaseBackupScheduleRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudDatabaseBackupScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	if v, ok := d.GetOk("id"); ok {
@@ -35,16 +36,21 @@ func dataSourceBizflyCloudDatabaseBackupScheduleRead(d *schema.ResourceData, met
 	scheduleID := d.Id()
 
 	log.Printf("[DEBUG] Reading database schedule: %s", scheduleID)
-	schedule, err := client.CloudDatabase.BackupSchedules().Get(context.Background(), scheduleID)
+	schedule, err := client.CloudDatabase.BackupSchedules().Get(ctx, scheduleID)
 
 	log.Printf("[DEBUG] Checking for error: %s", err)
 	if err != nil {
-		return fmt.Errorf("error describing database schedule: %w", err)
+		return diag.Errorf("error describing database schedule: %v", err)
 	}
 
 	log.Printf("[DEBUG] Found database backup schedule: %s", scheduleID)
 	log.Printf("[DEBUG] bizflycloud_cloud_database_backup_schedule - Single database backup schedule found: %s", schedule.Name)
 
+	return setCloudDatabaseBackupScheduleAttributes(d, schedule)
+}
+
+// setCloudDatabaseBackupScheduleAttributes copies the backup schedule into d.
+func setCloudDatabaseBackupScheduleAttributes(d *schema.ResourceData, schedule *gobizfly.CloudDatabaseBackupSchedule) diag.Diagnostics {
 	d.SetId(schedule.ID)
 	_ = d.Set("cron_expression", schedule.CronExpression)
 	_ = d.Set("first_execution_time", schedule.FirstExecutionTime)
@@ -20,21 +20,21 @@ package bizflycloud
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudDatabaseInstance() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudDatabaseInstanceRead,
-		Schema: dataCloudDatabaseInstanceSchema(),
+		ReadContext: dataSourceBizflyCloudDatabaseInstanceRead,
+		Schema:      dataCloudDatabaseInstanceSchema(),
 	}
 }
 
-func dataSourceBizflyCloudDatabaseInstanceRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudDatabaseInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	if v, ok := d.GetOk("id"); ok {
@@ -44,16 +44,21 @@ func dataSourceBizflyCloudDatabaseInstanceRead(d *schema.ResourceData, meta inte
 	instanceID := d.Id()
 
 	log.Printf("[DEBUG] Reading database instance: %s", instanceID)
-	instance, err := client.CloudDatabase.Instances().Get(context.Background(), instanceID)
+	instance, err := client.CloudDatabase.Instances().Get(ctx, instanceID)
 
 	log.Printf("[DEBUG] Checking for error: %s", err)
 	if err != nil {
-		return fmt.Errorf("error describing database Instance: %w", err)
+		return diag.Errorf("error describing database Instance: %v", err)
 	}
 
 	log.Printf("[DEBUG] Found database Instance: %s", instanceID)
 	log.Printf("[DEBUG] bizflycloud_cloud_database_instance - Single database Instance found: %s", instance.Name)
 
+	return setCloudDatabaseInstanceAttributes(d, instance)
+}
+
+// setCloudDatabaseInstanceAttributes copies the instance into d.
+func setCloudDatabaseInstanceAttributes(d *schema.ResourceData, instance *gobizfly.CloudDatabaseInstance) diag.Diagnostics {
 	d.SetId(instance.ID)
 	_ = d.Set("created_at", instance.CreatedAt)
 	_ = d.Set("enable_failover", instance.EnableFailover)
@@ -64,19 +69,19 @@ func dataSourceBizflyCloudDatabaseInstanceRead(d *schema.ResourceData, meta inte
 	_ = d.Set("volume_size", instance.Volume.Size)
 
 	if err := d.Set("autoscaling", readDataCloudDatabaseAutoScaling(instance.AutoScaling)); err != nil {
-		return fmt.Errorf("error setting autoscaling: %w", err)
+		return diag.Errorf("error setting autoscaling: %v", err)
 	}
 
 	if err := d.Set("datastore", FlattenStruct(instance.Datastore)); err != nil {
-		return fmt.Errorf("error setting datastore: %w", err)
+		return diag.Errorf("error setting datastore: %v", err)
 	}
 
 	if err := d.Set("dns", FlattenStruct(instance.DNS)); err != nil {
-		return fmt.Errorf("error setting dns: %w", err)
+		return diag.Errorf("error setting dns: %v", err)
 	}
 
 	if err := d.Set("nodes", readNodes(instance.Nodes)); err != nil {
-		return fmt.Errorf("error setting nodes: %w", err)
+		return diag.Errorf("error setting nodes: %v", err)
 	}
 	return nil
 }
@@ -19,21 +19,21 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
-	"github.com/bizflycloud/gobizfly"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudDatabaseNode() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudDatabaseNodeRead,
-		Schema: dataCloudDatabaseNodeSchema(),
+		ReadContext: dataSourceBizflyCloudDatabaseNodeRead,
+		Schema:      dataCloudDatabaseNodeSchema(),
 	}
 }
 
-func dataSourceBizflyCloudDatabaseNodeRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudDatabaseNodeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	if v, ok := d.GetOk("id"); ok {
@@ -43,11 +43,11 @@ func dataSourceBizflyCloudDatabaseNodeRead(d *schema.ResourceData, meta interfac
 	nodeID := d.Id()
 
 	log.Printf("[DEBUG] Reading database node: %s", nodeID)
-	node, err := client.CloudDatabase.Nodes().Get(context.Background(), nodeID)
+	node, err := client.CloudDatabase.Nodes().Get(ctx, nodeID)
 
 	log.Printf("[DEBUG] Checking for error: %s", err)
 	if err != nil {
-		return fmt.Errorf("error describing database node: %w", err)
+		return diag.Errorf("error describing database node: %v", err)
 	}
 
 	log.Printf("[DEBUG] Found database node: %s", nodeID)
@@ -80,23 +80,23 @@ func dataSourceBizflyCloudDatabaseNodeRead(d *schema.ResourceData, meta interfac
 	}
 
 	if err := d.Set("dns", FlattenStruct(node.DNS)); err != nil {
-		return fmt.Errorf("error setting dns for node %s: %s", d.Id(), err)
+		return diag.Errorf("error setting dns for node %s: %s", d.Id(), err)
 	}
 
 	if err := d.Set("datastore", FlattenStruct(node.Datastore)); err != nil {
-		return fmt.Errorf("error setting datastore for node %s: %s", d.Id(), err)
+		return diag.Errorf("error setting datastore for node %s: %s", d.Id(), err)
 	}
 
 	addresses := map[string]interface{}{}
 	readNodeAddresses(addresses, node.Addresses)
 	if err := d.Set("private_addresses", addresses["private_addresses"]); err != nil {
-		return fmt.Errorf("error setting private_addresses for node %s: %s", d.Id(), err)
+		return diag.Errorf("error setting private_addresses for node %s: %s", d.Id(), err)
 	}
 	if err := d.Set("public_addresses", addresses["public_addresses"]); err != nil {
-		return fmt.Errorf("error setting public_addresses for node %s: %s", d.Id(), err)
+		return diag.Errorf("error setting public_addresses for node %s: %s", d.Id(), err)
 	}
 	if err := d.Set("port_access", addresses["port_access"]); err != nil {
-		return fmt.Errorf("error setting port_access for node %s: %s", d.Id(), err)
+		return diag.Errorf("error setting port_access for node %s: %s", d.Id(), err)
 	}
 
 	return nil
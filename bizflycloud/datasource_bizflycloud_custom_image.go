@@ -2,7 +2,9 @@ package bizflycloud
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudCustomImage() *schema.Resource {
@@ -33,37 +35,37 @@ func dataSourceBizflyCloudCustomImage() *schema.Resource {
 				Computed: true,
 			},
 		},
-		Read: dataSourceBizflyCloudCustomImageRead,
+		ReadContext: dataSourceBizflyCloudCustomImageRead,
 	}
 }
 
-func dataSourceBizflyCloudCustomImageRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudCustomImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	resp, err := client.CloudServer.CustomImages().Get(context.Background(), d.Get("id").(string))
+	resp, err := client.CloudServer.CustomImages().Get(ctx, d.Get("id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	customImage := resp.Image
 	d.SetId(customImage.ID)
 	err = d.Set("name", customImage.Name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("size", customImage.Size)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("disk_format", customImage.DiskFormat)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("container_format", customImage.ContainerFormat)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("billing_plan", customImage.BillingPlan)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
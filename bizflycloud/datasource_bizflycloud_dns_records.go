@@ -0,0 +1,58 @@
+package bizflycloud
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBizflyCloudDNSRecords() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBizflyCloudDNSRecordsRead,
+		Schema:      dataDNSRecordsSchema(),
+	}
+}
+
+func dataSourceBizflyCloudDNSRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	zoneID := d.Get("zone_id").(string)
+	zone, err := client.DNS.GetZone(ctx, zoneID)
+	if err != nil {
+		return diag.Errorf("error getting dns zone %s: %v", zoneID, err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	recordType := d.Get("type").(string)
+
+	records := make([]map[string]interface{}, 0)
+	for _, record := range zone.RecordsSet {
+		if recordType != "" && !strings.EqualFold(record.Type, recordType) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(record.Name) {
+			continue
+		}
+		data, priority, weight, port := flattenDNSRecordData(record.Type, record.Data)
+		records = append(records, map[string]interface{}{
+			"id":       record.ID,
+			"name":     record.Name,
+			"type":     record.Type,
+			"ttl":      record.TTL,
+			"data":     data,
+			"priority": priority,
+			"weight":   weight,
+			"port":     port,
+		})
+	}
+	d.SetId(zone.ID)
+	if err := d.Set("records", records); err != nil {
+		return diag.Errorf("error setting records: %v", err)
+	}
+	return nil
+}
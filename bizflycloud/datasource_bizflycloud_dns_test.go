@@ -0,0 +1,93 @@
+package bizflycloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestBizflyCloudDNSDataSources_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+
+	zone := testResourceCreate(t, resourceBizflyCloudDNS(), map[string]interface{}{
+		"name": "example.vn",
+	}, meta)
+	testResourceCreate(t, resourceBizflyCloudDNS(), map[string]interface{}{
+		"name": "other.vn",
+	}, meta)
+	for _, record := range []map[string]interface{}{
+		{"name": "www", "type": "A", "data": []interface{}{"192.0.2.10"}},
+		{"name": "api", "type": "A", "data": []interface{}{"192.0.2.20"}},
+		{"name": "mail", "type": "MX", "data": []interface{}{"mx1.example.vn"}, "priority": 10},
+	} {
+		record["zone_id"] = zone.Id()
+		testResourceCreate(t, resourceBizflyCloudDNSRecord(), record, meta)
+	}
+
+	byName := testDataSourceRead(t, dataSourceBizflyCloudDNSZone(), map[string]interface{}{
+		"name": "example.vn.",
+	}, meta)
+	if byName.Id() != zone.Id() {
+		t.Errorf("expected dns zone %s, got %s", zone.Id(), byName.Id())
+	}
+	testCheckResourceDataAttr(t, byName, "nameserver.#", 2)
+	testCheckResourceDataAttr(t, byName, "record_set.#", 3)
+
+	byID := testDataSourceRead(t, dataSourceBizflyCloudDNSZone(), map[string]interface{}{
+		"id": zone.Id(),
+	}, meta)
+	testCheckResourceDataAttr(t, byID, "name", "example.vn")
+
+	all := testDataSourceRead(t, dataSourceBizflyCloudDNSRecords(), map[string]interface{}{
+		"zone_id": zone.Id(),
+	}, meta)
+	testCheckResourceDataAttr(t, all, "records.#", 3)
+
+	filtered := testDataSourceRead(t, dataSourceBizflyCloudDNSRecords(), map[string]interface{}{
+		"zone_id":    zone.Id(),
+		"type":       "A",
+		"name_regex": "^w",
+	}, meta)
+	testCheckResourceDataAttr(t, filtered, "records.#", 1)
+	testCheckResourceDataAttr(t, filtered, "records.0.name", "www")
+	testCheckResourceDataAttr(t, filtered, "records.0.data.0", "192.0.2.10")
+
+	mx := testDataSourceRead(t, dataSourceBizflyCloudDNSRecords(), map[string]interface{}{
+		"zone_id": zone.Id(),
+		"type":    "MX",
+	}, meta)
+	testCheckResourceDataAttr(t, mx, "records.#", 1)
+	testCheckResourceDataAttr(t, mx, "records.0.priority", 10)
+}
+
+func TestBizflyCloudDNSZoneDataSource_FakeAPINotFound(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+
+	r := dataSourceBizflyCloudDNSZone()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "missing.vn"})
+	if diags := r.ReadContext(testContext(), d, meta); !diags.HasError() {
+		t.Error("expected an error reading a dns zone which does not exist")
+	}
+}
+
+func TestBizflyCloudDNSZoneDataSource_FakeAPIBeyondFirstPage(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	api.zonesPerPage = 1
+
+	testResourceCreate(t, resourceBizflyCloudDNS(), map[string]interface{}{"name": "first.vn"}, meta)
+	second := testResourceCreate(t, resourceBizflyCloudDNS(), map[string]interface{}{"name": "second.vn"}, meta)
+
+	d := testDataSourceRead(t, dataSourceBizflyCloudDNSZone(), map[string]interface{}{"name": "second.vn"}, meta)
+	if d.Id() != second.Id() {
+		t.Errorf("expected the zone on the second page %s to be found, got %q", second.Id(), d.Id())
+	}
+	if n := api.requestCount("GET", "/dns/zones"); n < 2 {
+		t.Errorf("expected the zones to be listed page by page, got %d requests", n)
+	}
+}
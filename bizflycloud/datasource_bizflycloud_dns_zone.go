@@ -0,0 +1,110 @@
+package bizflycloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBizflyCloudDNSZone() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBizflyCloudDNSZoneRead,
+		Schema:      dataDNSZoneSchema(),
+	}
+}
+
+func dataSourceBizflyCloudDNSZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+
+	zoneID := d.Get("id").(string)
+	if zoneID == "" {
+		name := d.Get("name").(string)
+		id, err := findDNSZoneID(ctx, client, name)
+		if err != nil {
+			return diag.Errorf("error looking up dns zone %s: %v", name, err)
+		}
+		if id == "" {
+			return diag.Errorf("no dns zone found with name %s", name)
+		}
+		zoneID = id
+	}
+
+	zone, err := client.DNS.GetZone(ctx, zoneID)
+	if err != nil {
+		return diag.Errorf("error getting dns zone %s: %v", zoneID, err)
+	}
+	d.SetId(zone.ID)
+	_ = d.Set("name", zone.Name)
+	_ = d.Set("active", zone.Active)
+	_ = d.Set("ttl", zone.TTL)
+	_ = d.Set("tenant_id", zone.TenantID)
+	_ = d.Set("deleted", zone.Deleted)
+	_ = d.Set("created_at", zone.CreatedAt)
+	_ = d.Set("updated_at", zone.UpdatedAt)
+
+	if err := d.Set("nameserver", readNameServer(zone.NameServer)); err != nil {
+		return diag.Errorf("error setting nameserver: %v", err)
+	}
+	if err := d.Set("record_set", readRecordsSet(zone.RecordsSet)); err != nil {
+		return diag.Errorf("error setting record_set: %v", err)
+	}
+	return nil
+}
+
+// findDNSZoneID looks the zone up through every page of the DNS zones and
+// returns its ID, or an empty string if there is no such zone.
+func findDNSZoneID(ctx context.Context, client *gobizfly.Client, name string) (string, error) {
+	seen := 0
+	for page := 1; ; page++ {
+		zones, err := listDNSZones(ctx, client, page, 50)
+		if err != nil {
+			return "", err
+		}
+		for _, zone := range zones.Zones {
+			if dnsNameEqual(zone.Name, name) {
+				return zone.ID, nil
+			}
+		}
+		seen += len(zones.Zones)
+		if len(zones.Zones) == 0 || seen >= zones.Meta.Total {
+			return "", nil
+		}
+	}
+}
+
+// listDNSZones lists one page of the DNS zones. DNS.ListZones ignores its
+// list options and only ever returns the first page.
+func listDNSZones(ctx context.Context, client *gobizfly.Client, page, limit int) (*gobizfly.ListZoneResp, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, dnsServiceName, "/zones", nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
+	req.URL.RawQuery = q.Encode()
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var data gobizfly.ListZoneResp
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// dnsNameEqual compares two domain names, ignoring case and the trailing dot
+// of fully qualified names.
+func dnsNameEqual(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
@@ -19,24 +19,24 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudImages() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudImageRead,
-		Schema: imageSchema(),
+		ReadContext: dataSourceBizflyCloudImageRead,
+		Schema:      imageSchema(),
 	}
 }
 
-func dataSourceBizflyCloudImageRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	osImages, err := client.CloudServer.OSImages().List(context.Background())
+	osImages, err := client.CloudServer.OSImages().List(ctx)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	distribution, okDist := d.GetOk("distribution")
 	version, okVer := d.GetOk("version")
@@ -54,7 +54,7 @@ func dataSourceBizflyCloudImageRead(d *schema.ResourceData, meta interface{}) er
 			}
 		}
 	} else {
-		return fmt.Errorf("distribution and version must be set")
+		return diag.Errorf("distribution and version must be set")
 	}
 	return nil
 }
@@ -20,16 +20,16 @@ package bizflycloud
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudKafka() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBizflyCloudKafkaRead,
+		ReadContext: dataSourceBizflyCloudKafkaRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -79,18 +79,18 @@ func dataSourceBizflyCloudKafka() *schema.Resource {
 	}
 }
 
-func dataSourceBizflyCloudKafkaRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudKafkaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	// If ID provided, prefer Get by ID
 	if id, ok := d.GetOk("id"); ok && id.(string) != "" {
-		cluster, err := client.Kafka.Get(context.Background(), id.(string))
+		cluster, err := client.Kafka.Get(ctx, id.(string))
 		if err != nil {
 			if errors.Is(err, gobizfly.ErrNotFound) {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("error getting kafka cluster %s: %w", id.(string), err)
+			return diag.Errorf("error getting kafka cluster %s: %v", id.(string), err)
 		}
 		d.SetId(cluster.ID)
 		_ = d.Set("name", cluster.Name)
@@ -109,12 +109,12 @@ func dataSourceBizflyCloudKafkaRead(d *schema.ResourceData, meta interface{}) er
 
 	// Otherwise try to lookup by name
 	name := d.Get("name").(string)
-	clusters, err := client.Kafka.List(context.Background(), &gobizfly.KafkaClusterListOptions{Name: name})
+	clusters, err := client.Kafka.List(ctx, &gobizfly.KafkaClusterListOptions{Name: name})
 	if err != nil {
-		return fmt.Errorf("error listing kafka clusters: %w", err)
+		return diag.Errorf("error listing kafka clusters: %v", err)
 	}
 	if len(clusters) == 0 {
-		return fmt.Errorf("no kafka cluster found with name %s", name)
+		return diag.Errorf("no kafka cluster found with name %s", name)
 	}
 	// pick first match
 	match := clusters[0]
@@ -22,12 +22,13 @@ import (
 	"fmt"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudKafkaFlavor() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBizflyCloudKafkaFlavorRead,
+		ReadContext: dataSourceBizflyCloudKafkaFlavorRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -93,7 +94,7 @@ func dataSourceBizflyCloudKafkaFlavor() *schema.Resource {
 	}
 }
 
-func dataSourceBizflyCloudKafkaFlavorRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudKafkaFlavorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	opts := &gobizfly.KafkaFlavorListOptions{}
@@ -101,9 +102,9 @@ func dataSourceBizflyCloudKafkaFlavorRead(d *schema.ResourceData, meta interface
 		opts.Name = name.(string)
 	}
 
-	flavors, err := client.Kafka.ListFlavor(context.Background(), opts)
+	flavors, err := client.Kafka.ListFlavor(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("error retrieving Kafka flavors: %v", err)
+		return diag.Errorf("error retrieving Kafka flavors: %v", err)
 	}
 
 	var flavorList []map[string]interface{}
@@ -125,7 +126,7 @@ func dataSourceBizflyCloudKafkaFlavorRead(d *schema.ResourceData, meta interface
 	}
 
 	if err := d.Set("flavors", flavorList); err != nil {
-		return fmt.Errorf("error setting flavors: %v", err)
+		return diag.Errorf("error setting flavors: %v", err)
 	}
 
 	// Set a unique ID for the data source
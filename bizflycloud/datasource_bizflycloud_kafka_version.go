@@ -22,12 +22,13 @@ import (
 	"fmt"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudKafkaVersion() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBizflyCloudKafkaVersionRead,
+		ReadContext: dataSourceBizflyCloudKafkaVersionRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -63,7 +64,7 @@ func dataSourceBizflyCloudKafkaVersion() *schema.Resource {
 	}
 }
 
-func dataSourceBizflyCloudKafkaVersionRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudKafkaVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	opts := &gobizfly.KafkaVersionListOptions{}
@@ -71,9 +72,9 @@ func dataSourceBizflyCloudKafkaVersionRead(d *schema.ResourceData, meta interfac
 		opts.Name = name.(string)
 	}
 
-	versions, err := client.Kafka.ListVersion(context.Background(), opts)
+	versions, err := client.Kafka.ListVersion(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("error retrieving Kafka versions: %v", err)
+		return diag.Errorf("error retrieving Kafka versions: %v", err)
 	}
 
 	var versionList []map[string]interface{}
@@ -88,7 +89,7 @@ func dataSourceBizflyCloudKafkaVersionRead(d *schema.ResourceData, meta interfac
 	}
 
 	if err := d.Set("versions", versionList); err != nil {
-		return fmt.Errorf("error setting versions: %v", err)
+		return diag.Errorf("error setting versions: %v", err)
 	}
 
 	// Set a unique ID for the data source
@@ -3,33 +3,33 @@ package bizflycloud
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudNetworkInterface() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudNetworkInterfaceRead,
-		Schema: dataNetworkInterfaceSchema(),
+		ReadContext: dataSourceBizflyCloudNetworkInterfaceRead,
+		Schema:      dataNetworkInterfaceSchema(),
 	}
 }
 
-func dataSourceBizflyCloudNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudNetworkInterfaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	var matchNetworkInterface *gobizfly.NetworkInterface
 
-	err := resource.Retry(d.Timeout(schema.TimeoutRead), func() *resource.RetryError {
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutRead), func() *retry.RetryError {
 		ipAddress := d.Get("ip_address").(string)
-		networkInterfaces, err := client.CloudServer.NetworkInterfaces().List(context.Background(), &gobizfly.ListNetworkInterfaceOptions{})
+		networkInterfaces, err := client.CloudServer.NetworkInterfaces().List(ctx, &gobizfly.ListNetworkInterfaceOptions{})
 		if d.IsNewResource() && errors.Is(err, gobizfly.ErrNotFound) {
-			return resource.RetryableError(err)
+			return retry.RetryableError(err)
 		}
 		if err != nil {
-			return resource.NonRetryableError(err)
+			return retry.NonRetryableError(err)
 		}
 		for _, networkInterface := range networkInterfaces {
 			if networkInterface.FixedIps[0].IPAddress == ipAddress {
@@ -37,7 +37,7 @@ func dataSourceBizflyCloudNetworkInterfaceRead(d *schema.ResourceData, meta inte
 			}
 		}
 		if matchNetworkInterface == nil {
-			return resource.NonRetryableError(errors.New("no match network interface found"))
+			return retry.NonRetryableError(errors.New("no match network interface found"))
 		}
 		return nil
 	})
@@ -51,12 +51,12 @@ func dataSourceBizflyCloudNetworkInterfaceRead(d *schema.ResourceData, meta inte
 	}
 
 	if err != nil {
-		return fmt.Errorf("error reading network interface network %s: %w", d.Id(), err)
+		return diag.Errorf("error reading network interface network %s: %v", d.Id(), err)
 	}
 
 	// Prevent panics.
 	if matchNetworkInterface == nil {
-		return fmt.Errorf("error reading network interface network (%s): empty response", d.Id())
+		return diag.Errorf("error reading network interface network (%s): empty response", d.Id())
 	}
 
 	d.SetId(matchNetworkInterface.ID)
@@ -69,11 +69,11 @@ func dataSourceBizflyCloudNetworkInterfaceRead(d *schema.ResourceData, meta inte
 	_ = d.Set("updated_at", matchNetworkInterface.UpdatedAt)
 
 	if err := d.Set("fixed_ips", readFixedIps(matchNetworkInterface.FixedIps)); err != nil {
-		return fmt.Errorf("error setting fixed_ips: %w", err)
+		return diag.Errorf("error setting fixed_ips: %v", err)
 	}
 
 	if err := d.Set("security_groups", readSecurityGroups(matchNetworkInterface.SecurityGroups)); err != nil {
-		return fmt.Errorf("error setting security_groups: %w", err)
+		return diag.Errorf("error setting security_groups: %v", err)
 	}
 
 	return nil
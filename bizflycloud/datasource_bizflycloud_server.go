@@ -19,17 +19,16 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/bizflycloud/gobizfly"
-
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func datasourceBizflyCloudServers() *schema.Resource {
+func datasourceBizflyCloudServer() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBizflyCloudServerRead,
+		ReadContext: dataSourceBizflyCloudServerRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -124,11 +123,11 @@ func datasourceBizflyCloudServers() *schema.Resource {
 	}
 }
 
-func dataSourceBizflyCloudServerRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	osServers, err := client.CloudServer.List(context.Background(), &gobizfly.ServerListOptions{})
+	osServers, err := client.CloudServer.List(ctx, &gobizfly.ServerListOptions{})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	id, okID := d.GetOk("id")
 	if okID {
@@ -137,14 +136,14 @@ func dataSourceBizflyCloudServerRead(d *schema.ResourceData, meta interface{}) e
 				continue
 			}
 			d.SetId(server.ID)
-			err := resourceBizflyCloudServerRead(d, meta)
+			err := diagnosticsError(resourceBizflyCloudServerRead(ctx, d, meta))
 			if err != nil {
-				return fmt.Errorf("couldn't set data %+v", err)
+				return diag.Errorf("couldn't set data %+v", err)
 			}
 			break
 		}
 	} else {
-		return fmt.Errorf("server ID must be set")
+		return diag.Errorf("server ID must be set")
 	}
 	return nil
 }
@@ -24,24 +24,25 @@ import (
 	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudServerTypes() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudServerTypeRead,
-		Schema: dataSourceServerTypeSchema(),
+		ReadContext: dataSourceBizflyCloudServerTypeRead,
+		Schema:      dataSourceServerTypeSchema(),
 	}
 }
 
-func dataSourceBizflyCloudServerTypeRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudServerTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	var matchServerType *gobizfly.ServerType
-	err := resource.Retry(d.Timeout(schema.TimeoutRead), func() *resource.RetryError {
-		serverTypes, err := client.CloudServer.ListServerTypes(context.Background())
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutRead), func() *retry.RetryError {
+		serverTypes, err := client.CloudServer.ListServerTypes(ctx)
 		if err != nil {
-			return resource.RetryableError(err)
+			return retry.RetryableError(err)
 		}
 		for _, serverType := range serverTypes {
 			if serverType.Name == d.Get("name").(string) {
@@ -50,7 +51,7 @@ func dataSourceBizflyCloudServerTypeRead(d *schema.ResourceData, meta interface{
 			}
 		}
 		if matchServerType == nil {
-			return resource.RetryableError(fmt.Errorf("server type %s not found", d.Get("name").(string)))
+			return retry.RetryableError(fmt.Errorf("server type %s not found", d.Get("name").(string)))
 		}
 		return nil
 	})
@@ -60,7 +61,7 @@ func dataSourceBizflyCloudServerTypeRead(d *schema.ResourceData, meta interface{
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf("error reading server type %s: %s", d.Get("name").(string), err)
+		return diag.Errorf("error reading server type %s: %s", d.Get("name").(string), err)
 	}
 	d.SetId(matchServerType.ID)
 	_ = d.Set("name", matchServerType.Name)
@@ -0,0 +1,241 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverStringKeys are the string attributes of a server.
+var serverStringKeys = []string{
+	"id", "name", "flavor_name", "category", "status", "state", "availability_zone",
+	"key_name", "billing_plan", "network_plan", "created_at", "updated_at",
+}
+
+// serverListKeys are the list attributes of a server.
+var serverListKeys = []string{"vpc_network_ids", "lan_ip", "wan_ipv4", "wan_ipv6", "volume_ids", "tags"}
+
+// serverSortKeys are the attributes servers can be sorted on. The tags are
+// compared as a sorted, comma separated string.
+var serverSortKeys = append([]string{"tags"}, serverStringKeys...)
+
+// serverFilterKeys are the attributes servers can be filtered on. A list
+// attribute matches when any of its items matches.
+var serverFilterKeys = append(append([]string{}, serverListKeys...), serverStringKeys...)
+
+func dataSourceBizflyCloudServers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBizflyCloudServersRead,
+		Schema:      dataServersSchema(),
+	}
+}
+
+func dataSourceBizflyCloudServersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	servers, err := client.CloudServer.List(ctx, &gobizfly.ServerListOptions{})
+	if err != nil {
+		return diag.Errorf("error listing servers: %v", err)
+	}
+	ports, err := client.CloudServer.NetworkInterfaces().List(ctx, &gobizfly.ListNetworkInterfaceOptions{
+		Type: lanWanType,
+	})
+	if err != nil {
+		return diag.Errorf("error listing network interfaces: %v", err)
+	}
+	serverPorts := make(map[string][]*gobizfly.NetworkInterface)
+	for _, port := range ports {
+		serverPorts[port.DeviceID] = append(serverPorts[port.DeviceID], port)
+	}
+
+	filters, err := expandServerFilters(d.Get("filter").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	result := make([]map[string]interface{}, 0)
+	for _, server := range servers {
+		flattened := flattenServerSummary(server, serverPorts[server.ID])
+		if matchServerFilters(flattened, filters) {
+			result = append(result, flattened)
+		}
+	}
+	sortServers(result, d.Get("sort").([]interface{}))
+
+	ids := make([]string, 0, len(result))
+	for _, server := range result {
+		ids = append(ids, server["id"].(string))
+	}
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	if err := d.Set("servers", result); err != nil {
+		return diag.Errorf("error setting servers: %v", err)
+	}
+	return nil
+}
+
+type serverFilter struct {
+	name    string
+	values  []string
+	regexes []*regexp.Regexp
+}
+
+func expandServerFilters(filters []interface{}) ([]serverFilter, error) {
+	result := make([]serverFilter, 0, len(filters))
+	for _, v := range filters {
+		raw := v.(map[string]interface{})
+		filter := serverFilter{
+			name:   raw["name"].(string),
+			values: readStringArray(raw["values"].([]interface{})),
+		}
+		if raw["regex"].(bool) {
+			for _, value := range filter.values {
+				re, err := regexp.Compile(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid regex %q in filter %s: %v", value, filter.name, err)
+				}
+				filter.regexes = append(filter.regexes, re)
+			}
+		}
+		result = append(result, filter)
+	}
+	return result, nil
+}
+
+// matchServerFilters reports whether the server matches all the filters.
+func matchServerFilters(server map[string]interface{}, filters []serverFilter) bool {
+	for _, filter := range filters {
+		var attrs []string
+		switch v := server[filter.name].(type) {
+		case string:
+			attrs = []string{v}
+		case []string:
+			attrs = v
+		}
+		if !matchServerFilter(attrs, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchServerFilter(attrs []string, filter serverFilter) bool {
+	for _, attr := range attrs {
+		if filter.regexes != nil {
+			for _, re := range filter.regexes {
+				if re.MatchString(attr) {
+					return true
+				}
+			}
+		} else if checkIDInList(attr, filter.values) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortServers(servers []map[string]interface{}, sorts []interface{}) {
+	if len(sorts) == 0 {
+		return
+	}
+	sort.SliceStable(servers, func(i, j int) bool {
+		for _, v := range sorts {
+			s := v.(map[string]interface{})
+			key := s["key"].(string)
+			a, b := serverSortValue(servers[i][key]), serverSortValue(servers[j][key])
+			if a == b {
+				continue
+			}
+			if s["direction"].(string) == "desc" {
+				return a > b
+			}
+			return a < b
+		}
+		return false
+	})
+}
+
+func serverSortValue(v interface{}) string {
+	if values, ok := v.([]string); ok {
+		return strings.Join(values, ",")
+	}
+	return v.(string)
+}
+
+// flattenServerTags returns the metadata of a server as sorted key=value tags.
+// gobizfly does not return any other tags for a server.
+func flattenServerTags(metadata map[string]string) []string {
+	tags := make([]string, 0, len(metadata))
+	for key, value := range metadata {
+		tags = append(tags, key+"="+value)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func flattenServerSummary(server *gobizfly.Server, ports []*gobizfly.NetworkInterface) map[string]interface{} {
+	var (
+		vpcNetworkIDs = make([]string, 0)
+		lanIPs        = make([]string, 0)
+		wanIPv4s      = make([]string, 0)
+		wanIPv6s      = make([]string, 0)
+		volumeIDs     = make([]string, 0)
+	)
+	for _, port := range ports {
+		switch {
+		case port.Type == lanType:
+			vpcNetworkIDs = append(vpcNetworkIDs, port.NetworkID)
+			lanIPs = append(lanIPs, port.IPAddress)
+		case port.IPVersion == 6:
+			wanIPv6s = append(wanIPv6s, port.IPAddress)
+		default:
+			wanIPv4s = append(wanIPv4s, port.IPAddress)
+		}
+	}
+	for _, volume := range server.AttachedVolumes {
+		if volume.AttachedType == attachTypeDataDisk {
+			volumeIDs = append(volumeIDs, volume.ID)
+		}
+	}
+	return map[string]interface{}{
+		"id":                server.ID,
+		"name":              server.Name,
+		"flavor_name":       formatFlavor(server.Flavor.Name),
+		"category":          server.Category,
+		"status":            server.Status,
+		"state":             serverState(server.Status),
+		"availability_zone": server.AvailabilityZone,
+		"key_name":          server.KeyName,
+		"billing_plan":      server.BillingPlan,
+		"network_plan":      server.NetworkPlan,
+		"created_at":        server.CreatedAt,
+		"updated_at":        server.UpdatedAt,
+		"vpc_network_ids":   vpcNetworkIDs,
+		"lan_ip":            lanIPs,
+		"wan_ipv4":          wanIPv4s,
+		"wan_ipv6":          wanIPv6s,
+		"volume_ids":        volumeIDs,
+		"tags":              flattenServerTags(server.Metadata),
+	}
+}
@@ -0,0 +1,109 @@
+package bizflycloud
+
+import (
+	"testing"
+
+	"github.com/bizflycloud/gobizfly"
+)
+
+func TestBizflyCloudServersDataSource_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+
+	ids := make(map[string]string)
+	for _, server := range []struct{ name, zone string }{
+		{"web-1", "HN1"},
+		{"web-2", "HN2"},
+		{"db-1", "HN1"},
+	} {
+		d := testResourceCreate(t, resourceBizflyCloudServer(), map[string]interface{}{
+			"name":                  server.name,
+			"flavor_name":           "2c_2g",
+			"category":              "premium",
+			"os_type":               "image",
+			"os_id":                 "image-1",
+			"root_disk_size":        20,
+			"root_disk_volume_type": "SSD",
+			"availability_zone":     server.zone,
+		}, meta)
+		ids[server.name] = d.Id()
+	}
+	api.mu.Lock()
+	api.ports["port-lan"] = &gobizfly.NetworkInterface{
+		ID:        "port-lan",
+		DeviceID:  ids["web-2"],
+		NetworkID: "vpc-1",
+		Status:    "ACTIVE",
+		Type:      lanType,
+		IPVersion: 4,
+		IPAddress: "10.20.0.5",
+	}
+	api.servers[ids["web-1"]].Metadata = map[string]string{"role": "web", "env": "prod"}
+	api.servers[ids["db-1"]].Metadata = map[string]string{"role": "db"}
+	api.mu.Unlock()
+
+	all := testDataSourceRead(t, dataSourceBizflyCloudServers(), map[string]interface{}{}, meta)
+	testCheckResourceDataAttr(t, all, "servers.#", 3)
+
+	web := testDataSourceRead(t, dataSourceBizflyCloudServers(), map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{"name": "name", "values": []interface{}{"^web-"}, "regex": true},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"key": "name", "direction": "desc"},
+		},
+	}, meta)
+	testCheckResourceDataAttr(t, web, "servers.#", 2)
+	testCheckResourceDataAttr(t, web, "servers.0.name", "web-2")
+	testCheckResourceDataAttr(t, web, "servers.0.lan_ip.0", "10.20.0.5")
+	testCheckResourceDataAttr(t, web, "servers.1.name", "web-1")
+
+	zone := testDataSourceRead(t, dataSourceBizflyCloudServers(), map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{"name": "availability_zone", "values": []interface{}{"HN1"}},
+			map[string]interface{}{"name": "state", "values": []interface{}{"running"}},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"key": "name"},
+		},
+	}, meta)
+	testCheckResourceDataAttr(t, zone, "servers.#", 2)
+	testCheckResourceDataAttr(t, zone, "servers.0.name", "db-1")
+
+	vpc := testDataSourceRead(t, dataSourceBizflyCloudServers(), map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{"name": "vpc_network_ids", "values": []interface{}{"vpc-1"}},
+		},
+	}, meta)
+	testCheckResourceDataAttr(t, vpc, "servers.#", 1)
+	testCheckResourceDataAttr(t, vpc, "servers.0.id", ids["web-2"])
+
+	tagged := testDataSourceRead(t, dataSourceBizflyCloudServers(), map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{"name": "tags", "values": []interface{}{"^role="}, "regex": true},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"key": "tags"},
+		},
+	}, meta)
+	testCheckResourceDataAttr(t, tagged, "servers.#", 2)
+	testCheckResourceDataAttr(t, tagged, "servers.0.name", "web-1")
+	testCheckResourceDataAttr(t, tagged, "servers.0.tags.#", 2)
+	testCheckResourceDataAttr(t, tagged, "servers.0.tags.0", "env=prod")
+	testCheckResourceDataAttr(t, tagged, "servers.1.name", "db-1")
+}
+
+func TestFormatFlavor(t *testing.T) {
+	for _, tc := range []struct{ name, expected string }{
+		{"nix.2c_2g", "2c_2g"},
+		{"2c_2g_basic", "2c_2g"},
+		{"2c_2g", "2c_2g"},
+		{"custom", "custom"},
+		{"", ""},
+	} {
+		if got := formatFlavor(tc.name); got != tc.expected {
+			t.Errorf("formatFlavor(%q): expected %q, got %q", tc.name, tc.expected, got)
+		}
+	}
+}
@@ -19,7 +19,9 @@ package bizflycloud
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudSSHKey() *schema.Resource {
@@ -38,24 +40,24 @@ func dataSourceBizflyCloudSSHKey() *schema.Resource {
 				Computed: true,
 			},
 		},
-		Read: dataSourceBizflyClouldSSHKeyRead,
+		ReadContext: dataSourceBizflyClouldSSHKeyRead,
 	}
 }
 
-func dataSourceBizflyClouldSSHKeyRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyClouldSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	sshKey, err := client.CloudServer.SSHKeys().Get(context.Background(), d.Get("name").(string))
+	sshKey, err := client.CloudServer.SSHKeys().Get(ctx, d.Get("name").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(sshKey.Name)
 	err = d.Set("fingerprint", sshKey.FingerPrint)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("public_key", sshKey.PublicKey)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 
@@ -2,7 +2,9 @@ package bizflycloud
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudVolumeSnapshot() *schema.Resource {
@@ -49,54 +51,54 @@ func dataSourceBizflyCloudVolumeSnapshot() *schema.Resource {
 				Computed: true,
 			},
 		},
-		Read: dataSourceBizflyCloudVolumeSnapshotRead,
+		ReadContext: dataSourceBizflyCloudVolumeSnapshotRead,
 	}
 }
 
-func dataSourceBizflyCloudVolumeSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudVolumeSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
-	snapshot, err := client.CloudServer.Snapshots().Get(context.Background(), d.Get("id").(string))
+	snapshot, err := client.CloudServer.Snapshots().Get(ctx, d.Get("id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(snapshot.ID)
 	err = d.Set("name", snapshot.Name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("volume_id", snapshot.VolumeID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("size", snapshot.Size)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("created_at", snapshot.CreateAt)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("updated_at", snapshot.UpdatedAt)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("snapshot_type", snapshot.SnapshotType)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("type", snapshot.Type)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("availability_zone", snapshot.ZoneName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("region_name", snapshot.RegionName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -20,29 +20,30 @@ package bizflycloud
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudVolumeTypes() *schema.Resource {
 	return &schema.Resource{
-		Read:   datasourceBizflyCloudVolumeTypesRead,
-		Schema: dataSourceVolumeTypeSchema(),
+		ReadContext: datasourceBizflyCloudVolumeTypesRead,
+		Schema:      dataSourceVolumeTypeSchema(),
 	}
 }
 
-func datasourceBizflyCloudVolumeTypesRead(d *schema.ResourceData, meta interface{}) error {
+func datasourceBizflyCloudVolumeTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	volumeTypes, err := client.CloudServer.Volumes().ListVolumeTypes(context.Background(), &gobizfly.ListVolumeTypesOptions{})
+	volumeTypes, err := client.CloudServer.Volumes().ListVolumeTypes(ctx, &gobizfly.ListVolumeTypesOptions{})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	name := d.Get("name").(string)
 	category := d.Get("category").(string)
-	err = resource.Retry(d.Timeout(schema.TimeoutRead), func() *resource.RetryError {
+	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutRead), func() *retry.RetryError {
 		for _, volumeType := range volumeTypes {
 			if volumeType.Name == name && volumeType.Category == category {
 				d.SetId(volumeType.Type)
@@ -53,7 +54,7 @@ func datasourceBizflyCloudVolumeTypesRead(d *schema.ResourceData, meta interface
 				return nil
 			}
 		}
-		return resource.RetryableError(err)
+		return retry.RetryableError(err)
 	})
 	if !d.IsNewResource() && errors.Is(err, gobizfly.ErrNotFound) {
 		log.Printf("[WARN] Volume Type %s is not found, removing from state", d.Id())
@@ -61,7 +62,7 @@ func datasourceBizflyCloudVolumeTypesRead(d *schema.ResourceData, meta interface
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf("error read Volume Type %s: %w", d.Id(), err)
+		return diag.Errorf("error read Volume Type %s: %v", d.Id(), err)
 	}
 	return nil
 }
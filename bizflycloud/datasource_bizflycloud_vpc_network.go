@@ -3,39 +3,39 @@ package bizflycloud
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudVPCNetwork() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudVPCNetworkRead,
-		Schema: dataVPCNetworkSchema(),
+		ReadContext: dataSourceBizflyCloudVPCNetworkRead,
+		Schema:      dataVPCNetworkSchema(),
 	}
 }
 
-func dataSourceBizflyCloudVPCNetworkRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudVPCNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	var matchVPC *gobizfly.VPCNetwork
 	cidr := d.Get("cidr")
 
-	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
 		var err error
 
 		log.Printf("[DEBUG] Reading vpc network: %s", d.Id())
-		vpcs, err := client.CloudServer.VPCNetworks().List(context.Background())
+		vpcs, err := client.CloudServer.VPCNetworks().List(ctx)
 
 		// Retry on any API "not found" errors, but only on new resources.
 		if d.IsNewResource() && errors.Is(err, gobizfly.ErrNotFound) {
-			return resource.RetryableError(err)
+			return retry.RetryableError(err)
 		}
 		if err != nil {
-			return resource.NonRetryableError(err)
+			return retry.NonRetryableError(err)
 		}
 		for _, vpc := range vpcs {
 			if vpc.Subnets[0].CIDR == cidr {
@@ -54,12 +54,12 @@ func dataSourceBizflyCloudVPCNetworkRead(d *schema.ResourceData, meta interface{
 	}
 
 	if err != nil {
-		return fmt.Errorf("error reading vpc network %s: %w", d.Id(), err)
+		return diag.Errorf("error reading vpc network %s: %v", d.Id(), err)
 	}
 
 	// Prevent panics.
 	if matchVPC == nil {
-		return fmt.Errorf("error reading vpc network (%s): empty response", d.Id())
+		return diag.Errorf("error reading vpc network (%s): empty response", d.Id())
 	}
 
 	d.SetId(matchVPC.ID)
@@ -74,11 +74,11 @@ func dataSourceBizflyCloudVPCNetworkRead(d *schema.ResourceData, meta interface{
 	_ = d.Set("mtu", matchVPC.MTU)
 
 	if err := d.Set("availability_zones", readAvailabilityZones(matchVPC.AvailabilityZones)); err != nil {
-		return fmt.Errorf("error setting availability_zones: %w", err)
+		return diag.Errorf("error setting availability_zones: %v", err)
 	}
 
 	if err := d.Set("subnets", readSubnets(matchVPC.Subnets)); err != nil {
-		return fmt.Errorf("error setting subnets: %w", err)
+		return diag.Errorf("error setting subnets: %v", err)
 	}
 
 	return nil
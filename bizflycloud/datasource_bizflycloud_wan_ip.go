@@ -3,31 +3,32 @@ package bizflycloud
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBizflyCloudWanIP() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceBizflyCloudWanIPRead,
-		Schema: dataSourceWanIPSchema(),
+		ReadContext: dataSourceBizflyCloudWanIPRead,
+		Schema:      dataSourceWanIPSchema(),
 	}
 }
-func dataSourceBizflyCloudWanIPRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudWanIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	var matchWanIP *gobizfly.CloudServerPublicNetworkInterface
 
-	err := resource.Retry(d.Timeout(schema.TimeoutRead), func() *resource.RetryError {
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutRead), func() *retry.RetryError {
 		ipAddress := d.Get("ip_address").(string)
-		wanIPs, err := client.CloudServer.PublicNetworkInterfaces().List(context.Background())
+		wanIPs, err := client.CloudServer.PublicNetworkInterfaces().List(ctx)
 		if d.IsNewResource() && errors.Is(err, gobizfly.ErrNotFound) {
-			return resource.RetryableError(err)
+			return retry.RetryableError(err)
 		}
 		if err != nil {
-			return resource.NonRetryableError(err)
+			return retry.NonRetryableError(err)
 		}
 		for _, wanIP := range wanIPs {
 			if wanIP.IPAddress == ipAddress {
@@ -35,7 +36,7 @@ func dataSourceBizflyCloudWanIPRead(d *schema.ResourceData, meta interface{}) er
 			}
 		}
 		if matchWanIP == nil {
-			return resource.NonRetryableError(errors.New("no wan ip found"))
+			return retry.NonRetryableError(errors.New("no wan ip found"))
 		}
 		return nil
 	})
@@ -46,7 +47,7 @@ func dataSourceBizflyCloudWanIPRead(d *schema.ResourceData, meta interface{}) er
 	}
 
 	if err != nil {
-		return fmt.Errorf("error read WAN IP network %s: %w", d.Id(), err)
+		return diag.Errorf("error read WAN IP network %s: %v", d.Id(), err)
 	}
 
 	d.SetId(matchWanIP.ID)
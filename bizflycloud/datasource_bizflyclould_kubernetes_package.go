@@ -19,14 +19,14 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudKubernetesControllerPackage() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBizflyCloudKubernetesPackage,
+		ReadContext: dataSourceBizflyCloudKubernetesPackage,
 		Schema: map[string]*schema.Schema{
 			"provision_type": {
 				Type:     schema.TypeString,
@@ -43,12 +43,12 @@ func datasourceBizflyCloudKubernetesControllerPackage() *schema.Resource {
 		},
 	}
 }
-func dataSourceBizflyCloudKubernetesPackage(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudKubernetesPackage(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	provisionType := d.Get("provision_type").(string)
-	resp, err := client.KubernetesEngine.GetPackages(context.Background(), provisionType)
+	resp, err := client.KubernetesEngine.GetPackages(ctx, provisionType)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	packageName := d.Get("name").(string)
 
@@ -61,7 +61,7 @@ func dataSourceBizflyCloudKubernetesPackage(d *schema.ResourceData, meta interfa
 
 	packageID := d.Get("id")
 	if packageID == "" {
-		return fmt.Errorf("package %s not found", packageName)
+		return diag.Errorf("package %s not found", packageName)
 	}
 	return nil
 }
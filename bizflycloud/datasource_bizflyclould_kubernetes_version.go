@@ -19,15 +19,15 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceBizflyCloudKubernetesControllerVersions() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBizflyCloudKubernetesVersion,
+		ReadContext: dataSourceBizflyCloudKubernetesVersion,
 		Schema: map[string]*schema.Schema{
 			"version": {
 				Type:     schema.TypeString,
@@ -40,15 +40,15 @@ func datasourceBizflyCloudKubernetesControllerVersions() *schema.Resource {
 		},
 	}
 }
-func dataSourceBizflyCloudKubernetesVersion(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBizflyCloudKubernetesVersion(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	allOpt := true
 	opts := gobizfly.GetKubernetesVersionOpts{
 		All: &allOpt,
 	}
-	resp, err := client.KubernetesEngine.GetKubernetesVersion(context.Background(), opts)
+	resp, err := client.KubernetesEngine.GetKubernetesVersion(ctx, opts)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	versionName := d.Get("version").(string)
 	for _, controllerVersion := range resp.ControllerVersions {
@@ -60,7 +60,7 @@ func dataSourceBizflyCloudKubernetesVersion(d *schema.ResourceData, meta interfa
 
 	versionID := d.Get("id")
 	if versionID == "" {
-		return fmt.Errorf("version %s not found", versionName)
+		return diag.Errorf("version %s not found", versionName)
 	}
 	return nil
 }
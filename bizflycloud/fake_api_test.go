@@ -0,0 +1,1587 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2026  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const (
+	fakeAPIEmail     = "tester@bizflycloud.vn"
+	fakeAPIPassword  = "fake-password"
+	fakeAPIProjectID = "fake-project"
+
+	fakeAPIAppCredentialID     = "fake-credential"
+	fakeAPIAppCredentialSecret = "fake-credential-secret"
+)
+
+// fakeBizflyAPI is an in-process stand-in for the Bizfly Cloud API. It serves
+// the token and service catalog endpoints plus the cloud server, volume, load
+// balancer, DNS, CDN, cloud database and Kafka routes used by the provider, so that
+// resources can be exercised without real credentials.
+//
+// Asynchronous objects (server tasks, load balancer provisioning, database and
+// Kafka clusters) stay in a pending state for pendingPolls reads before they
+// settle, mimicking the real API. Waiters run on a fake clock in these tests
+// (see testContext), so lifecycle tests are fast and are not skipped in short
+// mode.
+type fakeBizflyAPI struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	pendingPolls int
+	nextID       int
+	tokens       map[string]time.Time
+	tokenTTL     time.Duration
+	requests     []string
+	rejected     int
+	failures     []int
+
+	servers       map[string]*gobizfly.Server
+	serverTasks   map[string]*fakeTask
+	serverActions map[string][]string
+	rebooting     map[string]int
+	volumes       map[string]*gobizfly.Volume
+	gateways      map[string]*gobizfly.ExtendedInternetGateway
+	vpcs          map[string]*gobizfly.VPCNetwork
+	ports         map[string]*gobizfly.NetworkInterface
+	loadBalancers map[string]*fakeAsyncObject
+	zones         map[string]*gobizfly.ExtendedZone
+	zonesPerPage  int
+	records       map[string]*gobizfly.Record
+	recordPolicy  map[string]json.RawMessage
+	databases     map[string]*fakeAsyncObject
+	databaseTasks map[string]*fakeTask
+	kafkaClusters map[string]*fakeAsyncObject
+	cdnDomains    map[string]map[string]interface{}
+	cdnPurges     map[string][][]string
+	clusters      map[string]*gobizfly.FullCluster
+}
+
+// fakeTask is an asynchronous task which becomes ready after a number of
+// polls, running done exactly once when it completes.
+type fakeTask struct {
+	polls  int
+	done   func() interface{}
+	result interface{}
+	ready  bool
+}
+
+func (t *fakeTask) poll() bool {
+	if t.ready {
+		return true
+	}
+	if t.polls > 0 {
+		t.polls--
+		return false
+	}
+	t.ready = true
+	if t.done != nil {
+		t.result = t.done()
+	}
+	return true
+}
+
+// fakeAsyncObject holds an object whose status moves from a transitional
+// status to a settled one after a number of reads.
+type fakeAsyncObject struct {
+	value   interface{}
+	polls   int
+	settle  func()
+	deleted bool
+}
+
+func (o *fakeAsyncObject) poll() {
+	if o.polls > 0 {
+		o.polls--
+		return
+	}
+	if o.settle != nil {
+		o.settle()
+		o.settle = nil
+	}
+}
+
+// newFakeBizflyAPI starts a fake API server which is closed when the test ends.
+func newFakeBizflyAPI(t *testing.T) *fakeBizflyAPI {
+	t.Helper()
+	f := &fakeBizflyAPI{
+		pendingPolls:  1,
+		tokens:        make(map[string]time.Time),
+		tokenTTL:      time.Hour,
+		servers:       make(map[string]*gobizfly.Server),
+		serverTasks:   make(map[string]*fakeTask),
+		serverActions: make(map[string][]string),
+		rebooting:     make(map[string]int),
+		volumes:       make(map[string]*gobizfly.Volume),
+		gateways:      make(map[string]*gobizfly.ExtendedInternetGateway),
+		vpcs:          make(map[string]*gobizfly.VPCNetwork),
+		ports:         make(map[string]*gobizfly.NetworkInterface),
+		loadBalancers: make(map[string]*fakeAsyncObject),
+		zones:         make(map[string]*gobizfly.ExtendedZone),
+		records:       make(map[string]*gobizfly.Record),
+		recordPolicy:  make(map[string]json.RawMessage),
+		databases:     make(map[string]*fakeAsyncObject),
+		databaseTasks: make(map[string]*fakeTask),
+		kafkaClusters: make(map[string]*fakeAsyncObject),
+		cdnDomains:    make(map[string]map[string]interface{}),
+		cdnPurges:     make(map[string][][]string),
+		clusters:      make(map[string]*gobizfly.FullCluster),
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// providerMeta configures the provider against the fake API and returns the
+// meta value passed to CRUD functions.
+func (f *fakeBizflyAPI) providerMeta(t *testing.T) *CombinedConfig {
+	t.Helper()
+	return f.providerMetaWithDefaultTags(t)
+}
+
+// providerMetaWithDefaultTags is providerMeta with the given default_tags
+// configured on the provider.
+func (f *fakeBizflyAPI) providerMetaWithDefaultTags(t *testing.T, defaultTags ...interface{}) *CombinedConfig {
+	t.Helper()
+	raw := map[string]interface{}{
+		"api_endpoint": f.URL + "/api",
+		"auth_method":  "password",
+		"email":        fakeAPIEmail,
+		"password":     fakeAPIPassword,
+		"region_name":  "HaNoi",
+		"project_id":   fakeAPIProjectID,
+	}
+	if len(defaultTags) > 0 {
+		raw["default_tags"] = []interface{}{map[string]interface{}{"tags": defaultTags}}
+	}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+	meta, diags := providerConfigure(context.Background(), d, "0.12+compatible")
+	if diags.HasError() {
+		t.Fatalf("error configuring provider against fake API: %v", diagnosticsError(diags))
+	}
+	return meta.(*CombinedConfig)
+}
+
+// requestCount returns how many requests matched the given method and path prefix.
+func (f *fakeBizflyAPI) requestCount(method, pathPrefix string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, r := range f.requests {
+		if strings.HasPrefix(r, method+" "+pathPrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func (f *fakeBizflyAPI) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", prefix, f.nextID)
+}
+
+func (f *fakeBizflyAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 0 {
+		writeFakeError(w, http.StatusNotFound)
+		return
+	}
+	service, parts := parts[0], parts[1:]
+	if service == "api" {
+		f.serveAuth(w, r, parts)
+		return
+	}
+	if !f.authorized(r) {
+		f.rejected++
+		writeFakeError(w, http.StatusUnauthorized)
+		return
+	}
+	if len(f.failures) > 0 {
+		status := f.failures[0]
+		f.failures = f.failures[1:]
+		writeFakeError(w, status)
+		return
+	}
+	switch service {
+	case "bizfly_account":
+		f.serveAccount(w, r, parts)
+	case "cloud_server":
+		f.serveCloudServer(w, r, parts)
+	case "load_balancer":
+		f.serveLoadBalancer(w, r, parts)
+	case "dns":
+		f.serveDNS(w, r, parts)
+	case "cloud_database":
+		f.serveCloudDatabase(w, r, parts)
+	case "kafka":
+		f.serveKafka(w, r, parts)
+	case "cdn":
+		f.serveCDN(w, r, parts)
+	case "kubernetes_engine":
+		f.serveKubernetesEngine(w, r, parts)
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+// expireTokens invalidates every token issued so far, as if they had expired
+// early on the server side.
+func (f *fakeBizflyAPI) expireTokens() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for token := range f.tokens {
+		f.tokens[token] = time.Now()
+	}
+}
+
+// failNext makes the next count authorized requests fail with status.
+func (f *fakeBizflyAPI) failNext(status, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := 0; i < count; i++ {
+		f.failures = append(f.failures, status)
+	}
+}
+
+// rejectedCount returns how many requests were answered with 401 Unauthorized.
+func (f *fakeBizflyAPI) rejectedCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rejected
+}
+
+func (f *fakeBizflyAPI) authorized(r *http.Request) bool {
+	expiresAt, ok := f.tokens[r.Header.Get("X-Auth-Token")]
+	return ok && time.Now().Before(expiresAt)
+}
+
+func (f *fakeBizflyAPI) serveAuth(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "token" && r.Method == http.MethodPost:
+		var req gobizfly.TokenCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		switch req.AuthMethod {
+		case "password":
+			if req.Username != fakeAPIEmail || req.Password != fakeAPIPassword {
+				writeFakeError(w, http.StatusUnauthorized)
+				return
+			}
+		case "application_credential":
+			if req.AppCredID != fakeAPIAppCredentialID || req.AppCredSecret != fakeAPIAppCredentialSecret {
+				writeFakeError(w, http.StatusUnauthorized)
+				return
+			}
+		default:
+			writeFakeError(w, http.StatusUnauthorized)
+			return
+		}
+		token := f.newID("token")
+		expiresAt := time.Now().Add(f.tokenTTL)
+		f.tokens[token] = expiresAt
+		writeFakeJSON(w, http.StatusCreated, gobizfly.Token{
+			KeystoneToken: token,
+			ExpiresAt:     expiresAt.Format(time.RFC3339),
+			ProjectID:     req.ProjectID,
+		})
+	case len(parts) == 2 && parts[0] == "auth" && parts[1] == "service":
+		var services []*gobizfly.Service
+		for _, region := range []string{"HaNoi", "HoChiMinh"} {
+			for _, name := range []string{"bizfly_account", "cloud_server", "load_balancer", "dns",
+				"cloud_database", "kafka", "cdn", "kubernetes_engine", "auto_scaling", "container_registry",
+				"simple_storage"} {
+				services = append(services, &gobizfly.Service{
+					CanonicalName: name,
+					Region:        region,
+					Enabled:       true,
+					ServiceURL:    f.URL + "/" + name,
+				})
+			}
+		}
+		// The account service is global: it is also listed for regions the
+		// fake user has no access to.
+		services = append(services, &gobizfly.Service{
+			CanonicalName: "bizfly_account",
+			Region:        "VC-HaNoi",
+			Enabled:       true,
+			ServiceURL:    f.URL + "/bizfly_account",
+		})
+		writeFakeJSON(w, http.StatusOK, gobizfly.ServiceList{Services: services})
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+func (f *fakeBizflyAPI) serveAccount(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 1 || parts[0] != "user" {
+		writeFakeError(w, http.StatusNotFound)
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": gobizfly.User{
+			Email:    fakeAPIEmail,
+			TenantID: fakeAPIProjectID,
+			UserRegions: []gobizfly.UserRegion{
+				{Name: "Ha Noi", Code: "HaNoi", ShortName: "HN"},
+				{Name: "Ho Chi Minh", Code: "HoChiMinh", ShortName: "HCM"},
+			},
+		},
+	})
+}
+
+func (f *fakeBizflyAPI) serveCloudServer(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "servers" && r.Method == http.MethodGet:
+		servers := make([]*gobizfly.Server, 0, len(f.servers))
+		for _, server := range f.servers {
+			servers = append(servers, server)
+		}
+		writeFakeJSON(w, http.StatusOK, servers)
+	case len(parts) == 1 && parts[0] == "servers" && r.Method == http.MethodPost:
+		var reqs []*gobizfly.ServerCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil || len(reqs) != 1 {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		server := f.createServer(reqs[0])
+		taskID := f.newID("task")
+		f.serverTasks[taskID] = &fakeTask{
+			polls: f.pendingPolls,
+			done: func() interface{} {
+				server.Status = "ACTIVE"
+				return gobizfly.ServerTaskResult{Action: "create", Progress: 100, Success: true, Server: *server}
+			},
+		}
+		writeFakeJSON(w, http.StatusAccepted, gobizfly.ServerCreateResponse{Task: []string{taskID}})
+	case len(parts) == 2 && parts[0] == "servers":
+		server, ok := f.servers[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeFakeJSON(w, http.StatusOK, server)
+			// a rebooting server is back after pendingPolls reads
+			if polls, ok := f.rebooting[server.ID]; ok {
+				if polls > 0 {
+					f.rebooting[server.ID]--
+				} else {
+					delete(f.rebooting, server.ID)
+					server.Status = "ACTIVE"
+				}
+			}
+		case http.MethodDelete:
+			var req gobizfly.DeletedVolumes
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			server.Status = "DELETING"
+			writeFakeJSON(w, http.StatusAccepted, gobizfly.ServerTask{TaskID: f.serverTask(func() interface{} {
+				f.deleteServer(server.ID, req.IDs)
+				return nil
+			})})
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	case len(parts) == 3 && parts[0] == "servers" && parts[2] == "action":
+		server, ok := f.servers[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		f.serverAction(w, r, server)
+	case len(parts) == 2 && parts[0] == "tasks":
+		task, ok := f.serverTasks[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		resp := map[string]interface{}{"ready": task.poll()}
+		if task.ready && task.result != nil {
+			resp["result"] = task.result
+		}
+		writeFakeJSON(w, http.StatusOK, resp)
+	case len(parts) >= 1 && parts[0] == "volumes":
+		f.serveVolumes(w, r, parts[1:])
+	case len(parts) >= 1 && parts[0] == "internet-gateways":
+		f.serveInternetGateways(w, r, parts[1:])
+	case len(parts) >= 1 && parts[0] == "vpc-networks":
+		f.serveVPCNetworks(w, r, parts[1:])
+	case len(parts) == 1 && parts[0] == "network-interfaces":
+		ports := make([]*gobizfly.NetworkInterface, 0, len(f.ports))
+		for _, port := range f.ports {
+			if status := r.URL.Query().Get("status"); status != "" && port.Status != status {
+				continue
+			}
+			ports = append(ports, port)
+		}
+		writeFakeJSON(w, http.StatusOK, ports)
+	case len(parts) == 1 && parts[0] == "firewalls":
+		writeFakeJSON(w, http.StatusOK, []*gobizfly.Firewall{})
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+// serverTask registers a task which runs done once it becomes ready.
+func (f *fakeBizflyAPI) serverTask(done func() interface{}) string {
+	taskID := f.newID("task")
+	f.serverTasks[taskID] = &fakeTask{polls: f.pendingPolls, done: done}
+	return taskID
+}
+
+func (f *fakeBizflyAPI) createServer(req *gobizfly.ServerCreateRequest) *gobizfly.Server {
+	server := &gobizfly.Server{
+		ID:               f.newID("server"),
+		Name:             req.Name,
+		KeyName:          req.SSHKey,
+		ProjectID:        fakeAPIProjectID,
+		Status:           "BUILD",
+		Flavor:           gobizfly.Flavor{Name: req.FlavorName},
+		AvailabilityZone: req.AvailabilityZone,
+		Category:         req.Type,
+		NetworkPlan:      req.NetworkPlan,
+		BillingPlan:      req.BillingPlan,
+		IsAvailable:      true,
+		Metadata:         req.Metadata,
+		CreatedAt:        time.Now().Format(time.RFC3339),
+	}
+	rootDisk := &gobizfly.Volume{
+		ID:           f.newID("volume"),
+		Name:         req.Name + "-rootdisk",
+		Size:         req.RootDisk.Size,
+		AttachedType: attachTypeRootDisk,
+		Bootable:     true,
+		Status:       "in-use",
+		Attachments:  []gobizfly.VolumeAttachment{{ServerID: server.ID}},
+	}
+	if req.RootDisk.VolumeType != nil {
+		rootDisk.VolumeType = *req.RootDisk.VolumeType
+	}
+	rootDisk.ImageMetadata.ImageID = req.OS.ID
+	if req.OS.Type == "snapshot" {
+		rootDisk.SnapshotID = req.OS.ID
+	}
+	f.volumes[rootDisk.ID] = rootDisk
+	server.AttachedVolumes = append(server.AttachedVolumes, gobizfly.AttachedVolume{
+		ID:           rootDisk.ID,
+		Size:         rootDisk.Size,
+		AttachedType: attachTypeRootDisk,
+	})
+	for i, disk := range req.DataDisks {
+		dataDisk := &gobizfly.Volume{
+			ID:           f.newID("volume"),
+			Name:         fmt.Sprintf("%s-datadisk-%d", req.Name, i),
+			Size:         disk.Size,
+			AttachedType: attachTypeDataDisk,
+			Status:       "in-use",
+			Attachments:  []gobizfly.VolumeAttachment{{ServerID: server.ID}},
+		}
+		if disk.VolumeType != nil {
+			dataDisk.VolumeType = *disk.VolumeType
+		}
+		f.volumes[dataDisk.ID] = dataDisk
+		server.AttachedVolumes = append(server.AttachedVolumes, gobizfly.AttachedVolume{
+			ID:           dataDisk.ID,
+			Size:         dataDisk.Size,
+			AttachedType: attachTypeDataDisk,
+		})
+	}
+	if req.IsCreatedWan != nil && *req.IsCreatedWan {
+		port := &gobizfly.NetworkInterface{
+			ID:          f.newID("port"),
+			DeviceID:    server.ID,
+			Status:      "ACTIVE",
+			Type:        wanType,
+			BillingType: freeWan,
+			IPVersion:   4,
+			IPAddress:   fmt.Sprintf("203.0.113.%d", f.nextID%250+1),
+		}
+		f.ports[port.ID] = port
+	}
+	f.servers[server.ID] = server
+	return server
+}
+
+func (f *fakeBizflyAPI) deleteServer(id string, deletedVolumeIDs []string) {
+	for _, volumeID := range deletedVolumeIDs {
+		delete(f.volumes, volumeID)
+	}
+	for _, volume := range f.volumes {
+		if len(volume.Attachments) > 0 && volume.Attachments[0].ServerID == id {
+			volume.Status = "available"
+			volume.Attachments = nil
+			volume.AttachedType = ""
+		}
+	}
+	for portID, port := range f.ports {
+		if port.DeviceID == id {
+			delete(f.ports, portID)
+		}
+	}
+	delete(f.servers, id)
+}
+
+func (f *fakeBizflyAPI) serverAction(w http.ResponseWriter, r *http.Request, server *gobizfly.Server) {
+	var action gobizfly.ServerAction
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil {
+		writeFakeError(w, http.StatusBadRequest)
+		return
+	}
+	f.serverActions[server.ID] = append(f.serverActions[server.ID], action.Action)
+	switch action.Action {
+	case "rename":
+		server.Name = action.NewName
+		writeFakeJSON(w, http.StatusOK, gobizfly.ServerMessageResponse{Message: "renamed"})
+	case "resize":
+		server.Status = "RESIZE"
+		writeFakeJSON(w, http.StatusAccepted, gobizfly.ServerTask{TaskID: f.serverTask(func() interface{} {
+			server.Flavor.Name = action.FlavorName
+			server.Status = "ACTIVE"
+			return gobizfly.ServerTaskResult{Action: "resize", Progress: 100, Success: true, Server: *server}
+		})})
+	case "rebuild":
+		server.Status = "REBUILD"
+		writeFakeJSON(w, http.StatusAccepted, gobizfly.ServerTask{TaskID: f.serverTask(func() interface{} {
+			for _, volume := range f.volumes {
+				if volume.AttachedType == attachTypeRootDisk && volume.Attachments[0].ServerID == server.ID {
+					volume.ImageMetadata.ImageID = action.ImageID
+				}
+			}
+			server.Status = "ACTIVE"
+			return gobizfly.ServerTaskResult{Action: "rebuild", Progress: 100, Success: true, Server: *server}
+		})})
+	case "change_type":
+		writeFakeJSON(w, http.StatusAccepted, gobizfly.ServerTask{TaskID: f.serverTask(func() interface{} {
+			server.Category = action.NewType
+			return gobizfly.ServerTaskResult{Action: "change_type", Progress: 100, Success: true, Server: *server}
+		})})
+	case "start":
+		server.Status = "ACTIVE"
+		writeFakeJSON(w, http.StatusOK, server)
+	case "stop":
+		server.Status = "SHUTOFF"
+		writeFakeJSON(w, http.StatusOK, server)
+	case "change_network_plan":
+		server.NetworkPlan = action.NewNetworkPlan
+		writeFakeJSON(w, http.StatusOK, gobizfly.ServerMessageResponse{Message: "ok"})
+	case "switch_billing_plan":
+		server.BillingPlan = action.NewBillingPlan
+		writeFakeJSON(w, http.StatusOK, gobizfly.ServerMessageResponse{Message: "ok"})
+	case "soft_reboot", "hard_reboot":
+		server.Status = "REBOOT"
+		if action.Action == "hard_reboot" {
+			server.Status = "HARD_REBOOT"
+		}
+		f.rebooting[server.ID] = f.pendingPolls
+		writeFakeJSON(w, http.StatusOK, gobizfly.ServerMessageResponse{Message: "rebooting"})
+	case "get_vnc":
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{"console": gobizfly.ServerConsoleResponse{
+			URL:  "https://console.example.vn/vnc_auto.html?token=" + server.ID,
+			Type: action.ConsoleType,
+		}})
+	default:
+		writeFakeError(w, http.StatusBadRequest)
+	}
+}
+
+func (f *fakeBizflyAPI) serveVolumes(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		volumes := make([]*gobizfly.Volume, 0, len(f.volumes))
+		for _, volume := range f.volumes {
+			volumes = append(volumes, volume)
+		}
+		writeFakeJSON(w, http.StatusOK, volumes)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req gobizfly.VolumeCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		volume := &gobizfly.Volume{
+			ID:               f.newID("volume"),
+			Name:             req.Name,
+			Size:             req.Size,
+			VolumeType:       req.VolumeType,
+			Category:         req.VolumeCategory,
+			AvailabilityZone: req.AvailabilityZone,
+			SnapshotID:       req.SnapshotID,
+			Status:           "available",
+			ProjectID:        fakeAPIProjectID,
+			CreatedAt:        time.Now().Format(time.RFC3339),
+		}
+		f.volumes[volume.ID] = volume
+		writeFakeJSON(w, http.StatusCreated, volume)
+	case len(parts) == 1:
+		volume, ok := f.volumes[parts[0]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeFakeJSON(w, http.StatusOK, volume)
+		case http.MethodDelete:
+			delete(f.volumes, volume.ID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	case len(parts) == 2 && parts[1] == "action":
+		volume, ok := f.volumes[parts[0]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		var action gobizfly.VolumeAction
+		if err := json.NewDecoder(r.Body).Decode(&action); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		f.volumeAction(w, volume, &action)
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+func (f *fakeBizflyAPI) serveInternetGateways(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req gobizfly.CreateInternetGatewayPayload
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		igw := &gobizfly.ExtendedInternetGateway{}
+		igw.ID = f.newID("igw")
+		igw.Name = req.Name
+		igw.Status = activeStatus
+		igw.ProjectID = fakeAPIProjectID
+		if req.Description != nil {
+			igw.Description = *req.Description
+		}
+		f.gateways[igw.ID] = igw
+		writeFakeJSON(w, http.StatusCreated, igw)
+	case len(parts) == 1:
+		igw, ok := f.gateways[parts[0]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeFakeJSON(w, http.StatusOK, igw)
+		case http.MethodPut:
+			var req struct {
+				gobizfly.UpdateInternetGatewayPayload
+				Tags *[]string `json:"tags"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			igw.Name = req.Name
+			igw.Description = req.Description
+			if req.Tags != nil {
+				igw.Tags = *req.Tags
+			}
+			writeFakeJSON(w, http.StatusOK, igw)
+		case http.MethodDelete:
+			delete(f.gateways, igw.ID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+func (f *fakeBizflyAPI) serveVPCNetworks(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		vpcs := make([]*gobizfly.VPCNetwork, 0, len(f.vpcs))
+		for _, vpc := range f.vpcs {
+			vpcs = append(vpcs, vpc)
+		}
+		writeFakeJSON(w, http.StatusOK, vpcs)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req gobizfly.CreateVPCPayload
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		vpc := &gobizfly.VPCNetwork{
+			ID:          f.newID("vpc"),
+			Name:        req.Name,
+			Description: req.Description,
+			IsDefault:   req.IsDefault,
+			Status:      activeStatus,
+			MTU:         1450,
+			Subnets:     []gobizfly.Subnet{{CIDR: req.CIDR}},
+			Tags:        []string{},
+		}
+		f.vpcs[vpc.ID] = vpc
+		writeFakeJSON(w, http.StatusCreated, vpc)
+	case len(parts) == 1:
+		vpc, ok := f.vpcs[parts[0]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeFakeJSON(w, http.StatusOK, vpc)
+		case http.MethodPut:
+			var req struct {
+				gobizfly.UpdateVPCPayload
+				Tags *[]string `json:"tags"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			vpc.Name = req.Name
+			vpc.Description = req.Description
+			if req.Tags != nil {
+				vpc.Tags = *req.Tags
+			}
+			writeFakeJSON(w, http.StatusOK, vpc)
+		case http.MethodDelete:
+			delete(f.vpcs, vpc.ID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+func (f *fakeBizflyAPI) volumeAction(w http.ResponseWriter, volume *gobizfly.Volume, action *gobizfly.VolumeAction) {
+	switch action.Type {
+	case "extend":
+		status := volume.Status
+		volume.Status = "extending"
+		writeFakeJSON(w, http.StatusAccepted, gobizfly.Task{TaskID: f.serverTask(func() interface{} {
+			volume.Size = action.NewSize
+			volume.Status = status
+			for _, server := range f.servers {
+				for i := range server.AttachedVolumes {
+					if server.AttachedVolumes[i].ID == volume.ID {
+						server.AttachedVolumes[i].Size = action.NewSize
+					}
+				}
+			}
+			return nil
+		})})
+	case "attach":
+		server, ok := f.servers[action.ServerID]
+		if !ok || volume.Status != "available" {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		volume.Status = "in-use"
+		volume.AttachedType = attachTypeDataDisk
+		volume.Attachments = []gobizfly.VolumeAttachment{{ServerID: server.ID}}
+		server.AttachedVolumes = append(server.AttachedVolumes, gobizfly.AttachedVolume{
+			ID:           volume.ID,
+			Size:         volume.Size,
+			AttachedType: attachTypeDataDisk,
+		})
+		writeFakeJSON(w, http.StatusAccepted, gobizfly.VolumeAttachDetachResponse{Message: "attached", VolumeDetail: *volume})
+	case "detach":
+		server, ok := f.servers[action.ServerID]
+		if !ok || len(volume.Attachments) == 0 || volume.Attachments[0].ServerID != server.ID {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		volume.Status = "available"
+		volume.AttachedType = ""
+		volume.Attachments = nil
+		for i, attached := range server.AttachedVolumes {
+			if attached.ID == volume.ID {
+				server.AttachedVolumes = append(server.AttachedVolumes[:i], server.AttachedVolumes[i+1:]...)
+				break
+			}
+		}
+		writeFakeJSON(w, http.StatusAccepted, gobizfly.VolumeAttachDetachResponse{Message: "detached", VolumeDetail: *volume})
+	default:
+		writeFakeError(w, http.StatusBadRequest)
+	}
+}
+
+func (f *fakeBizflyAPI) serveLoadBalancer(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "loadbalancers" && r.Method == http.MethodGet:
+		lbs := make([]*gobizfly.LoadBalancer, 0, len(f.loadBalancers))
+		for _, obj := range f.loadBalancers {
+			lbs = append(lbs, obj.value.(*gobizfly.LoadBalancer))
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{"loadbalancers": lbs})
+	case len(parts) == 1 && parts[0] == "loadbalancers" && r.Method == http.MethodPost:
+		var req struct {
+			LoadBalancer gobizfly.LoadBalancerCreateRequest `json:"loadbalancer"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		lb := &gobizfly.LoadBalancer{
+			ID:                 f.newID("lb"),
+			Name:               req.LoadBalancer.Name,
+			Description:        req.LoadBalancer.Description,
+			NetworkType:        req.LoadBalancer.NetworkType,
+			VipNetworkID:       req.LoadBalancer.VPCNetworkID,
+			Type:               req.LoadBalancer.Type,
+			VipAddress:         "198.51.100.10",
+			ProvisioningStatus: "PENDING_CREATE",
+			OperatingStatus:    "OFFLINE",
+			ProjectID:          fakeAPIProjectID,
+		}
+		f.loadBalancers[lb.ID] = &fakeAsyncObject{value: lb, polls: f.pendingPolls, settle: func() {
+			lb.ProvisioningStatus = activeStatus
+			lb.OperatingStatus = "ONLINE"
+		}}
+		writeFakeJSON(w, http.StatusAccepted, map[string]interface{}{"loadbalancer": lb})
+	case len(parts) == 2 && parts[0] == "loadbalancer":
+		obj, ok := f.loadBalancers[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		lb := obj.value.(*gobizfly.LoadBalancer)
+		switch r.Method {
+		case http.MethodGet:
+			obj.poll()
+			if obj.deleted {
+				delete(f.loadBalancers, lb.ID)
+				writeFakeError(w, http.StatusNotFound)
+				return
+			}
+			writeFakeJSON(w, http.StatusOK, lb)
+		case http.MethodPut:
+			var req struct {
+				LoadBalancer gobizfly.LoadBalancerUpdateRequest `json:"loadbalancer"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			if req.LoadBalancer.Name != nil {
+				lb.Name = *req.LoadBalancer.Name
+			}
+			if req.LoadBalancer.Description != nil {
+				lb.Description = *req.LoadBalancer.Description
+			}
+			writeFakeJSON(w, http.StatusOK, map[string]interface{}{"loadbalancer": lb})
+		case http.MethodDelete:
+			lb.ProvisioningStatus = "PENDING_DELETE"
+			obj.polls = f.pendingPolls
+			obj.settle = func() {
+				obj.deleted = true
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+func (f *fakeBizflyAPI) serveDNS(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "zones" && r.Method == http.MethodGet:
+		zones := make([]gobizfly.Zone, 0, len(f.zones))
+		for _, zone := range f.zones {
+			zones = append(zones, zone.Zone)
+		}
+		sort.Slice(zones, func(i, j int) bool { return zones[i].ID < zones[j].ID })
+		page, limit := 1, len(zones)
+		if n, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && n > 0 {
+			page = n
+		}
+		if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+			limit = n
+		}
+		if f.zonesPerPage > 0 && limit > f.zonesPerPage {
+			limit = f.zonesPerPage
+		}
+		meta := gobizfly.Meta{Total: len(zones), Page: page, MaxResults: limit}
+		if limit > 0 {
+			start := (page - 1) * limit
+			if start > len(zones) {
+				start = len(zones)
+			}
+			end := start + limit
+			if end > len(zones) {
+				end = len(zones)
+			}
+			zones = zones[start:end]
+		}
+		writeFakeJSON(w, http.StatusOK, gobizfly.ListZoneResp{Zones: zones, Meta: meta})
+	case len(parts) == 1 && parts[0] == "zones" && r.Method == http.MethodPost:
+		var req gobizfly.WrappedZonePayload
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Zones == nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		now := time.Now().Format(time.RFC3339)
+		zone := &gobizfly.ExtendedZone{
+			Zone: gobizfly.Zone{
+				ID:         f.newID("zone"),
+				Name:       req.Zones.Name,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+				TenantID:   fakeAPIProjectID,
+				NameServer: []string{"ns1.bizflycloud.vn", "ns2.bizflycloud.vn"},
+				TTL:        3600,
+				Active:     true,
+			},
+			RecordsSet: []gobizfly.Record{},
+		}
+		f.zones[zone.ID] = zone
+		writeFakeJSON(w, http.StatusCreated, zone)
+	case len(parts) == 2 && parts[0] == "zone":
+		zone, ok := f.zones[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			zone.RecordsSet = f.zoneRecords(zone.ID)
+			writeFakeJSON(w, http.StatusOK, zone)
+		case http.MethodPut:
+			var req struct {
+				Zones *struct {
+					Active *bool `json:"active"`
+					TTL    int   `json:"ttl"`
+				} `json:"zones"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Zones == nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			if req.Zones.Active != nil {
+				zone.Active = *req.Zones.Active
+			}
+			if req.Zones.TTL != 0 {
+				zone.TTL = req.Zones.TTL
+			}
+			zone.UpdatedAt = time.Now().Format(time.RFC3339)
+			writeFakeJSON(w, http.StatusOK, zone)
+		case http.MethodDelete:
+			for id, record := range f.records {
+				if record.ZoneID == zone.ID {
+					delete(f.records, id)
+				}
+			}
+			delete(f.zones, zone.ID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	case len(parts) == 3 && parts[0] == "zone" && parts[2] == "record" && r.Method == http.MethodPost:
+		if _, ok := f.zones[parts[1]]; !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		var req struct {
+			Record fakeRecord `json:"record"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		record := req.Record.Record
+		record.ID = f.newID("record")
+		record.ZoneID = parts[1]
+		record.TenantID = fakeAPIProjectID
+		f.records[record.ID] = &record
+		f.recordPolicy[record.ID] = req.Record.RoutingPolicyData
+		writeFakeJSON(w, http.StatusCreated, map[string]interface{}{"record": f.record(record.ID)})
+	case len(parts) == 2 && parts[0] == "record":
+		record, ok := f.records[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeFakeJSON(w, http.StatusOK, map[string]interface{}{"record": f.record(record.ID)})
+		case http.MethodPut:
+			var req struct {
+				Record fakeRecord `json:"record"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			if req.Record.Name != "" {
+				record.Name = req.Record.Name
+			}
+			if req.Record.Type != "" {
+				record.Type = req.Record.Type
+			}
+			if req.Record.TTL != 0 {
+				record.TTL = req.Record.TTL
+			}
+			record.Data = req.Record.Data
+			if req.Record.RoutingPolicyData != nil {
+				f.recordPolicy[record.ID] = req.Record.RoutingPolicyData
+			}
+			writeFakeJSON(w, http.StatusOK, f.record(record.ID))
+		case http.MethodDelete:
+			delete(f.records, record.ID)
+			delete(f.recordPolicy, record.ID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+// fakeRecord is a DNS record with its routing policy, which gobizfly.Record
+// does not carry.
+type fakeRecord struct {
+	gobizfly.Record
+	RoutingPolicyData json.RawMessage `json:"routing_policy_data,omitempty"`
+}
+
+func (f *fakeBizflyAPI) record(id string) fakeRecord {
+	policy := f.recordPolicy[id]
+	if policy == nil {
+		policy = json.RawMessage("{}")
+	}
+	return fakeRecord{Record: *f.records[id], RoutingPolicyData: policy}
+}
+
+func (f *fakeBizflyAPI) zoneRecords(zoneID string) []gobizfly.Record {
+	records := make([]gobizfly.Record, 0)
+	for _, record := range f.records {
+		if record.ZoneID == zoneID {
+			records = append(records, *record)
+		}
+	}
+	return records
+}
+
+func (f *fakeBizflyAPI) serveCloudDatabase(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "instances" && r.Method == http.MethodPost:
+		var req gobizfly.CloudDatabaseInstanceCreate
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		instance := &gobizfly.CloudDatabaseInstance{
+			ID:           f.newID("instance"),
+			Name:         req.Name,
+			InstanceType: req.InstanceType,
+			Datastore:    req.Datastore,
+			PublicAccess: req.PublicAccess,
+			Status:       "BUILD",
+			TaskID:       f.newID("task"),
+			ProjectID:    fakeAPIProjectID,
+			CreatedAt:    time.Now().Format(time.RFC3339),
+		}
+		instance.Volume.Size = req.VolumeSize
+		instance.Nodes = []gobizfly.CloudDatabaseNode{{ID: f.newID("node"), Name: req.Name + "-primary", Role: "primary", Status: "BUILD"}}
+		f.databases[instance.ID] = &fakeAsyncObject{value: instance, polls: f.pendingPolls, settle: func() {
+			instance.Status = "ACTIVE"
+			for i := range instance.Nodes {
+				instance.Nodes[i].Status = "ACTIVE"
+			}
+		}}
+		writeFakeJSON(w, http.StatusAccepted, instance)
+	case len(parts) == 2 && parts[0] == "instances":
+		obj, ok := f.databases[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		instance := obj.value.(*gobizfly.CloudDatabaseInstance)
+		switch r.Method {
+		case http.MethodGet:
+			obj.poll()
+			writeFakeJSON(w, http.StatusOK, instance)
+		case http.MethodDelete:
+			instance.Status = "SHUTDOWN"
+			taskID := f.newID("task")
+			f.databaseTasks[taskID] = &fakeTask{polls: f.pendingPolls, done: func() interface{} {
+				delete(f.databases, instance.ID)
+				return nil
+			}}
+			writeFakeJSON(w, http.StatusAccepted, gobizfly.CloudDatabaseMessageResponse{Message: "deleting", TaskID: taskID})
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	case len(parts) == 3 && parts[0] == "tasks" && parts[2] == "status":
+		task, ok := f.databaseTasks[parts[1]]
+		if !ok {
+			// Tasks which are not tracked (such as the creation task) are done.
+			writeFakeJSON(w, http.StatusOK, gobizfly.CloudDatabaseTask{Ready: true})
+			return
+		}
+		writeFakeJSON(w, http.StatusOK, gobizfly.CloudDatabaseTask{Ready: task.poll()})
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+func (f *fakeBizflyAPI) serveKafka(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "clusters" && r.Method == http.MethodGet:
+		clusters := make([]*gobizfly.KafkaCluster, 0, len(f.kafkaClusters))
+		name := r.URL.Query().Get("name")
+		for _, obj := range f.kafkaClusters {
+			obj.poll()
+			cluster := obj.value.(*gobizfly.ClusterResponse)
+			if obj.deleted || (name != "" && cluster.Name != name) {
+				continue
+			}
+			clusters = append(clusters, &gobizfly.KafkaCluster{
+				ID:               cluster.ID,
+				Name:             cluster.Name,
+				KafkaVersion:     cluster.KafkaVersion,
+				Nodes:            len(cluster.Nodes),
+				Flavor:           cluster.Flavor,
+				VolumeSize:       cluster.VolumeSize,
+				Status:           cluster.Status,
+				AvailabilityZone: cluster.AvailabilityZone,
+				PublicAccess:     cluster.PublicAccess,
+			})
+		}
+		f.purgeDeletedKafkaClusters()
+		writeFakeJSON(w, http.StatusOK, gobizfly.ListClusterResponse{Success: true, Data: clusters})
+	case len(parts) == 1 && parts[0] == "clusters" && r.Method == http.MethodPost:
+		var req gobizfly.KafkaInitClusterRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		cluster := &gobizfly.ClusterResponse{
+			ID:               f.newID("kafka"),
+			Name:             req.ClusterName,
+			KafkaVersion:     req.VersionID,
+			Flavor:           req.Flavor,
+			VolumeSize:       req.VolumeSize,
+			Status:           "Creating",
+			AvailabilityZone: req.AvailabilityZone,
+			PublicAccess:     req.PublicAccess,
+			ProjectID:        fakeAPIProjectID,
+			CreatedAt:        time.Now().Format(time.RFC3339),
+		}
+		for i := 0; i < req.Nodes; i++ {
+			cluster.Nodes = append(cluster.Nodes, gobizfly.NodeResponse{ID: f.newID("kafka-node"), ClusterID: cluster.ID})
+		}
+		f.kafkaClusters[cluster.ID] = &fakeAsyncObject{value: cluster, polls: f.pendingPolls, settle: func() {
+			cluster.Status = "Active"
+		}}
+		writeFakeJSON(w, http.StatusAccepted, gobizfly.KafkaTaskResponse{TaskID: f.newID("task")})
+	case len(parts) >= 2 && parts[0] == "clusters":
+		obj, ok := f.kafkaClusters[parts[1]]
+		if !ok || obj.deleted {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		cluster := obj.value.(*gobizfly.ClusterResponse)
+		switch {
+		case len(parts) == 2 && r.Method == http.MethodGet:
+			obj.poll()
+			writeFakeJSON(w, http.StatusOK, gobizfly.GetClusterResponse{Success: true, Data: cluster})
+		case len(parts) == 2 && r.Method == http.MethodPut:
+			var req gobizfly.KafkaResizeClusterRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			cluster.Status = "Resizing"
+			obj.polls = f.pendingPolls
+			obj.settle = func() {
+				if req.Type == "flavor" {
+					cluster.Flavor = req.Flavor
+				} else {
+					cluster.VolumeSize = req.VolumeSize
+				}
+				cluster.Status = "Active"
+			}
+			writeFakeJSON(w, http.StatusAccepted, gobizfly.KafkaTaskResponse{TaskID: f.newID("task")})
+		case len(parts) == 3 && parts[2] == "resize" && r.Method == http.MethodPost:
+			var req gobizfly.KafkaAddNodeRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			cluster.Status = "Resizing"
+			obj.polls = f.pendingPolls
+			obj.settle = func() {
+				for i := 0; i < req.Nodes; i++ {
+					cluster.Nodes = append(cluster.Nodes, gobizfly.NodeResponse{ID: f.newID("kafka-node"), ClusterID: cluster.ID})
+				}
+				cluster.Status = "Active"
+			}
+			writeFakeJSON(w, http.StatusAccepted, gobizfly.KafkaTaskResponse{TaskID: f.newID("task")})
+		case len(parts) == 2 && r.Method == http.MethodDelete:
+			cluster.Status = "Destroying"
+			obj.polls = f.pendingPolls
+			obj.settle = func() {
+				obj.deleted = true
+			}
+			writeFakeJSON(w, http.StatusAccepted, gobizfly.KafkaTaskResponse{TaskID: f.newID("task")})
+		default:
+			writeFakeError(w, http.StatusNotFound)
+		}
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+func (f *fakeBizflyAPI) purgeDeletedKafkaClusters() {
+	for id, obj := range f.kafkaClusters {
+		if obj.deleted {
+			delete(f.kafkaClusters, id)
+		}
+	}
+}
+
+func (f *fakeBizflyAPI) serveKubernetesEngine(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] != "_" {
+		writeFakeError(w, http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		var req gobizfly.ClusterCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		cluster := &gobizfly.FullCluster{}
+		cluster.UID = f.newID("cluster")
+		cluster.Name = req.Name
+		cluster.Version.ID = req.Version
+		cluster.ClusterPackage.ID = req.Package
+		cluster.VPCNetworkID = req.VPCNetworkID
+		cluster.AutoUpgrade = req.AutoUpgrade
+		cluster.LocalDNS = req.LocalDNS
+		cluster.CNIPlugin = req.CNIPlugin
+		cluster.Tags = req.Tags
+		cluster.ProvisionStatus = "PROVISIONED"
+		for _, pool := range req.WorkerPools {
+			cluster.WorkerPools = append(cluster.WorkerPools, gobizfly.ExtendedWorkerPool{
+				WorkerPool:      pool,
+				UID:             f.newID("pool"),
+				ProvisionStatus: "PROVISIONED",
+			})
+		}
+		cluster.WorkerPoolsCount = len(cluster.WorkerPools)
+		f.clusters[cluster.UID] = cluster
+		writeFakeJSON(w, http.StatusCreated, map[string]interface{}{"cluster": cluster.ExtendedCluster})
+	case len(parts) == 2:
+		cluster, ok := f.clusters[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeFakeJSON(w, http.StatusOK, cluster)
+			// the cluster is gone once its destruction has been seen
+			if cluster.ProvisionStatus == "DESTROYING" {
+				delete(f.clusters, cluster.UID)
+			}
+		case http.MethodPatch:
+			var req struct {
+				gobizfly.UpdateClusterRequest
+				Tags *[]string `json:"tags"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			if req.AutoUpgrade != nil {
+				cluster.AutoUpgrade = *req.AutoUpgrade
+			}
+			if req.Tags != nil {
+				cluster.Tags = *req.Tags
+			}
+			writeFakeJSON(w, http.StatusOK, cluster.ExtendedCluster)
+		case http.MethodDelete:
+			cluster.ProvisionStatus = "DESTROYING"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	case len(parts) == 3:
+		cluster, ok := f.clusters[parts[1]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		var pool *gobizfly.ExtendedWorkerPool
+		index := 0
+		for i := range cluster.WorkerPools {
+			if cluster.WorkerPools[i].UID == parts[2] {
+				pool = &cluster.WorkerPools[i]
+				index = i
+			}
+		}
+		if pool == nil {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeFakeJSON(w, http.StatusOK, gobizfly.WorkerPoolWithNodes{ExtendedWorkerPool: *pool})
+		case http.MethodPatch:
+			var req struct {
+				gobizfly.UpdateWorkerPoolRequest
+				Tags *[]string `json:"tags"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			if req.DesiredSize > 0 {
+				pool.DesiredSize = req.DesiredSize
+			}
+			if req.Tags != nil {
+				pool.Tags = *req.Tags
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodDelete:
+			cluster.WorkerPools = append(cluster.WorkerPools[:index], cluster.WorkerPools[index+1:]...)
+			cluster.WorkerPoolsCount = len(cluster.WorkerPools)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+// serveCDN keeps CDN domains as plain JSON objects: updates are merged into
+// the domain key by key, like the real API does.
+func (f *fakeBizflyAPI) serveCDN(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 2 && parts[0] == "users" && parts[1] == "domains" && r.Method == http.MethodGet:
+		domains := make([]map[string]interface{}, 0, len(f.cdnDomains))
+		for _, domain := range f.cdnDomains {
+			domains = append(domains, domain)
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{"results": domains, "pages": 1, "total": len(domains)})
+	case len(parts) == 2 && parts[0] == "clients" && parts[1] == "domains" && r.Method == http.MethodPost:
+		var req gobizfly.CreateDomainPayload
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Origin == nil {
+			writeFakeError(w, http.StatusBadRequest)
+			return
+		}
+		id := f.newID("domain")
+		domain := map[string]interface{}{
+			"domain":     req.Domain,
+			"domain_id":  id,
+			"slug":       strings.ReplaceAll(req.Domain, ".", "-"),
+			"domain_cdn": id + ".cdn.bizflycloud.vn",
+			"origin":     req.Origin,
+		}
+		f.cdnDomains[id] = domain
+		writeFakeJSON(w, http.StatusCreated, map[string]interface{}{"message": "created", "domain": domain})
+	case len(parts) == 3 && parts[0] == "clients" && parts[1] == "domains":
+		domain, ok := f.cdnDomains[parts[2]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeFakeJSON(w, http.StatusOK, map[string]interface{}{"domain": domain})
+		case http.MethodPut:
+			var req map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeFakeError(w, http.StatusBadRequest)
+				return
+			}
+			for k, v := range req {
+				domain[k] = v
+			}
+			writeFakeJSON(w, http.StatusOK, map[string]interface{}{"message": "updated", "domain": domain})
+		case http.MethodDelete:
+			// Purging the cache uses the same route as deleting the
+			// domain, with the files to purge in the body.
+			var req gobizfly.Files
+			if err := json.NewDecoder(r.Body).Decode(&req); err == nil {
+				f.cdnPurges[parts[2]] = append(f.cdnPurges[parts[2]], req.Files)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			delete(f.cdnDomains, parts[2])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed)
+		}
+	default:
+		writeFakeError(w, http.StatusNotFound)
+	}
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeFakeError(w http.ResponseWriter, status int) {
+	writeFakeJSON(w, status, map[string]string{"message": http.StatusText(status)})
+}
+
+// testContext returns a context whose waiters run on a fake clock, so that
+// delays and poll intervals elapse instantly against the fake API.
+func testContext() context.Context {
+	return waiter.WithClock(context.Background(), waiter.NewFakeClock(time.Now()))
+}
+
+// testResourceCreate runs the resource Create function with the given raw
+// configuration and fails the test on error.
+func testResourceCreate(t *testing.T, r *schema.Resource, raw map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(testContext(), d, meta); diags.HasError() {
+		t.Fatalf("error creating resource: %v", diagnosticsError(diags))
+	}
+	if d.Id() == "" {
+		t.Fatal("resource ID is not set after create")
+	}
+	return d
+}
+
+// testResourceUpdate plans the given raw configuration against the state of
+// d and applies it, which runs the resource Update function.
+func testResourceUpdate(t *testing.T, r *schema.Resource, d *schema.ResourceData, raw map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+	state := d.State()
+	diff, err := r.Diff(testContext(), state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("error planning update: %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("update unexpectedly requires a new resource")
+	}
+	newState, diags := r.Apply(testContext(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("error updating resource: %v", diagnosticsError(diags))
+	}
+	return r.Data(newState)
+}
+
+// testResourceImport imports the resource by ID and reads it back.
+func testResourceImport(t *testing.T, r *schema.Resource, id string, meta interface{}) *schema.ResourceData {
+	t.Helper()
+	d := r.Data(nil)
+	d.SetId(id)
+	imported, err := r.Importer.StateContext(testContext(), d, meta)
+	if err != nil {
+		t.Fatalf("error importing resource %s: %v", id, err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+	if diags := r.ReadContext(testContext(), imported[0], meta); diags.HasError() {
+		t.Fatalf("error reading imported resource %s: %v", id, diagnosticsError(diags))
+	}
+	return imported[0]
+}
+
+// testResourceDelete runs the resource Delete function.
+func testResourceDelete(t *testing.T, r *schema.Resource, d *schema.ResourceData, meta interface{}) {
+	t.Helper()
+	if diags := r.DeleteContext(testContext(), d, meta); diags.HasError() {
+		t.Fatalf("error deleting resource: %v", diagnosticsError(diags))
+	}
+}
+
+func testCheckResourceDataAttr(t *testing.T, d *schema.ResourceData, key string, expected interface{}) {
+	t.Helper()
+	if actual := d.Get(key); actual != expected {
+		t.Errorf("expected %s to be %v, got %v", key, expected, actual)
+	}
+}
+
+// testCheckNoDiff checks that planning the same configuration again does not
+// show a change.
+func testCheckNoDiff(t *testing.T, r *schema.Resource, d *schema.ResourceData, raw map[string]interface{}, meta interface{}) {
+	t.Helper()
+	diff, err := r.Diff(testContext(), d.State(), terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("error planning: %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes, got %v", diff.Attributes)
+	}
+}
+
+// testCheckResourceGone reads the resource after it has been deleted outside
+// of Terraform and checks that it is removed from the state without error.
+func testCheckResourceGone(t *testing.T, r *schema.Resource, d *schema.ResourceData, meta interface{}) {
+	t.Helper()
+	id := d.Id()
+	if diags := r.ReadContext(testContext(), d, meta); diags.HasError() {
+		t.Fatalf("error reading resource %s deleted outside of terraform: %v", id, diagnosticsError(diags))
+	}
+	if d.Id() != "" {
+		t.Errorf("expected resource %s to be removed from state, ID is still %q", id, d.Id())
+	}
+}
+
+// testDataSourceRead reads the data source with the given raw configuration
+// and fails the test on error.
+func testDataSourceRead(t *testing.T, r *schema.Resource, raw map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.ReadContext(testContext(), d, meta); diags.HasError() {
+		t.Fatalf("error reading data source: %v", diagnosticsError(diags))
+	}
+	return d
+}
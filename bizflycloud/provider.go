@@ -18,25 +18,33 @@
 package bizflycloud
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a schema.Provider for Bizfly Cloud.
-func Provider() terraform.ResourceProvider {
+func Provider() *schema.Provider {
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_endpoint": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "The URL use for the Bizfly Cloud API",
-				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_API_ENDPOINT", "https://manage.bizflycloud.vn/api"),
+				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_API_ENDPOINT", nil),
 			},
 			"auth_method": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_AUTH_METHOD", "password"),
-				Description: "Authentication method for Bizfly Cloud API",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BIZFLYCLOUD_AUTH_METHOD", nil),
+				ValidateFunc: validation.StringInSlice(authMethods, false),
+				Description:  "Authentication method for Bizfly Cloud API",
 			},
 			"email": {
 				Type:        schema.TypeString,
@@ -47,6 +55,7 @@ func Provider() terraform.ResourceProvider {
 			"password": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				Description: "Password for email with auth_method password",
 				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_PASSWORD", nil),
 			},
@@ -59,6 +68,7 @@ func Provider() terraform.ResourceProvider {
 			"application_credential_secret": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				Description: "Application credential secret for authenticate use application_credential",
 				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_APPLICATION_CREDENTIAL_SECRET", nil),
 			},
@@ -66,7 +76,7 @@ func Provider() terraform.ResourceProvider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Bizfly Cloud Region Name. Default is HaNoi",
-				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_REGION_NAME", "HaNoi"),
+				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_REGION_NAME", nil),
 			},
 			"project_id": {
 				Type:        schema.TypeString,
@@ -74,6 +84,64 @@ func Provider() terraform.ResourceProvider {
 				Description: "Bizfly Cloud Project ID",
 				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_PROJECT_ID", nil),
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the profile in the shared credentials file. Default is default",
+				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_PROFILE", nil),
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the shared credentials file. Default is ~/.bizflycloud/credentials",
+				DefaultFunc: schema.EnvDefaultFunc("BIZFLYCLOUD_SHARED_CREDENTIALS_FILE", defaultSharedCredentialsFile),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of times a failed API request is retried. Default is 3",
+				DefaultFunc:  schema.EnvDefaultFunc("BIZFLYCLOUD_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum time in seconds to wait before retrying a failed API request. Default is 1",
+				Default:      int(defaultRetryWaitMin / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum time in seconds to wait before retrying a failed API request. Default is 30",
+				Default:      int(defaultRetryWaitMax / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retryable_status_codes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(400, 599),
+				},
+				Description: "HTTP status codes of API responses which are retried. Default is 429, 502, 503 and 504",
+			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags added to every resource which supports tags",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The default tags",
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"bizflycloud_server":                               resourceBizflyCloudServer(),
@@ -101,6 +169,7 @@ func Provider() terraform.ResourceProvider {
 			"bizflycloud_vpc_network":                          resourceBizflyCloudVPCNetwork(),
 			"bizflycloud_network_interface":                    resourceBizflyCloudNetworkInterface(),
 			"bizflycloud_dns":                                  resourceBizflyCloudDNS(),
+			"bizflycloud_dns_record":                           resourceBizflyCloudDNSRecord(),
 			"bizflycloud_wan_ip":                               resourceBizflyCloudWanIP(),
 			"bizflycloud_scheduled_volume_backup":              resourceBizflyCloudScheduledVolumeBackup(),
 			"bizflycloud_cloud_database_backup":                resourceBizflyCloudDatabaseBackup(),
@@ -110,6 +179,7 @@ func Provider() terraform.ResourceProvider {
 			"bizflycloud_custom_image":                         resourceBizflyCloudCustomImage(),
 			"bizflycloud_volume_attachment":                    resourceBizflyCloudVolumeAttachment(),
 			"bizflycloud_cdn":                                  resourceBizflyCloudCDN(),
+			"bizflycloud_cdn_purge":                            resourceBizflyCloudCDNPurge(),
 			"bizflycloud_internet_gateway":                     resourceInternetGateway(),
 			"bizflycloud_container_registry":                   resourceBizflyCloudContainerRegistry(),
 			"bizflycloud_kafka":                                resourceBizflyCloudKafka(),
@@ -122,10 +192,11 @@ func Provider() terraform.ResourceProvider {
 			"bizflycloud_kubernetes_version":               datasourceBizflyCloudKubernetesControllerVersions(),
 			"bizflycloud_kubernetes_package":               datasourceBizflyCloudKubernetesControllerPackage(),
 			"bizflycloud_network_interface":                dataSourceBizflyCloudNetworkInterface(),
-			"bizflycloud_server":                           datasourceBizflyCloudServers(),
+			"bizflycloud_server":                           datasourceBizflyCloudServer(),
 			"bizflycloud_autoscaling_nodes":                datasourceBizflyCloudAutoscalingNodes(),
 			"bizflycloud_ssh_key":                          dataSourceBizflyCloudSSHKey(),
 			"bizflycloud_wan_ip":                           dataSourceBizflyCloudWanIP(),
+			"bizflycloud_servers":                          dataSourceBizflyCloudServers(),
 			"bizflycloud_server_type":                      dataSourceBizflyCloudServerTypes(),
 			"bizflycloud_volume_type":                      datasourceBizflyCloudVolumeTypes(),
 			"bizflycloud_cloud_database_backup":            datasourceBizflyCloudDatabaseBackup(),
@@ -138,35 +209,111 @@ func Provider() terraform.ResourceProvider {
 			"bizflycloud_kafka":                            dataSourceBizflyCloudKafka(),
 			"bizflycloud_kafka_version":                    dataSourceBizflyCloudKafkaVersion(),
 			"bizflycloud_kafka_flavor":                     dataSourceBizflyCloudKafkaFlavor(),
+			"bizflycloud_dns_zone":                         dataSourceBizflyCloudDNSZone(),
+			"bizflycloud_dns_records":                      dataSourceBizflyCloudDNSRecords(),
+			"bizflycloud_cdn":                              dataSourceBizflyCloudCDN(),
 		},
 	}
-	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := p.TerraformVersion
 		if terraformVersion == "" {
 			// Terraform 0.12 introduced this field to the protocol
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return providerConfigure(d, terraformVersion)
+		return providerConfigure(ctx, d, terraformVersion)
 	}
 	return p
 }
 
-func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
-	config := Config{
-		APIEndpoint:         d.Get("api_endpoint").(string),
-		AuthMethod:          d.Get("auth_method").(string),
-		Email:               d.Get("email").(string),
-		Password:            d.Get("password").(string),
-		AppCredentialID:     d.Get("application_credential_id").(string),
-		AppCredentialSecret: d.Get("application_credential_secret").(string),
-		RegionName:          d.Get("region_name").(string),
-		TerraformVersion:    terraformVersion,
-		ProjectID:           d.Get("project_id").(string),
+func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
+	config, diags := providerConfig(d, terraformVersion)
+	if diags.HasError() {
+		return nil, diags
 	}
-	combinedClient, err := config.Client()
+	combinedClient, err := config.Client(ctx)
 	if err != nil {
-		return nil, err
+		return nil, configureDiagnostics(err)
 	}
 	return combinedClient, nil
 }
+
+// providerConfig builds the client configuration. Each setting is taken from
+// the provider argument or its environment variable, then from the shared
+// credentials profile, and finally from the built-in default.
+func providerConfig(d *schema.ResourceData, terraformVersion string) (Config, diag.Diagnostics) {
+	profileName := d.Get("profile").(string)
+	profile, err := loadCredentialsProfile(d.Get("shared_credentials_file").(string), defaultString(profileName, defaultProfile))
+	if err != nil {
+		// Without an explicit profile, a missing credentials file or default
+		// profile simply means that no profile is used.
+		if profileName != "" || !errors.Is(err, errProfileNotFound) {
+			return Config{}, diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Error loading shared credentials profile",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("profile"),
+			}}
+		}
+		log.Printf("[DEBUG] Not using a shared credentials profile: %v", err)
+	}
+	get := func(key, fallback string) string {
+		if v := d.Get(key).(string); v != "" {
+			return v
+		}
+		return defaultString(profile[key], fallback)
+	}
+
+	config := Config{
+		APIEndpoint:         get("api_endpoint", defaultAPIEndpoint),
+		AuthMethod:          get("auth_method", authMethodPassword),
+		Email:               get("email", ""),
+		Password:            get("password", ""),
+		AppCredentialID:     get("application_credential_id", ""),
+		AppCredentialSecret: get("application_credential_secret", ""),
+		RegionName:          get("region_name", defaultRegionName),
+		TerraformVersion:    terraformVersion,
+		ProjectID:           get("project_id", ""),
+		MaxRetries:          d.Get("max_retries").(int),
+		RetryWaitMin:        time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+		RetryWaitMax:        time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+	}
+	for _, code := range d.Get("retryable_status_codes").(*schema.Set).List() {
+		config.RetryableStatusCodes = append(config.RetryableStatusCodes, code.(int))
+	}
+	if v, ok := d.GetOk("default_tags.0.tags"); ok {
+		config.DefaultTags = readStringArray(v.(*schema.Set).List())
+	}
+	return config, nil
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// configureDiagnostics converts an error returned by Config.Client into
+// diagnostics pointing at the offending provider argument when possible.
+func configureDiagnostics(err error) diag.Diagnostics {
+	var configErr *ConfigError
+	if errors.As(err, &configErr) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid provider configuration",
+			Detail:        configErr.Error(),
+			AttributePath: cty.GetAttrPath(configErr.Attribute),
+		}}
+	}
+	var regionErr *RegionError
+	if errors.As(err, &regionErr) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid region_name",
+			Detail:        regionErr.Error(),
+			AttributePath: cty.GetAttrPath("region_name"),
+		}}
+	}
+	return diag.FromErr(err)
+}
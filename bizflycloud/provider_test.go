@@ -19,32 +19,34 @@ package bizflycloud
 
 import (
 	"os"
+	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var (
-	testAccProviders map[string]terraform.ResourceProvider
-	testAccProvider  *schema.Provider
+	testAccProviderFactories map[string]func() (*schema.Provider, error)
+	testAccProvider          *schema.Provider
 )
 
 func init() {
-	testAccProvider = Provider().(*schema.Provider)
-	testAccProviders = map[string]terraform.ResourceProvider{
-		"bizflycloud": testAccProvider,
+	testAccProvider = Provider()
+	testAccProviderFactories = map[string]func() (*schema.Provider, error){
+		"bizflycloud": func() (*schema.Provider, error) {
+			return testAccProvider, nil
+		},
 	}
 }
 
 func TestProvider(t *testing.T) {
-	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
 
 func TestProvider_impl(t *testing.T) {
-	var _ = Provider()
+	var _ *schema.Provider = Provider()
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -55,3 +57,48 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("BIZFLYCLOUD_PASSWORD must be set for acceptance tests")
 	}
 }
+
+func TestProvider_retryConfiguration(t *testing.T) {
+	t.Setenv("BIZFLYCLOUD_MAX_RETRIES", "5")
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"retryable_status_codes": []interface{}{429, 503},
+	})
+	if got := d.Get("max_retries").(int); got != 5 {
+		t.Errorf("expected max_retries 5 from the environment, got %d", got)
+	}
+	if got := d.Get("retry_wait_max").(int); got != 30 {
+		t.Errorf("expected retry_wait_max to default to 30, got %d", got)
+	}
+	if got := d.Get("retryable_status_codes").(*schema.Set).Len(); got != 2 {
+		t.Errorf("expected 2 retryable status codes, got %d", got)
+	}
+}
+
+// secretAttributePattern matches attribute names which hold credentials or
+// other secrets.
+var secretAttributePattern = regexp.MustCompile(`(^|_)(password|secret|secret_key|token|private_key|passphrase|user_data)$`)
+
+func TestProvider_sensitiveAttributes(t *testing.T) {
+	p := Provider()
+	checkSensitiveAttributes(t, "provider", p.Schema)
+	for name, r := range p.ResourcesMap {
+		checkSensitiveAttributes(t, name, r.Schema)
+	}
+	for name, r := range p.DataSourcesMap {
+		checkSensitiveAttributes(t, "data."+name, r.Schema)
+	}
+}
+
+func checkSensitiveAttributes(t *testing.T, path string, attributes map[string]*schema.Schema) {
+	t.Helper()
+	for key, s := range attributes {
+		// Boolean flags such as a server's password attribute only tell
+		// whether a secret is set.
+		if secretAttributePattern.MatchString(key) && s.Type != schema.TypeBool && !s.Sensitive {
+			t.Errorf("%s.%s looks like a secret but is not marked Sensitive", path, key)
+		}
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			checkSensitiveAttributes(t, path+"."+key, r.Schema)
+		}
+	}
+}
@@ -19,34 +19,36 @@ package bizflycloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"strconv"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudAutoscalingGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudAutoscalingGroupCreate,
-		Read:   resourceBizflyCloudAutoscalingGroupRead,
-		Update: resourceBizflyCloudAutoscalingGroupUpdate,
-		Delete: resourceBizflyCloudAutoscalingGroupDelete,
+		CreateContext: resourceBizflyCloudAutoscalingGroupCreate,
+		ReadContext:   resourceBizflyCloudAutoscalingGroupRead,
+		UpdateContext: resourceBizflyCloudAutoscalingGroupUpdate,
+		DeleteContext: resourceBizflyCloudAutoscalingGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 		Schema: resourceAutoScalingGroupSchema(),
 	}
 }
 
-func resourceBizflyCloudAutoscalingGroupCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	ascr := &gobizfly.AutoScalingGroupCreateRequest{
@@ -61,9 +63,9 @@ func resourceBizflyCloudAutoscalingGroupCreate(d *schema.ResourceData, meta inte
 		ascr.LoadBalancerPolicies = readLoadBalancersFromConfig(d)
 	}
 
-	task, err := client.AutoScaling.AutoScalingGroups().Create(context.Background(), ascr)
+	task, err := client.AutoScaling.AutoScalingGroups().Create(ctx, ascr)
 	if err != nil {
-		return fmt.Errorf("[ERROR] create auto scaling group %s failed: %s", d.Get("name"), err)
+		return diag.Errorf("[ERROR] create auto scaling group %s failed: %s", d.Get("name"), err)
 	}
 
 	log.Printf("[DEBUG] creating auto scaling group with task %s", task.TaskID)
@@ -72,23 +74,29 @@ func resourceBizflyCloudAutoscalingGroupCreate(d *schema.ResourceData, meta inte
 	_ = d.Set("task_id", task.TaskID)
 
 	// wait for auto scaling group to become active
-	_, err = waitForAutoScalingGroupReady(d, meta)
+	err = waitForAutoScalingGroupReady(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] create auto scaling group (%s) failed: %s", d.Get("name").(string), err)
+		return diag.Errorf("[ERROR] create auto scaling group (%s) failed: %s", d.Get("name").(string), err)
 	}
 
-	return resourceBizflyCloudAutoscalingGroupRead(d, meta)
+	return resourceBizflyCloudAutoscalingGroupRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingGroupRead(d *schema.ResourceData, meta interface{}) error {
-	if err := dataSourceBizflyCloudAutoScalingGroupRead(d, meta); err != nil {
-		return err
+func resourceBizflyCloudAutoscalingGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	group, err := client.AutoScaling.AutoScalingGroups().Get(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] AutoScaling Group (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error describing AutoScaling Groups: %v", err)
 	}
-
-	return nil
+	return setAutoScalingGroupAttributes(d, group)
 }
 
-func resourceBizflyCloudAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	asur := &gobizfly.AutoScalingGroupUpdateRequest{
@@ -105,14 +113,14 @@ func resourceBizflyCloudAutoscalingGroupUpdate(d *schema.ResourceData, meta inte
 	}
 
 	// wait for auto scaling group to become active
-	_, err := waitForAutoScalingGroupReady(d, meta)
+	err := waitForAutoScalingGroupReady(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] updating auto scaling group (%s) failed: %s", d.Get("name").(string), err)
+		return diag.Errorf("[ERROR] updating auto scaling group (%s) failed: %s", d.Get("name").(string), err)
 	}
 
-	task, err := client.AutoScaling.AutoScalingGroups().Update(context.Background(), d.Id(), asur)
+	task, err := client.AutoScaling.AutoScalingGroups().Update(ctx, d.Id(), asur)
 	if err != nil {
-		return fmt.Errorf("[ERROR] update auto scaling group %s failed: %s", d.Get("name"), err)
+		return diag.Errorf("[ERROR] update auto scaling group %s failed: %s", d.Get("name"), err)
 	}
 
 	log.Printf("[DEBUG] updating auto scaling group with task %s", task.TaskID)
@@ -120,71 +128,58 @@ func resourceBizflyCloudAutoscalingGroupUpdate(d *schema.ResourceData, meta inte
 	_ = d.Set("task_id", task.TaskID)
 
 	// wait for auto scaling group to become active
-	_, err = waitForAutoScalingGroupReady(d, meta)
+	err = waitForAutoScalingGroupReady(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] updating auto scaling group (%s) failed: %s", d.Get("name").(string), err)
+		return diag.Errorf("[ERROR] updating auto scaling group (%s) failed: %s", d.Get("name").(string), err)
 	}
 
-	return resourceBizflyCloudAutoscalingGroupRead(d, meta)
+	return resourceBizflyCloudAutoscalingGroupRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingGroupDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
-	err := client.AutoScaling.AutoScalingGroups().Delete(context.Background(), d.Id())
+	err := client.AutoScaling.AutoScalingGroups().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting auto scaling group %v", err)
+		return diag.Errorf("error deleting auto scaling group %v", err)
 	}
 
 	return nil
 }
 
-func waitForAutoScalingGroupReady(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for auto scaling group (%s) to be ready", d.Get("name").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"CREATING", "RESIZING", "UPDATING"},
-		Target:     []string{"ACTIVE", "ERROR"},
-		Refresh:    newStateRefreshfunc(d, "status", meta),
-		Timeout:    3600 * time.Second,
-		Delay:      20 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	return stateConf.WaitForState()
-}
-
-func newStateRefreshfunc(d *schema.ResourceData, attribute string, meta interface{}) resource.StateRefreshFunc {
+func waitForAutoScalingGroupReady(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
-		resp, err := client.AutoScaling.Tasks().Get(context.Background(), d.Get("task_id").(string))
+	name := d.Get("name").(string)
+	taskID := d.Get("task_id").(string)
+	conf := waiter.Config{
+		Description: fmt.Sprintf("auto scaling group (%s) task (%s) to complete", name, taskID),
+		Timeout:     timeout,
+		Delay:       20 * time.Second,
+	}
+	if _, err := conf.ForTask(ctx, func(ctx context.Context) (interface{}, bool, error) {
+		resp, err := client.AutoScaling.Tasks().Get(ctx, taskID)
 		if err != nil {
-			return nil, "", err
-		}
-		// if the task is not ready, we need to wait for a moment
-		if !resp.Ready {
-			log.Println("[DEBUG] auto scaling is not ready")
-			return nil, "", nil
+			return nil, false, err
 		}
+		return resp, resp.Ready, nil
+	}); err != nil {
+		return err
+	}
 
-		err = resourceBizflyCloudAutoscalingGroupRead(d, meta)
+	conf = waiter.Config{
+		Description: fmt.Sprintf("auto scaling group (%s) to be ready", name),
+		Pending:     []string{"CREATING", "RESIZING", "UPDATING"},
+		Target:      []string{"ACTIVE", "ERROR"},
+		Timeout:     timeout,
+	}
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		asg, err := client.AutoScaling.AutoScalingGroups().Get(ctx, d.Id())
 		if err != nil {
-			return nil, "", err
-		}
-
-		if attr, ok := d.GetOk(attribute); ok {
-			asg, err := client.AutoScaling.AutoScalingGroups().Get(context.Background(), d.Id())
-			if err != nil {
-				return nil, "", fmt.Errorf("error retrieving auto scaling group: %v", err)
-			}
-			_ = asg // ensure variable is recognized as used
-			switch attr := attr.(type) {
-			case bool:
-				return &asg, strconv.FormatBool(attr), nil
-			default:
-				return &asg, attr.(string), nil
-			}
+			return nil, "", fmt.Errorf("error retrieving auto scaling group: %v", err)
 		}
-		return nil, "", nil
-	}
+		return asg, asg.Status, nil
+	})
+	return err
 }
 
 func readLoadBalancersFromConfig(l *schema.ResourceData) *gobizfly.LoadBalancerPolicy {
@@ -19,13 +19,13 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
-
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
@@ -35,11 +35,11 @@ const (
 
 func resourceBizflyCloudAutoscalingLaunchConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudAutoscalingLaunchConfigurationCreate,
-		Read:   resourceBizflyCloudAutoscalingLaunchConfigurationRead,
-		Delete: resourceBizflyCloudAutoscalingLaunchConfigurationDelete,
+		CreateContext: resourceBizflyCloudAutoscalingLaunchConfigurationCreate,
+		ReadContext:   resourceBizflyCloudAutoscalingLaunchConfigurationRead,
+		DeleteContext: resourceBizflyCloudAutoscalingLaunchConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -49,7 +49,7 @@ func resourceBizflyCloudAutoscalingLaunchConfiguration() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudAutoscalingLaunchConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingLaunchConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	lcr := &gobizfly.LaunchConfiguration{
@@ -110,26 +110,36 @@ func resourceBizflyCloudAutoscalingLaunchConfigurationCreate(d *schema.ResourceD
 		lcr.Networks = networks
 	}
 
-	profile, err := client.AutoScaling.LaunchConfigurations().Create(context.Background(), lcr)
+	profile, err := client.AutoScaling.LaunchConfigurations().Create(ctx, lcr)
 
 	if err != nil {
-		return fmt.Errorf("[ERROR] Launch Configuration create failed: %v", err)
+		return diag.Errorf("[ERROR] Launch Configuration create failed: %v", err)
 	}
 
 	d.SetId(profile.ID)
-	return resourceBizflyCloudAutoscalingLaunchConfigurationRead(d, meta)
+	return resourceBizflyCloudAutoscalingLaunchConfigurationRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingLaunchConfigurationRead(d *schema.ResourceData, meta interface{}) error {
-	return dataBizflyCloudAutoScalingLaunchConfigurationRead(d, meta)
+func resourceBizflyCloudAutoscalingLaunchConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	profile, err := client.AutoScaling.LaunchConfigurations().Get(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Launch Configuration (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error describing Launch Configuration: %v", err)
+	}
+	return setLaunchConfigurationAttributes(d, profile)
 }
 
-func resourceBizflyCloudAutoscalingLaunchConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingLaunchConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	log.Printf("[DEBUG] Launch Configuration destroy: %v", d.Id())
 
 	for retry := retryCount; retry > 0; retry-- {
-		if err := client.AutoScaling.LaunchConfigurations().Delete(context.Background(), d.Id()); err != nil {
+		if err := client.AutoScaling.LaunchConfigurations().Delete(ctx, d.Id()); err != nil {
 			log.Printf("[ERROR] Launch Configuration destroy %v was failed: %v", d.Id(), err)
 			time.Sleep(waitTime)
 			continue
@@ -139,7 +149,7 @@ func resourceBizflyCloudAutoscalingLaunchConfigurationDelete(d *schema.ResourceD
 		return nil
 	}
 
-	return fmt.Errorf("[ERROR] Launch Configuration destroy %v was failed", d.Id())
+	return diag.Errorf("[ERROR] Launch Configuration destroy %v was failed", d.Id())
 }
 
 func readBlockDeviceMappingFromConfig(bdm map[string]interface{}) *gobizfly.AutoScalingDataDisk {
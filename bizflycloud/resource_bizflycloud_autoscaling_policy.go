@@ -21,12 +21,12 @@ import (
 	"context"
 	"fmt"
 	"log"
-
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
@@ -37,15 +37,17 @@ const (
 
 func resourceBizflyCloudAutoscalingScaleInPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudAutoscalingScaleInPolicyCreate,
-		Read:   resourceBizflyCloudAutoscalingScaleInPolicyRead,
-		Update: resourceBizflyCloudAutoscalingScaleInPolicyUpdate,
-		Delete: resourceBizflyCloudAutoscalingScalePolicyDelete,
+		CreateContext: resourceBizflyCloudAutoscalingScaleInPolicyCreate,
+		ReadContext:   resourceBizflyCloudAutoscalingScaleInPolicyRead,
+		UpdateContext: resourceBizflyCloudAutoscalingScaleInPolicyUpdate,
+		DeleteContext: resourceBizflyCloudAutoscalingScalePolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 		Schema: resourceScaleInPolicySchema(),
@@ -54,14 +56,16 @@ func resourceBizflyCloudAutoscalingScaleInPolicy() *schema.Resource {
 
 func resourceBizflyCloudAutoscalingScaleOutPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudAutoscalingScaleOutPolicyCreate,
-		Read:   resourceBizflyCloudAutoscalingScaleOutPolicyRead,
-		Update: resourceBizflyCloudAutoscalingScaleOutPolicyUpdate,
-		Delete: resourceBizflyCloudAutoscalingScalePolicyDelete,
+		CreateContext: resourceBizflyCloudAutoscalingScaleOutPolicyCreate,
+		ReadContext:   resourceBizflyCloudAutoscalingScaleOutPolicyRead,
+		UpdateContext: resourceBizflyCloudAutoscalingScaleOutPolicyUpdate,
+		DeleteContext: resourceBizflyCloudAutoscalingScalePolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 		Schema: resourceScaleOutPolicySchema(),
@@ -70,14 +74,16 @@ func resourceBizflyCloudAutoscalingScaleOutPolicy() *schema.Resource {
 
 func resourceBizflyCloudAutoscalingDeletionPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudAutoscalingDeletionPolicyCreate,
-		Read:   resourceBizflyCloudAutoscalingDeletionPolicyRead,
-		Update: resourceBizflyCloudAutoscalingDeletionPolicyUpdate,
-		Delete: resourceBizflyCloudAutoscalingDeletionPolicyDelete,
+		CreateContext: resourceBizflyCloudAutoscalingDeletionPolicyCreate,
+		ReadContext:   resourceBizflyCloudAutoscalingDeletionPolicyRead,
+		UpdateContext: resourceBizflyCloudAutoscalingDeletionPolicyUpdate,
+		DeleteContext: resourceBizflyCloudAutoscalingDeletionPolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 		Schema: resourceDeletionPolicySchema(),
@@ -86,18 +92,18 @@ func resourceBizflyCloudAutoscalingDeletionPolicy() *schema.Resource {
 
 // Scale Policy
 
-func resourceBizflyCloudAutoscalingScaleInPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingScaleInPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Get("cluster_id").(string)
 
-	if _, err := waitForAutoScalingGroupPolicyAvailableInteractive(d, meta); err != nil {
-		return fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
+	if err := waitForAutoScalingGroupPolicyAvailableInteractive(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
 	}
 
 	if d.Get("metric_type").(string) == requestPerSecond {
-		policies, err := client.AutoScaling.Policies().List(context.Background(), clusterID)
+		policies, err := client.AutoScaling.Policies().List(ctx, clusterID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] errors when create scale in policy for cluster: %s, error: %s", clusterID, err)
+			return diag.Errorf("[ERROR] errors when create scale in policy for cluster: %s, error: %s", clusterID, err)
 		}
 
 		lb := policies.LoadBalancerPolicies
@@ -115,12 +121,12 @@ func resourceBizflyCloudAutoscalingScaleInPolicyCreate(d *schema.ResourceData, m
 			ScaleSize: d.Get("scale_size").(int),
 			Threshold: d.Get("threshold").(int),
 		}
-		retry := maxRetry
-		for retry > 0 {
-			task, err := client.AutoScaling.Policies().CreateLoadBalancers(context.Background(), clusterID, lbpcr)
+		retries := maxRetry
+		for retries > 0 {
+			task, err := client.AutoScaling.Policies().CreateLoadBalancers(ctx, clusterID, lbpcr)
 			if err != nil {
 				fmt.Printf("[WARNING] errors when create scale in policy for cluster: %s, error: %s", clusterID, err)
-				retry = retry - 1
+				retries = retries - 1
 				time.Sleep(timeSleep)
 				continue
 			}
@@ -137,12 +143,12 @@ func resourceBizflyCloudAutoscalingScaleInPolicyCreate(d *schema.ResourceData, m
 			ScaleSize:  d.Get("scale_size").(int),
 			Threshold:  d.Get("threshold").(int),
 		}
-		retry := maxRetry
-		for retry > 0 {
-			task, err := client.AutoScaling.Policies().CreateAutoScaling(context.Background(), clusterID, pcr)
+		retries := maxRetry
+		for retries > 0 {
+			task, err := client.AutoScaling.Policies().CreateAutoScaling(ctx, clusterID, pcr)
 			if err != nil {
 				fmt.Printf("[WARNING] errors when create scale in policy for cluster: %s, error: %s", clusterID, err)
-				retry = retry - 1
+				retries = retries - 1
 				time.Sleep(timeSleep)
 				continue
 			}
@@ -151,35 +157,35 @@ func resourceBizflyCloudAutoscalingScaleInPolicyCreate(d *schema.ResourceData, m
 		}
 	}
 
-	_, err := waitForAutoScalingGroupPolicyReady(d, meta)
+	err := waitForAutoScalingGroupPolicyReady(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] errors when create scale in policy for cluster: %s, error: %s", clusterID, err)
+		return diag.Errorf("[ERROR] errors when create scale in policy for cluster: %s, error: %s", clusterID, err)
 	}
 
-	return resourceBizflyCloudAutoscalingScaleInPolicyRead(d, meta)
+	return resourceBizflyCloudAutoscalingScaleInPolicyRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingScaleInPolicyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingScaleInPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourceBizflyCloudAutoscalingScaleInPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingScaleInPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Get("cluster_id").(string)
 	policyID := d.Id()
 
 	if d.HasChange("metric_type") {
-		return fmt.Errorf("[ERROR] value of metric_type is not allow change")
+		return diag.Errorf("[ERROR] value of metric_type is not allow change")
 	}
 
-	if _, err := waitForAutoScalingGroupPolicyAvailableInteractive(d, meta); err != nil {
-		return fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
+	if err := waitForAutoScalingGroupPolicyAvailableInteractive(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
 	}
 
 	if d.Get("metric_type").(string) == requestPerSecond {
-		policies, err := client.AutoScaling.Policies().List(context.Background(), clusterID)
+		policies, err := client.AutoScaling.Policies().List(ctx, clusterID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] errors when update scale in policy for cluster: %s, error: %s", clusterID, err)
+			return diag.Errorf("[ERROR] errors when update scale in policy for cluster: %s, error: %s", clusterID, err)
 		}
 
 		lb := policies.LoadBalancerPolicies
@@ -197,12 +203,12 @@ func resourceBizflyCloudAutoscalingScaleInPolicyUpdate(d *schema.ResourceData, m
 			Threshold: d.Get("threshold").(int),
 		}
 
-		retry := maxRetry
-		for retry > 0 {
-			task, err := client.AutoScaling.Policies().UpdateLoadBalancers(context.Background(), clusterID, policyID, lbpur)
+		retries := maxRetry
+		for retries > 0 {
+			task, err := client.AutoScaling.Policies().UpdateLoadBalancers(ctx, clusterID, policyID, lbpur)
 			if err != nil {
 				fmt.Printf("[WARNING] errors when update scale in policy for cluster: %s, error: %s", clusterID, err)
-				retry = retry - 1
+				retries = retries - 1
 				time.Sleep(timeSleep)
 				continue
 			}
@@ -220,12 +226,12 @@ func resourceBizflyCloudAutoscalingScaleInPolicyUpdate(d *schema.ResourceData, m
 			Threshold:  d.Get("threshold").(int),
 		}
 
-		retry := maxRetry
-		for retry > 0 {
-			task, err := client.AutoScaling.Policies().UpdateAutoScaling(context.Background(), clusterID, policyID, pur)
+		retries := maxRetry
+		for retries > 0 {
+			task, err := client.AutoScaling.Policies().UpdateAutoScaling(ctx, clusterID, policyID, pur)
 			if err != nil {
 				fmt.Printf("[WARNING] errors when update scale in policy for cluster: %s, error: %s", clusterID, err)
-				retry = retry - 1
+				retries = retries - 1
 				time.Sleep(timeSleep)
 				continue
 			}
@@ -234,26 +240,26 @@ func resourceBizflyCloudAutoscalingScaleInPolicyUpdate(d *schema.ResourceData, m
 		}
 	}
 
-	_, err := waitForAutoScalingGroupPolicyReady(d, meta)
+	err := waitForAutoScalingGroupPolicyReady(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] errors when update scale in policy for cluster: %s, error: %s", clusterID, err)
+		return diag.Errorf("[ERROR] errors when update scale in policy for cluster: %s, error: %s", clusterID, err)
 	}
 
-	return resourceBizflyCloudAutoscalingScaleInPolicyRead(d, meta)
+	return resourceBizflyCloudAutoscalingScaleInPolicyRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingScaleOutPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingScaleOutPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Get("cluster_id").(string)
 
-	if _, err := waitForAutoScalingGroupPolicyAvailableInteractive(d, meta); err != nil {
-		return fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
+	if err := waitForAutoScalingGroupPolicyAvailableInteractive(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
 	}
 
 	if d.Get("metric_type").(string) == requestPerSecond {
-		policies, err := client.AutoScaling.Policies().List(context.Background(), clusterID)
+		policies, err := client.AutoScaling.Policies().List(ctx, clusterID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] errors when create scale out policy for cluster: %s, error: %s", clusterID, err)
+			return diag.Errorf("[ERROR] errors when create scale out policy for cluster: %s, error: %s", clusterID, err)
 		}
 
 		lb := policies.LoadBalancerPolicies
@@ -272,12 +278,12 @@ func resourceBizflyCloudAutoscalingScaleOutPolicyCreate(d *schema.ResourceData,
 			Threshold: d.Get("threshold").(int),
 		}
 
-		retry := maxRetry
-		for retry > 0 {
-			task, err := client.AutoScaling.Policies().CreateLoadBalancers(context.Background(), clusterID, lbpcr)
+		retries := maxRetry
+		for retries > 0 {
+			task, err := client.AutoScaling.Policies().CreateLoadBalancers(ctx, clusterID, lbpcr)
 			if err != nil {
 				fmt.Printf("[WARNING] errors when create scale out policy for cluster: %s, error: %s", clusterID, err)
-				retry = retry - 1
+				retries = retries - 1
 				time.Sleep(timeSleep)
 				continue
 			}
@@ -295,12 +301,12 @@ func resourceBizflyCloudAutoscalingScaleOutPolicyCreate(d *schema.ResourceData,
 			Threshold:  d.Get("threshold").(int),
 		}
 
-		retry := maxRetry
-		for retry > 0 {
-			task, err := client.AutoScaling.Policies().CreateAutoScaling(context.Background(), clusterID, pcr)
+		retries := maxRetry
+		for retries > 0 {
+			task, err := client.AutoScaling.Policies().CreateAutoScaling(ctx, clusterID, pcr)
 			if err != nil {
 				fmt.Printf("[WARNING] errors when create scale out policy for cluster: %s, error: %s", clusterID, err)
-				retry = retry - 1
+				retries = retries - 1
 				time.Sleep(timeSleep)
 				continue
 			}
@@ -309,35 +315,35 @@ func resourceBizflyCloudAutoscalingScaleOutPolicyCreate(d *schema.ResourceData,
 		}
 	}
 
-	_, err := waitForAutoScalingGroupPolicyReady(d, meta)
+	err := waitForAutoScalingGroupPolicyReady(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] errors when create scale out policy for cluster: %s, error: %s", clusterID, err)
+		return diag.Errorf("[ERROR] errors when create scale out policy for cluster: %s, error: %s", clusterID, err)
 	}
 
-	return resourceBizflyCloudAutoscalingScaleOutPolicyRead(d, meta)
+	return resourceBizflyCloudAutoscalingScaleOutPolicyRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingScaleOutPolicyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingScaleOutPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourceBizflyCloudAutoscalingScaleOutPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingScaleOutPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Get("cluster_id").(string)
 	policyID := d.Id()
 
 	if d.HasChange("metric_type") {
-		return fmt.Errorf("[ERROR] value of metric_type is not allow change")
+		return diag.Errorf("[ERROR] value of metric_type is not allow change")
 	}
 
-	if _, err := waitForAutoScalingGroupPolicyAvailableInteractive(d, meta); err != nil {
-		return fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
+	if err := waitForAutoScalingGroupPolicyAvailableInteractive(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
 	}
 
 	if d.Get("metric_type").(string) == requestPerSecond {
-		policies, err := client.AutoScaling.Policies().List(context.Background(), clusterID)
+		policies, err := client.AutoScaling.Policies().List(ctx, clusterID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] errors when update scale out policy for cluster: %s, error: %s", clusterID, err)
+			return diag.Errorf("[ERROR] errors when update scale out policy for cluster: %s, error: %s", clusterID, err)
 		}
 
 		lb := policies.LoadBalancerPolicies
@@ -356,12 +362,12 @@ func resourceBizflyCloudAutoscalingScaleOutPolicyUpdate(d *schema.ResourceData,
 			Threshold: d.Get("threshold").(int),
 		}
 
-		retry := maxRetry
-		for retry > 0 {
-			task, err := client.AutoScaling.Policies().UpdateLoadBalancers(context.Background(), clusterID, policyID, lbpur)
+		retries := maxRetry
+		for retries > 0 {
+			task, err := client.AutoScaling.Policies().UpdateLoadBalancers(ctx, clusterID, policyID, lbpur)
 			if err != nil {
 				fmt.Printf("[WARNING] errors when update scale out policy for cluster: %s, error: %s", clusterID, err)
-				retry = retry - 1
+				retries = retries - 1
 				time.Sleep(timeSleep)
 				continue
 			}
@@ -379,12 +385,12 @@ func resourceBizflyCloudAutoscalingScaleOutPolicyUpdate(d *schema.ResourceData,
 			Threshold:  d.Get("threshold").(int),
 		}
 
-		retry := maxRetry
-		for retry > 0 {
-			task, err := client.AutoScaling.Policies().UpdateAutoScaling(context.Background(), clusterID, policyID, pur)
+		retries := maxRetry
+		for retries > 0 {
+			task, err := client.AutoScaling.Policies().UpdateAutoScaling(ctx, clusterID, policyID, pur)
 			if err != nil {
 				fmt.Printf("[WARNING] errors when update scale out policy for cluster: %s, error: %s", clusterID, err)
-				retry = retry - 1
+				retries = retries - 1
 				time.Sleep(timeSleep)
 				continue
 			}
@@ -393,24 +399,24 @@ func resourceBizflyCloudAutoscalingScaleOutPolicyUpdate(d *schema.ResourceData,
 		}
 	}
 
-	_, err := waitForAutoScalingGroupPolicyReady(d, meta)
+	err := waitForAutoScalingGroupPolicyReady(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] errors when update scale out policy for cluster: %s, error: %s", clusterID, err)
+		return diag.Errorf("[ERROR] errors when update scale out policy for cluster: %s, error: %s", clusterID, err)
 	}
 
-	return resourceBizflyCloudAutoscalingScaleInPolicyRead(d, meta)
+	return resourceBizflyCloudAutoscalingScaleInPolicyRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingScalePolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingScalePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Get("cluster_id").(string)
 	policyID := d.Id()
 
-	if _, err := waitForAutoScalingGroupPolicyAvailableInteractive(d, meta); err != nil {
-		return fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
+	if err := waitForAutoScalingGroupPolicyAvailableInteractive(ctx, d, meta, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
 	}
 
-	if err := client.AutoScaling.Policies().Delete(context.Background(), clusterID, policyID); err != nil {
+	if err := client.AutoScaling.Policies().Delete(ctx, clusterID, policyID); err != nil {
 		log.Printf("[WARNING] errors when delete scale policy %s for cluster: %s, error: %s", policyID, clusterID, err)
 	}
 
@@ -418,21 +424,21 @@ func resourceBizflyCloudAutoscalingScalePolicyDelete(d *schema.ResourceData, met
 }
 
 // Deletion Policy
-func resourceBizflyCloudAutoscalingDeletionPolicyCreate(d *schema.ResourceData, meta interface{}) error {
-	return resourceBizflyCloudAutoscalingDeletionPolicyRead(d, meta)
+func resourceBizflyCloudAutoscalingDeletionPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceBizflyCloudAutoscalingDeletionPolicyRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingDeletionPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingDeletionPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Get("cluster_id").(string)
 
-	if _, err := waitForAutoScalingGroupPolicyAvailableInteractive(d, meta); err != nil {
-		return fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
+	if err := waitForAutoScalingGroupPolicyAvailableInteractive(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
 	}
 
-	clusterPolicies, err := client.AutoScaling.Policies().List(context.Background(), clusterID)
+	clusterPolicies, err := client.AutoScaling.Policies().List(ctx, clusterID)
 	if err != nil {
-		return fmt.Errorf("[ERROR] error when get policies of cluster (%s): %s", d.Get("cluster_id"), err)
+		return diag.Errorf("[ERROR] error when get policies of cluster (%s): %s", d.Get("cluster_id"), err)
 	}
 
 	deletionPolicy := clusterPolicies.DeletionPolicy
@@ -444,98 +450,83 @@ func resourceBizflyCloudAutoscalingDeletionPolicyUpdate(d *schema.ResourceData,
 			ReduceDesiredCapacity: deletionPolicy.ReduceDesiredCapacity,
 		}
 
-		task, err := client.AutoScaling.Policies().UpdateDeletion(context.Background(), clusterID, deletionPolicy.ID, pdur)
+		task, err := client.AutoScaling.Policies().UpdateDeletion(ctx, clusterID, deletionPolicy.ID, pdur)
 		if err != nil {
-			return fmt.Errorf("[ERROR] errors when update deletion policy for cluster: %s, error: %s", clusterID, err)
+			return diag.Errorf("[ERROR] errors when update deletion policy for cluster: %s, error: %s", clusterID, err)
 		}
 
 		_ = d.Set("task_id", task.TaskID)
 	}
 
-	_, err = waitForAutoScalingGroupPolicyReady(d, meta)
+	err = waitForAutoScalingGroupPolicyReady(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] errors when update deletion policy for cluster: %s, error: %s", clusterID, err)
+		return diag.Errorf("[ERROR] errors when update deletion policy for cluster: %s, error: %s", clusterID, err)
 	}
 
-	return resourceBizflyCloudAutoscalingDeletionPolicyRead(d, meta)
+	return resourceBizflyCloudAutoscalingDeletionPolicyRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudAutoscalingDeletionPolicyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingDeletionPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Get("cluster_id").(string)
 
-	if _, err := waitForAutoScalingGroupPolicyAvailableInteractive(d, meta); err != nil {
-		return fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
+	if err := waitForAutoScalingGroupPolicyAvailableInteractive(ctx, d, meta, d.Timeout(schema.TimeoutRead)); err != nil {
+		return diag.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
 	}
-	clusterPolicies, err := client.AutoScaling.Policies().List(context.Background(), clusterID)
+	clusterPolicies, err := client.AutoScaling.Policies().List(ctx, clusterID)
 	if err != nil {
-		return fmt.Errorf("[ERROR] error when get policies of cluster (%s): %s", d.Get("cluster_id"), err)
+		return diag.Errorf("[ERROR] error when get policies of cluster (%s): %s", d.Get("cluster_id"), err)
 	}
 	d.SetId(clusterPolicies.DeletionPolicy.ID)
 
 	return nil
 }
 
-func resourceBizflyCloudAutoscalingDeletionPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudAutoscalingDeletionPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
 
 // Wait other tasks done
-func waitForAutoScalingGroupPolicyAvailableInteractive(d *schema.ResourceData, meta interface{}) (interface{}, error) {
+func waitForAutoScalingGroupPolicyAvailableInteractive(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-
-	log.Printf("[INFO] Waiting for scaling policy for (%s) to be available", d.Get("cluster_id").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING"},
-		Target:  []string{"DONE"},
-		Refresh: func() (interface{}, string, error) {
-			policies, err := client.AutoScaling.Policies().List(context.Background(), d.Get("cluster_id").(string))
-			if err != nil {
-				return nil, "", fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", d.Get("cluster_id"), err)
-			}
-
-			if len(policies.DoingTasks) > 0 {
-				return &policies, "PENDING", nil
-			}
-
-			return &policies, "DONE", nil
-		},
-		Timeout:    3600 * time.Second,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+	clusterID := d.Get("cluster_id").(string)
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("scaling policy for (%s) to be available", clusterID),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 5 * time.Second,
 	}
-	return stateConf.WaitForState()
+	_, err := conf.ForTask(ctx, func(ctx context.Context) (interface{}, bool, error) {
+		policies, err := client.AutoScaling.Policies().List(ctx, clusterID)
+		if err != nil {
+			return nil, false, fmt.Errorf("[ERROR] error when wait scaling policy available to interactive (%s): %s", clusterID, err)
+		}
+		return policies, len(policies.DoingTasks) == 0, nil
+	})
+	return err
 }
 
 // Wait to create new done
-func waitForAutoScalingGroupPolicyReady(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for create scaling policy for (%s) to be ready", d.Get("cluster_id").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"PENDING"},
-		Target:     []string{"DONE"},
-		Refresh:    newStateRefreshPolicyfunc(d, "ready", meta),
-		Timeout:    3600 * time.Second,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
-	}
-	return stateConf.WaitForState()
-}
-
-func newStateRefreshPolicyfunc(d *schema.ResourceData, attribute string, meta interface{}) resource.StateRefreshFunc {
+func waitForAutoScalingGroupPolicyReady(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
-		resp, err := client.AutoScaling.Tasks().Get(context.Background(), d.Get("task_id").(string))
+	taskID := d.Get("task_id").(string)
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("scaling policy for (%s) to be ready", d.Get("cluster_id").(string)),
+		Timeout:      timeout,
+		Delay:        10 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	result, err := conf.ForTask(ctx, func(ctx context.Context) (interface{}, bool, error) {
+		resp, err := client.AutoScaling.Tasks().Get(ctx, taskID)
 		if err != nil {
-			return nil, "", fmt.Errorf("[ERROR] error when wait task %s done: %s", d.Get("task_id"), err)
-		}
-
-		if !resp.Ready {
-			return &resp, "PENDING", nil
+			return nil, false, fmt.Errorf("[ERROR] error when wait task %s done: %s", taskID, err)
 		}
-
-		// Set policy_id to d
-		d.SetId(resp.Result.Data.(map[string]interface{})["policy_id"].(string))
-
-		return &resp, "DONE", nil
+		return resp, resp.Ready, nil
+	})
+	if err != nil {
+		return err
 	}
+	// Set policy_id to d
+	d.SetId(result.(*gobizfly.ASTask).Result.Data.(map[string]interface{})["policy_id"].(string))
+	return nil
 }
@@ -2,31 +2,35 @@ package bizflycloud
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
+	"log"
+	"net/http"
+	"strings"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const cdnServiceName = "cdn"
+
 func resourceBizflyCloudCDN() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudCDNCreate,
-		Read:   resourceBizflyCloudCDNRead,
-		Update: resourceBizflyCloudCDNUpdate,
-		Delete: resourceBizflyCloudCDNDelete,
+		CreateContext: resourceBizflyCloudCDNCreate,
+		ReadContext:   resourceBizflyCloudCDNRead,
+		UpdateContext: resourceBizflyCloudCDNUpdate,
+		DeleteContext: resourceBizflyCloudCDNDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 1,
 		Schema:        resourceCDNSchema(),
-		Timeouts: &schema.ResourceTimeout{
-			Read: schema.DefaultTimeout(10 * time.Minute),
-		},
 	}
 }
 
-func resourceBizflyCloudCDNCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCDNCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	origins := d.Get("origin").(*schema.Set).List()
@@ -41,32 +45,37 @@ func resourceBizflyCloudCDNCreate(d *schema.ResourceData, meta interface{}) erro
 			UpstreamAddrs: origin["upstream_addrs"].(string),
 		},
 	}
-	cdr, err := client.CDN.Create(context.Background(), cdp)
+	cdr, err := client.CDN.Create(ctx, cdp)
 	if err != nil {
-		return fmt.Errorf("error when create cdn resource: %v", err)
+		return diag.Errorf("error when create cdn resource: %v", err)
 	}
 	domain := cdr.Domain
 	d.SetId(domain.DomainID)
-	return resourceBizflyCloudCDNRead(d, meta)
+	return resourceBizflyCloudCDNRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudCDNRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCDNRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	domain, err := client.CDN.Get(context.Background(), d.Id())
+	domain, err := getCDNDomain(ctx, client, d.Id())
 	if err != nil {
-		return fmt.Errorf("error when get cdn resource: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] CDN domain (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error when get cdn resource: %v", err)
+	}
+	if err := setCDNDomainAttributes(d, domain); err != nil {
+		return diag.FromErr(err)
 	}
-	_ = d.Set("domain_cdn", domain.DomainCDN)
-	_ = d.Set("domain_id", domain.DomainID)
-
 	return nil
 }
 
-func resourceBizflyCloudCDNUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCDNUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	domain, err := client.CDN.Get(context.Background(), d.Id())
+	domain, err := client.CDN.Get(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error when get cdn resource: %v", err)
+		return diag.Errorf("error when get cdn resource: %v", err)
 	}
 
 	if d.HasChange("origin") {
@@ -80,19 +89,91 @@ func resourceBizflyCloudCDNUpdate(d *schema.ResourceData, meta interface{}) erro
 				UpstreamAddrs: origin["upstream_addrs"].(string),
 			},
 		}
-		_, err := client.CDN.Update(context.Background(), domain.DomainID, udp)
+		_, err := client.CDN.Update(ctx, domain.DomainID, udp)
 		if err != nil {
-			return fmt.Errorf("error when update cdn resource: %v", err)
+			return diag.Errorf("error when update cdn resource: %v", err)
 		}
 	}
-	return resourceBizflyCloudCDNRead(d, meta)
+	return resourceBizflyCloudCDNRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudCDNDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCDNDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CDN.Delete(context.Background(), d.Id())
+	err := client.CDN.Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error when delete cdn resource : %v", err)
+		return diag.Errorf("error when delete cdn resource : %v", err)
+	}
+	return nil
+}
+
+// cdnDomain is a CDN domain as returned by the API, including the origin
+// which gobizfly.Domain leaves out. Domains which were not
+// updated since they were created may only have the upstream host and the
+// origin addresses instead of the origin.
+type cdnDomain struct {
+	gobizfly.Domain
+	Origin       *gobizfly.Origin      `json:"origin"`
+	UpstreamHost string                `json:"upstream_host"`
+	OriginAddrs  []gobizfly.OriginAddr `json:"origin_addrs"`
+}
+
+// setCDNDomainAttributes copies the CDN domain into d, for both the resource
+// and the data source.
+func setCDNDomainAttributes(d *schema.ResourceData, domain *cdnDomain) error {
+	_ = d.Set("domain", domain.Domain.Domain)
+	_ = d.Set("domain_cdn", domain.DomainCDN)
+	_ = d.Set("domain_id", domain.DomainID)
+	if origin := flattenCDNOrigin(domain); origin != nil {
+		if err := d.Set("origin", origin); err != nil {
+			return fmt.Errorf("error setting origin: %w", err)
+		}
 	}
 	return nil
 }
+
+func flattenCDNOrigin(domain *cdnDomain) []map[string]interface{} {
+	origin := domain.Origin
+	if origin == nil {
+		if domain.UpstreamHost == "" && len(domain.OriginAddrs) == 0 {
+			return nil
+		}
+		origin = &gobizfly.Origin{UpstreamHost: domain.UpstreamHost, UpstreamProto: "http"}
+		var addrs []string
+		for _, addr := range domain.OriginAddrs {
+			addrs = append(addrs, addr.Host)
+		}
+		origin.UpstreamAddrs = strings.Join(addrs, ",")
+	}
+	return []map[string]interface{}{{
+		"name":           origin.Name,
+		"upstream_host":  origin.UpstreamHost,
+		"upstream_addrs": origin.UpstreamAddrs,
+		"upstream_proto": origin.UpstreamProto,
+	}}
+}
+
+// getCDNDomain is client.CDN.Get, keeping the origin of the domain.
+func getCDNDomain(ctx context.Context, client *gobizfly.Client, domainID string) (*cdnDomain, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, cdnServiceName, cdnDomainPath(domainID), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var data struct {
+		Domain cdnDomain `json:"domain"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	return &data.Domain, nil
+}
+
+func cdnDomainPath(domainID string) string {
+	return "/clients/domains/" + domainID
+}
@@ -0,0 +1,87 @@
+package bizflycloud
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceBizflyCloudCDNPurge purges the cache of a CDN domain when it is
+// created. All of its arguments force a new resource, so changing the
+// triggers purges the cache again.
+func resourceBizflyCloudCDNPurge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBizflyCloudCDNPurgeCreate,
+		ReadContext:   resourceBizflyCloudCDNPurgeRead,
+		DeleteContext: resourceBizflyCloudCDNPurgeDelete,
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"paths": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"purged_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBizflyCloudCDNPurgeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	domainID := d.Get("domain_id").(string)
+	files := &gobizfly.Files{Files: readStringArray(d.Get("paths").([]interface{}))}
+	log.Printf("[INFO] Purging cache of CDN domain %s: %v", domainID, files.Files)
+	// The API has no endpoint to follow a purge, so it is only requested
+	// and the cache may still serve the old content for a short while.
+	if err := client.CDN.DeleteCache(ctx, domainID, files); err != nil {
+		return diag.Errorf("error when purge cache of cdn domain %s: %v", domainID, err)
+	}
+	d.SetId(id.PrefixedUniqueId(domainID + "-"))
+	_ = d.Set("purged_at", time.Now().UTC().Format(time.RFC3339))
+	return resourceBizflyCloudCDNPurgeRead(ctx, d, meta)
+}
+
+func resourceBizflyCloudCDNPurgeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	domainID := d.Get("domain_id").(string)
+	if _, err := client.CDN.Get(ctx, domainID); err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] CDN domain (%s) of cache purge %s is not found", domainID, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error when get cdn resource: %v", err)
+	}
+	return nil
+}
+
+// resourceBizflyCloudCDNPurgeDelete only removes the purge from the state,
+// a purge cannot be undone.
+func resourceBizflyCloudCDNPurgeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
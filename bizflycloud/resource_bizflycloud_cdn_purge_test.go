@@ -0,0 +1,59 @@
+package bizflycloud
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestBizflyCloudCDNPurge_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	domain := testResourceCreate(t, resourceBizflyCloudCDN(), map[string]interface{}{
+		"domain": "static.example.vn",
+		"origin": []interface{}{map[string]interface{}{
+			"upstream_addrs": "203.0.113.10",
+			"upstream_host":  "static.example.vn",
+		}},
+	}, meta)
+	r := resourceBizflyCloudCDNPurge()
+
+	config := map[string]interface{}{
+		"domain_id": domain.Id(),
+		"paths":     []interface{}{"/assets/*", "/index.html"},
+		"triggers":  map[string]interface{}{"release": "v1"},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	if d.Get("purged_at").(string) == "" {
+		t.Error("expected purged_at to be set")
+	}
+
+	diff, err := r.Diff(testContext(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"domain_id": domain.Id(),
+		"paths":     []interface{}{"/assets/*", "/index.html"},
+		"triggers":  map[string]interface{}{"release": "v2"},
+	}), meta)
+	if err != nil {
+		t.Fatalf("error planning purge: %v", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Error("expected a change of triggers to purge the cache again")
+	}
+
+	testResourceDelete(t, r, d, meta)
+	api.mu.Lock()
+	purges := api.cdnPurges[domain.Id()]
+	_, exists := api.cdnDomains[domain.Id()]
+	api.mu.Unlock()
+	if want := [][]string{{"/assets/*", "/index.html"}}; !reflect.DeepEqual(purges, want) {
+		t.Errorf("expected purges %v, got %v", want, purges)
+	}
+	if !exists {
+		t.Error("expected the cdn domain to be kept when the purge is deleted")
+	}
+
+	testResourceDelete(t, resourceBizflyCloudCDN(), domain, meta)
+	testCheckResourceGone(t, r, d, meta)
+}
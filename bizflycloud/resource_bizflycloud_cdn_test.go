@@ -0,0 +1,93 @@
+package bizflycloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestBizflyCloudCDN_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudCDN()
+
+	config := map[string]interface{}{
+		"domain": "static.example.vn",
+		"origin": []interface{}{map[string]interface{}{
+			"upstream_addrs": "203.0.113.10",
+			"upstream_host":  "static.example.vn",
+			"upstream_proto": "https",
+		}},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "domain_cdn", d.Id()+".cdn.bizflycloud.vn")
+	testCheckCDNOrigin(t, d, "upstream_proto", "https")
+	if n := api.requestCount("PUT", "/cdn/clients/domains/"); n != 0 {
+		t.Errorf("expected no update request on create, got %d", n)
+	}
+	testCheckNoDiff(t, r, d, config, meta)
+
+	config["origin"] = []interface{}{map[string]interface{}{
+		"upstream_addrs": "203.0.113.20",
+		"upstream_host":  "static.example.vn",
+		"upstream_proto": "https",
+	}}
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckCDNOrigin(t, d, "upstream_addrs", "203.0.113.20")
+	testCheckNoDiff(t, r, d, config, meta)
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckResourceDataAttr(t, imported, "domain", "static.example.vn")
+	testCheckCDNOrigin(t, imported, "upstream_addrs", "203.0.113.20")
+
+	testResourceDelete(t, r, d, meta)
+	testCheckResourceGone(t, r, d, meta)
+}
+
+func TestBizflyCloudCDN_FakeAPIOriginDrift(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudCDN()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"domain": "media.example.vn",
+		"origin": []interface{}{map[string]interface{}{
+			"upstream_addrs": "203.0.113.10",
+			"upstream_host":  "media.example.vn",
+		}},
+	}, meta)
+	testCheckCDNOrigin(t, d, "upstream_addrs", "203.0.113.10")
+
+	api.mu.Lock()
+	api.cdnDomains[d.Id()]["origin"] = map[string]interface{}{
+		"upstream_addrs": "203.0.113.20",
+		"upstream_host":  "origin.example.vn",
+		"upstream_proto": "https",
+	}
+	api.mu.Unlock()
+
+	if diags := r.ReadContext(testContext(), d, meta); diags.HasError() {
+		t.Fatalf("error reading cdn domain: %v", diagnosticsError(diags))
+	}
+	testCheckCDNOrigin(t, d, "upstream_addrs", "203.0.113.20")
+	testCheckCDNOrigin(t, d, "upstream_host", "origin.example.vn")
+	testCheckCDNOrigin(t, d, "upstream_proto", "https")
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckResourceDataAttr(t, imported, "domain", "media.example.vn")
+	testCheckResourceDataAttr(t, imported, "origin.#", 1)
+	testCheckCDNOrigin(t, imported, "upstream_addrs", "203.0.113.20")
+}
+
+func testCheckCDNOrigin(t *testing.T, d *schema.ResourceData, key string, expected string) {
+	t.Helper()
+	origins := d.Get("origin").(*schema.Set).List()
+	if len(origins) != 1 {
+		t.Fatalf("expected one origin, got %d", len(origins))
+	}
+	if got := origins[0].(map[string]interface{})[key]; got != expected {
+		t.Errorf("expected origin %s to be %s, got %v", key, expected, got)
+	}
+}
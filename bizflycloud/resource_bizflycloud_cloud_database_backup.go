@@ -21,22 +21,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"log"
-	"strconv"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/gobizfly"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudDatabaseBackup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudCloudDatabaseBackupCreate,
-		Delete: resourceBizflyCloudCloudDatabaseBackupDelete,
-		Read:   resourceBizflyCloudCloudDatabaseBackupRead,
+		CreateContext: resourceBizflyCloudCloudDatabaseBackupCreate,
+		DeleteContext: resourceBizflyCloudCloudDatabaseBackupDelete,
+		ReadContext:   resourceBizflyCloudCloudDatabaseBackupRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
@@ -46,7 +47,7 @@ func resourceBizflyCloudDatabaseBackup() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudCloudDatabaseBackupCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	bac := &gobizfly.CloudDatabaseBackupCreate{
@@ -68,145 +69,118 @@ func resourceBizflyCloudCloudDatabaseBackupCreate(d *schema.ResourceData, meta i
 		resourceType = "nodes"
 		resourceID = d.Get("node_id").(string)
 	} else {
-		return fmt.Errorf("[ERROR] create cloud database backup %s failed: not found resource to backup", d.Get("name"))
+		return diag.Errorf("[ERROR] create cloud database backup %s failed: not found resource to backup", d.Get("name"))
 	}
 
 	// retry
-	retry := maxRetry
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		backup, err := client.CloudDatabase.Backups().Create(context.Background(), resourceType, resourceID, bac)
+	retries := maxRetry
+	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		backup, err := client.CloudDatabase.Backups().Create(ctx, resourceType, resourceID, bac)
 
 		if err != nil {
-			retry--
-			if retry > 0 {
+			retries--
+			if retries > 0 {
 				time.Sleep(timeSleep)
-				return resource.RetryableError(fmt.Errorf("[ERROR] create cloud database backup %s failed: %s. Retrying", d.Get("name"), err))
+				return retry.RetryableError(fmt.Errorf("[ERROR] create cloud database backup %s failed: %s. Retrying", d.Get("name"), err))
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] create cloud database backup %s failed: %s. Can't retry", d.Get("name"), err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] create cloud database backup %s failed: %s. Can't retry", d.Get("name"), err))
 		}
 
 		log.Printf("[DEBUG] creating cloud database backup %s", backup.Name)
 		d.SetId(backup.ID)
 
 		// wait for cloud database backup to become active
-		_, err = waitForCloudDatabaseBackupCreate(d, meta)
+		err = waitForCloudDatabaseBackupCreate(ctx, d, meta)
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] create cloud database backup (%s) failed: %s. Can't retry", d.Get("name").(string), err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] create cloud database backup (%s) failed: %s. Can't retry", d.Get("name").(string), err))
 		}
 
-		err = resourceBizflyCloudCloudDatabaseBackupRead(d, meta)
+		err = diagnosticsError(resourceBizflyCloudCloudDatabaseBackupRead(ctx, d, meta))
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] read cloud database backup (%s) failed: %s. Can't retry", d.Get("name").(string), err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] read cloud database backup (%s) failed: %s. Can't retry", d.Get("name").(string), err))
 		}
 
 		return nil
-	})
+	}))
 
 }
 
-func resourceBizflyCloudCloudDatabaseBackupRead(d *schema.ResourceData, meta interface{}) error {
-	if err := dataSourceBizflyCloudDatabaseBackupRead(d, meta); err != nil {
-		return err
+func resourceBizflyCloudCloudDatabaseBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	backup, err := client.CloudDatabase.Backups().Get(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Cloud database backup (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error describing database backup: %v", err)
 	}
-	return nil
+	return setCloudDatabaseBackupAttributes(d, backup)
 }
 
-func resourceBizflyCloudCloudDatabaseBackupDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	id := d.Id()
 
 	// retry
-	retry := maxRetry
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		_, err := client.CloudDatabase.Backups().Delete(context.Background(), id)
+	retries := maxRetry
+	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		_, err := client.CloudDatabase.Backups().Delete(ctx, id)
 
 		if err != nil {
-			retry--
-			if retry > 0 {
+			retries--
+			if retries > 0 {
 				time.Sleep(timeSleep)
-				return resource.RetryableError(fmt.Errorf("[ERROR] delete cloud database backup %s failed: %v. Retrying", id, err))
+				return retry.RetryableError(fmt.Errorf("[ERROR] delete cloud database backup %s failed: %v. Retrying", id, err))
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database backup %s failed: %v. Can't retry", id, err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database backup %s failed: %v. Can't retry", id, err))
 		}
 
 		log.Printf("[DEBUG] delete cloud database backup %s success", id)
 
-		_, err = waitForCloudDatabaseBackupDelete(d, meta)
+		err = waitForCloudDatabaseBackupDelete(ctx, d, meta)
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database backup %s failed: %v. Can't retry", id, err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database backup %s failed: %v. Can't retry", id, err))
 		}
 		return nil
-	})
-}
-
-func waitForCloudDatabaseBackupCreate(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for cloud database instance (%s) to be ready", d.Get("name").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"false"},
-		Target:         []string{"true", "COMPLETED", "ERROR"},
-		Refresh:        newCloudDatabaseBackupStateRefreshFunc(d, meta),
-		Timeout:        d.Timeout(schema.TimeoutCreate),
-		Delay:          30 * time.Second,
-		MinTimeout:     20 * time.Second,
-		NotFoundChecks: 50,
-	}
-	return stateConf.WaitForState()
-}
-
-func waitForCloudDatabaseBackupDelete(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for cloud database instance (%s) to be ready", d.Get("name").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"false"},
-		Target:         []string{"true"},
-		Refresh:        deleteCloudDatabaseBackupStateRefreshFunc(d, meta),
-		Timeout:        d.Timeout(schema.TimeoutCreate),
-		Delay:          30 * time.Second,
-		MinTimeout:     20 * time.Second,
-		NotFoundChecks: 50,
-	}
-	return stateConf.WaitForState()
+	}))
 }
 
-func newCloudDatabaseBackupStateRefreshFunc(d *schema.ResourceData, meta interface{}) resource.StateRefreshFunc {
+func waitForCloudDatabaseBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-
-	return func() (interface{}, string, error) {
-		err := resourceBizflyCloudCloudDatabaseBackupRead(d, meta)
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cloud database backup (%s) to be ready", d.Get("name").(string)),
+		Target:       []string{"COMPLETED", "ERROR"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		Delay:        30 * time.Second,
+		PollInterval: 20 * time.Second,
+	}
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		bac, err := client.CloudDatabase.Backups().Get(ctx, d.Id())
 		if err != nil {
-			return nil, "", err
+			return nil, "", fmt.Errorf("[ERROR] Retrieving cloud database backup %s error: %v", d.Id(), err)
 		}
-
-		if attr, ok := d.GetOk("status"); ok {
-			bac, err := client.CloudDatabase.Backups().Get(context.Background(), d.Id())
-			if err != nil {
-				return nil, "", fmt.Errorf("[ERROR] Retrieving cloud database backup %s error: %v", d.Id(), err)
-			}
-			_ = bac // ensure variable is recognized as used
-			switch attr := attr.(type) {
-			case bool:
-				return &bac, strconv.FormatBool(attr), nil
-			default:
-				return &bac, attr.(string), nil
-			}
-		}
-		log.Print("ok")
-		return nil, "", nil
-	}
+		return bac, bac.Status, nil
+	})
+	return err
 }
 
-func deleteCloudDatabaseBackupStateRefreshFunc(d *schema.ResourceData, meta interface{}) resource.StateRefreshFunc {
+func waitForCloudDatabaseBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-
-	return func() (interface{}, string, error) {
-		bac, err := client.CloudDatabase.Backups().Get(context.Background(), d.Id())
-
-		if errors.Is(err, gobizfly.ErrNotFound) {
-			return bac, "true", nil
-		}
-
-		return bac, "", nil
-
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cloud database backup (%s) to be deleted", d.Get("name").(string)),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Delay:        30 * time.Second,
+		PollInterval: 20 * time.Second,
 	}
+	return conf.ForDeletion(ctx, func(ctx context.Context) (interface{}, string, error) {
+		bac, err := client.CloudDatabase.Backups().Get(ctx, d.Id())
+		if err != nil {
+			return nil, "", err
+		}
+		return bac, bac.Status, nil
+	})
 }
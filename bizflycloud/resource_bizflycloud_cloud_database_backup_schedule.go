@@ -19,23 +19,24 @@ package bizflycloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudDatabaseBackupSchedule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudCloudDatabaseBackupScheduleCreate,
-		Delete: resourceBizflyCloudCloudDatabaseBackupScheduleDelete,
-		Read:   resourceBizflyCloudCloudDatabaseBackupScheduleRead,
+		CreateContext: resourceBizflyCloudCloudDatabaseBackupScheduleCreate,
+		DeleteContext: resourceBizflyCloudCloudDatabaseBackupScheduleDelete,
+		ReadContext:   resourceBizflyCloudCloudDatabaseBackupScheduleRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
@@ -45,7 +46,7 @@ func resourceBizflyCloudDatabaseBackupSchedule() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudCloudDatabaseBackupScheduleCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseBackupScheduleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	scc := &gobizfly.CloudDatabaseBackupScheduleCreate{
@@ -55,57 +56,64 @@ func resourceBizflyCloudCloudDatabaseBackupScheduleCreate(d *schema.ResourceData
 	}
 
 	// retry
-	retry := maxRetry
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		schedule, err := client.CloudDatabase.BackupSchedules().Create(context.Background(), d.Get("node_id").(string), scc)
+	retries := maxRetry
+	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		schedule, err := client.CloudDatabase.BackupSchedules().Create(ctx, d.Get("node_id").(string), scc)
 
 		if err != nil {
-			retry--
-			if retry > 0 {
+			retries--
+			if retries > 0 {
 				time.Sleep(timeSleep)
-				return resource.RetryableError(fmt.Errorf("[ERROR] create cloud database schedule %s failed: %s. Retrying", d.Get("name"), err))
+				return retry.RetryableError(fmt.Errorf("[ERROR] create cloud database schedule %s failed: %s. Retrying", d.Get("name"), err))
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] create cloud database schedule %s failed: %s. Can't retry", d.Get("name"), err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] create cloud database schedule %s failed: %s. Can't retry", d.Get("name"), err))
 		}
 
 		log.Printf("[DEBUG] creating cloud database schedule %s", schedule.Name)
 		d.SetId(schedule.ID)
 
-		err = resourceBizflyCloudCloudDatabaseBackupScheduleRead(d, meta)
+		err = diagnosticsError(resourceBizflyCloudCloudDatabaseBackupScheduleRead(ctx, d, meta))
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] create cloud database schedule (%s) failed: %s. Can't retry", d.Get("name").(string), err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] create cloud database schedule (%s) failed: %s. Can't retry", d.Get("name").(string), err))
 		}
 
 		return nil
-	})
+	}))
 }
 
-func resourceBizflyCloudCloudDatabaseBackupScheduleRead(d *schema.ResourceData, meta interface{}) error {
-	if err := dataSourceBizflyCloudDatabaseBackupScheduleRead(d, meta); err != nil {
-		return err
+func resourceBizflyCloudCloudDatabaseBackupScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	schedule, err := client.CloudDatabase.BackupSchedules().Get(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Cloud database backup schedule (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error describing database schedule: %v", err)
 	}
-	return nil
+	return setCloudDatabaseBackupScheduleAttributes(d, schedule)
 }
 
-func resourceBizflyCloudCloudDatabaseBackupScheduleDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseBackupScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	id := d.Id()
 
 	// retry
-	retry := maxRetry
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		_, err := client.CloudDatabase.BackupSchedules().Delete(context.Background(), id, &gobizfly.CloudDatabaseBackupScheduleDelete{})
+	retries := maxRetry
+	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		_, err := client.CloudDatabase.BackupSchedules().Delete(ctx, id, &gobizfly.CloudDatabaseBackupScheduleDelete{})
 
 		if err != nil {
-			retry--
-			if retry > 0 {
+			retries--
+			if retries > 0 {
 				time.Sleep(timeSleep)
-				return resource.RetryableError(fmt.Errorf("[ERROR] delete cloud database schedule %s failed: %v. Retrying", id, err))
+				return retry.RetryableError(fmt.Errorf("[ERROR] delete cloud database schedule %s failed: %v. Retrying", id, err))
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database schedule %s failed: %v. Can't retry", id, err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database schedule %s failed: %v. Can't retry", id, err))
 		}
 		return nil
-	})
+	}))
 }
@@ -24,9 +24,9 @@ import (
 	"testing"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 const nodeID = "9ed6c0fb-205a-45fb-9d95-80d101affbbb"
@@ -41,9 +41,9 @@ func TestAccBizflyCloudCloudDatabaseBackup_Basic(t *testing.T) {
 	var backup gobizfly.CloudDatabaseBackup
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudCloudDatabaseBackupDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudCloudDatabaseBackupDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudCloudDatabaseBackupBasicConfig(rInt),
@@ -26,19 +26,20 @@ import (
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudDatabaseConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudCloudDatabaseConfigurationCreate,
-		Delete: resourceBizflyCloudCloudDatabaseConfigurationDelete,
-		Read:   resourceBizflyCloudCloudDatabaseConfigurationRead,
-		Update: resourceBizflyCloudCloudDatabaseConfigurationUpdate,
+		CreateContext: resourceBizflyCloudCloudDatabaseConfigurationCreate,
+		DeleteContext: resourceBizflyCloudCloudDatabaseConfigurationDelete,
+		ReadContext:   resourceBizflyCloudCloudDatabaseConfigurationRead,
+		UpdateContext: resourceBizflyCloudCloudDatabaseConfigurationUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -49,7 +50,7 @@ func resourceBizflyCloudDatabaseConfiguration() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudCloudDatabaseConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	datastore := readResourceCloudDatabaseDatastore(d)
 
@@ -63,39 +64,39 @@ func resourceBizflyCloudCloudDatabaseConfigurationCreate(d *schema.ResourceData,
 	}
 
 	// retry
-	retry := maxRetry
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		configuration, err := client.CloudDatabase.Configurations().Create(context.Background(), cfc)
+	retries := maxRetry
+	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		configuration, err := client.CloudDatabase.Configurations().Create(ctx, cfc)
 
 		if err != nil {
-			retry--
-			if retry > 0 {
+			retries--
+			if retries > 0 {
 				time.Sleep(timeSleep)
-				return resource.RetryableError(fmt.Errorf("[ERROR] create cloud database configuration %s failed: %s. Retrying", d.Get("name"), err))
+				return retry.RetryableError(fmt.Errorf("[ERROR] create cloud database configuration %s failed: %s. Retrying", d.Get("name"), err))
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] create cloud database configuration %s failed: %s. Can't retry", d.Get("name"), err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] create cloud database configuration %s failed: %s. Can't retry", d.Get("name"), err))
 		}
 
 		log.Printf("[DEBUG] creating cloud database configuration %s", configuration.Name)
 		d.SetId(configuration.ID)
 
 		// wait for cloud database Configuration to become active
-		_, err = waitForCloudDatabaseConfigurationCreate(d, meta)
+		err = waitForCloudDatabaseConfigurationCreate(ctx, d, meta)
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] create cloud database configuration (%s) failed: %s. Can't retry", d.Get("name").(string), err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] create cloud database configuration (%s) failed: %s. Can't retry", d.Get("name").(string), err))
 		}
 
-		err = resourceBizflyCloudCloudDatabaseConfigurationRead(d, meta)
+		err = diagnosticsError(resourceBizflyCloudCloudDatabaseConfigurationRead(ctx, d, meta))
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] create cloud database Configuration (%s) failed: %s. Can't retry", d.Get("name").(string), err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] create cloud database Configuration (%s) failed: %s. Can't retry", d.Get("name").(string), err))
 		}
 
 		return nil
-	})
+	}))
 }
 
-func resourceBizflyCloudCloudDatabaseConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	if v, ok := d.GetOk("id"); ok {
@@ -105,11 +106,16 @@ func resourceBizflyCloudCloudDatabaseConfigurationRead(d *schema.ResourceData, m
 	configurationID := d.Id()
 
 	log.Printf("[DEBUG] Reading database Configuration: %s", configurationID)
-	configuration, err := client.CloudDatabase.Configurations().Get(context.Background(), configurationID)
+	configuration, err := client.CloudDatabase.Configurations().Get(ctx, configurationID)
 
 	log.Printf("[DEBUG] Checking for error: %s", err)
 	if err != nil {
-		return fmt.Errorf("error describing database Configuration: %w", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Cloud database configuration (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error describing database Configuration: %v", err)
 	}
 
 	log.Printf("[DEBUG] Found database Configuration: %s", configurationID)
@@ -120,13 +126,13 @@ func resourceBizflyCloudCloudDatabaseConfigurationRead(d *schema.ResourceData, m
 	_ = d.Set("name", configuration.Name)
 	_ = d.Set("nodes", configuration.Nodes)
 	if err := d.Set("datastore", FlattenStruct(configuration.Datastore)); err != nil {
-		return fmt.Errorf("error setting datastore: %w", err)
+		return diag.Errorf("error setting datastore: %v", err)
 	}
 
 	return nil
 }
 
-func resourceBizflyCloudCloudDatabaseConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	datastore := readResourceCloudDatabaseDatastore(d)
 	id := d.Id()
@@ -141,49 +147,49 @@ func resourceBizflyCloudCloudDatabaseConfigurationUpdate(d *schema.ResourceData,
 		}
 
 		// retry
-		retry := maxRetry
-		err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-			task, err := client.CloudDatabase.Configurations().Update(context.Background(), id, cfu)
+		retries := maxRetry
+		err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *retry.RetryError {
+			task, err := client.CloudDatabase.Configurations().Update(ctx, id, cfu)
 
 			if err != nil {
-				retry--
-				if retry > 0 {
+				retries--
+				if retries > 0 {
 					time.Sleep(timeSleep)
-					return resource.RetryableError(fmt.Errorf("[ERROR] update cloud database configuration [%s] failed: %v. Retrying", id, err))
+					return retry.RetryableError(fmt.Errorf("[ERROR] update cloud database configuration [%s] failed: %v. Retrying", id, err))
 				}
 
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] update cloud database configuration [%s] failed: %v. Can't retry", id, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] update cloud database configuration [%s] failed: %v. Can't retry", id, err))
 			}
 
 			_ = d.Set("task_id", task.TaskID)
 
-			err = resourceBizflyCloudCloudDatabaseConfigurationRead(d, meta)
+			err = diagnosticsError(resourceBizflyCloudCloudDatabaseConfigurationRead(ctx, d, meta))
 			if err != nil {
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] update cloud database configuration %s failed: %s. Can't retry", id, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] update cloud database configuration %s failed: %s. Can't retry", id, err))
 			}
 			return nil
 		})
 
 		if err != nil {
-			return fmt.Errorf("[ERROR] update cloud database configuration %s failed: %s. Can't retry", id, err)
+			return diag.Errorf("[ERROR] update cloud database configuration %s failed: %s. Can't retry", id, err)
 		}
 	}
 	return nil
 }
 
-func resourceBizflyCloudCloudDatabaseConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	id := d.Id()
 
 	// First, detach configuration from all attached nodes
 	log.Printf("[DEBUG] Checking for nodes attached to configuration %s before deletion", id)
-	configuration, err := client.CloudDatabase.Configurations().Get(context.Background(), id)
+	configuration, err := client.CloudDatabase.Configurations().Get(ctx, id)
 	if err == nil && len(configuration.Nodes) > 0 {
 		log.Printf("[WARN] Configuration %s is attached to %d nodes, attempting to detach before deletion", id, len(configuration.Nodes))
 
 		for _, node := range configuration.Nodes {
 			log.Printf("[DEBUG] Detaching configuration %s from node %s (%s)", id, node.ID, node.Name)
-			_, detachErr := client.CloudDatabase.Configurations().Detach(context.Background(), node.ID, id, false)
+			_, detachErr := client.CloudDatabase.Configurations().Detach(ctx, node.ID, id, false)
 			if detachErr != nil {
 				// Skip if resource not found (already detached or deleted)
 				if errors.Is(detachErr, gobizfly.ErrNotFound) {
@@ -199,88 +205,63 @@ func resourceBizflyCloudCloudDatabaseConfigurationDelete(d *schema.ResourceData,
 	}
 
 	// retry
-	retry := maxRetry
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		_, err := client.CloudDatabase.Configurations().Delete(context.Background(), id)
+	retries := maxRetry
+	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		_, err := client.CloudDatabase.Configurations().Delete(ctx, id)
 
 		if err != nil {
-			retry--
-			if retry > 0 {
+			retries--
+			if retries > 0 {
 				time.Sleep(timeSleep)
-				return resource.RetryableError(fmt.Errorf("[ERROR] delete cloud database configuration %s failed: %v. Retrying", id, err))
+				return retry.RetryableError(fmt.Errorf("[ERROR] delete cloud database configuration %s failed: %v. Retrying", id, err))
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database configuration %s failed: %v. Can't retry", id, err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database configuration %s failed: %v. Can't retry", id, err))
 		}
 
 		// wait for cloud database Configuration to delete
-		_, err = waitForCloudDatabaseConfigurationDelete(d, meta)
+		err = waitForCloudDatabaseConfigurationDelete(ctx, d, meta)
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database configuration %s failed: %v. Can't retry", id, err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database configuration %s failed: %v. Can't retry", id, err))
 		}
 
 		return nil
-	})
+	}))
 }
 
-func waitForCloudDatabaseConfigurationCreate(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for cloud database configuration (%s) to be ready", d.Get("name").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"false"},
-		Target:         []string{"true"},
-		Refresh:        newCloudDatabaseConfigurationStateRefreshFunc(d, meta),
-		Timeout:        d.Timeout(schema.TimeoutCreate),
-		Delay:          10 * time.Second,
-		MinTimeout:     20 * time.Second,
-		NotFoundChecks: 20,
-	}
-	return stateConf.WaitForState()
-}
-
-func waitForCloudDatabaseConfigurationDelete(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for cloud database configuration (%s) to be delete", d.Get("name").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"false"},
-		Target:         []string{"true"},
-		Refresh:        deleteCloudDatabaseConfigurationStateRefreshFunc(d, meta),
-		Timeout:        d.Timeout(schema.TimeoutCreate),
-		Delay:          10 * time.Second,
-		MinTimeout:     20 * time.Second,
-		NotFoundChecks: 20,
-	}
-	return stateConf.WaitForState()
-}
-
-func newCloudDatabaseConfigurationStateRefreshFunc(d *schema.ResourceData, meta interface{}) resource.StateRefreshFunc {
+func waitForCloudDatabaseConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-
-	return func() (interface{}, string, error) {
-		err := resourceBizflyCloudCloudDatabaseConfigurationRead(d, meta)
-		if err != nil {
-			return nil, "", err
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cloud database configuration (%s) to be ready", d.Get("name").(string)),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		Delay:        10 * time.Second,
+		PollInterval: 20 * time.Second,
+	}
+	_, err := conf.ForTask(ctx, func(ctx context.Context) (interface{}, bool, error) {
+		configuration, err := client.CloudDatabase.Configurations().Get(ctx, d.Id())
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			return nil, false, nil
 		}
-		conf, err := client.CloudDatabase.Configurations().Get(context.Background(), d.Id())
 		if err != nil {
-			return nil, "", fmt.Errorf("[ERROR] Retrieving cloud database configuration %s error: %v", d.Id(), err)
+			return nil, false, fmt.Errorf("[ERROR] Retrieving cloud database configuration %s error: %v", d.Id(), err)
 		}
-		return &conf, "true", nil
-	}
-
+		return configuration, true, nil
+	})
+	return err
 }
 
-func deleteCloudDatabaseConfigurationStateRefreshFunc(d *schema.ResourceData, meta interface{}) resource.StateRefreshFunc {
+func waitForCloudDatabaseConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-
-	return func() (interface{}, string, error) {
-		conf, err := client.CloudDatabase.Configurations().Get(context.Background(), d.Id())
-
-		if errors.Is(err, gobizfly.ErrNotFound) {
-			return &conf, "true", nil
-		}
-
-		return &conf, "", nil
-
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cloud database configuration (%s) to be deleted", d.Get("name").(string)),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Delay:        10 * time.Second,
+		PollInterval: 20 * time.Second,
 	}
+	return conf.ForDeletion(ctx, func(ctx context.Context) (interface{}, string, error) {
+		configuration, err := client.CloudDatabase.Configurations().Get(ctx, d.Id())
+		return configuration, "", err
+	})
 }
 
 func readArrayParameters(params *schema.Set) map[string]interface{} {
@@ -24,9 +24,9 @@ import (
 	"testing"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -39,9 +39,9 @@ func TestAccBizflyCloudCloudDatabaseConfiguration_Basic(t *testing.T) {
 	var configuration gobizfly.CloudDatabaseConfiguration
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudCloudDatabaseConfigurationDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudCloudDatabaseConfigurationDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudCloudDatabaseConfigurationBasicConfig(rInt),
@@ -22,13 +22,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strconv"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
@@ -37,12 +38,12 @@ const (
 
 func resourceBizflyCloudDatabaseInstance() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudCloudDatabaseInstanceCreate,
-		Delete: resourceBizflyCloudCloudDatabaseInstanceDelete,
-		Read:   resourceBizflyCloudCloudDatabaseInstanceRead,
-		Update: resourceBizflyCloudCloudDatabaseInstanceUpdate,
+		CreateContext: resourceBizflyCloudCloudDatabaseInstanceCreate,
+		DeleteContext: resourceBizflyCloudCloudDatabaseInstanceDelete,
+		ReadContext:   resourceBizflyCloudCloudDatabaseInstanceRead,
+		UpdateContext: resourceBizflyCloudCloudDatabaseInstanceUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -53,7 +54,7 @@ func resourceBizflyCloudDatabaseInstance() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudCloudDatabaseInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	autoscaling := readResourceCloudDatabaseAutoScaling(d)
@@ -102,9 +103,9 @@ func resourceBizflyCloudCloudDatabaseInstanceCreate(d *schema.ResourceData, meta
 		}
 	}
 
-	instance, err := client.CloudDatabase.Instances().Create(context.Background(), insc)
+	instance, err := client.CloudDatabase.Instances().Create(ctx, insc)
 	if err != nil {
-		return fmt.Errorf("[ERROR] create cloud database instance %s failed: %s", d.Get("name"), err)
+		return diag.Errorf("[ERROR] create cloud database instance %s failed: %s", d.Get("name"), err)
 	}
 	log.Printf("[DEBUG] creating cloud database instance %s", instance.Name)
 
@@ -112,9 +113,9 @@ func resourceBizflyCloudCloudDatabaseInstanceCreate(d *schema.ResourceData, meta
 	_ = d.Set("task_id", instance.TaskID)
 
 	// wait for cloud database instance to become active
-	_, err = waitForCloudDatabaseInstanceCreate(d, meta)
+	err = waitForCloudDatabaseInstanceCreate(ctx, d, meta)
 	if err != nil {
-		return fmt.Errorf("[ERROR] create cloud database instance (%s) failed: %s", d.Get("name").(string), err)
+		return diag.Errorf("[ERROR] create cloud database instance (%s) failed: %s", d.Get("name").(string), err)
 	}
 
 	if _, ok := d.GetOk("init_databases"); ok {
@@ -127,10 +128,10 @@ func resourceBizflyCloudCloudDatabaseInstanceCreate(d *schema.ResourceData, meta
 		}
 
 		if len(newDatabases) > 0 {
-			err := client.CloudDatabase.Instances().CreateDatabases(context.Background(), instance.ID, newDatabases)
+			err := client.CloudDatabase.Instances().CreateDatabases(ctx, instance.ID, newDatabases)
 
 			if err != nil {
-				return fmt.Errorf("[ERROR] Create new database for database instance [%s] failed: %v", instance.ID, err)
+				return diag.Errorf("[ERROR] Create new database for database instance [%s] failed: %v", instance.ID, err)
 			}
 		}
 	}
@@ -139,9 +140,9 @@ func resourceBizflyCloudCloudDatabaseInstanceCreate(d *schema.ResourceData, meta
 		// Do create new users
 		newUsers := readDatabaseUsers(d.Get("users").(*schema.Set))
 
-		err = client.CloudDatabase.Instances().CreateUsers(context.Background(), instance.ID, newUsers)
+		err = client.CloudDatabase.Instances().CreateUsers(ctx, instance.ID, newUsers)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Create new user for database instance [%s] failed: %v", instance.ID, err)
+			return diag.Errorf("[ERROR] Create new user for database instance [%s] failed: %v", instance.ID, err)
 		}
 	}
 
@@ -152,33 +153,40 @@ func resourceBizflyCloudCloudDatabaseInstanceCreate(d *schema.ResourceData, meta
 			cfg[k] = fmt.Sprintf("%v", v)
 		}
 
-		ins, err := client.CloudDatabase.Instances().Get(context.Background(), instance.ID)
+		ins, err := client.CloudDatabase.Instances().Get(ctx, instance.ID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Attach configuration group for database instance [%s] failed: %v", instance.ID, err)
+			return diag.Errorf("[ERROR] Attach configuration group for database instance [%s] failed: %v", instance.ID, err)
 		}
 
 		for _, node := range ins.Nodes {
-			_, _ = client.CloudDatabase.Configurations().Attach(context.Background(), node.ID, cfg["id"], true)
+			_, _ = client.CloudDatabase.Configurations().Attach(ctx, node.ID, cfg["id"], true)
 		}
 
 		if cfg["apply_immediately"] == "true" {
 			for _, node := range ins.Nodes {
-				_, _ = client.CloudDatabase.Nodes().Restart(context.Background(), node.ID)
+				_, _ = client.CloudDatabase.Nodes().Restart(ctx, node.ID)
 			}
 		}
 	}
 
-	return resourceBizflyCloudCloudDatabaseInstanceRead(d, meta)
+	return resourceBizflyCloudCloudDatabaseInstanceRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudCloudDatabaseInstanceRead(d *schema.ResourceData, meta interface{}) error {
-	if err := dataSourceBizflyCloudDatabaseInstanceRead(d, meta); err != nil {
-		return err
+func resourceBizflyCloudCloudDatabaseInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	instance, err := client.CloudDatabase.Instances().Get(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Cloud database instance (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error describing database Instance: %v", err)
 	}
-	return nil
+	return setCloudDatabaseInstanceAttributes(d, instance)
 }
 
-func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	id := d.Id()
 	// We need keep current changed values in here because:
@@ -189,7 +197,7 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 
 	if d.HasChange("autoscaling") {
 		autoscaling := readResourceCloudDatabaseAutoScaling(d)
-		_ = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		_ = retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *retry.RetryError {
 			das := &gobizfly.CloudDatabaseAutoScaling{
 				Enable: false,
 				Volume: gobizfly.CloudDatabaseAutoScalingVolume{
@@ -199,14 +207,14 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 
 			if autoscaling["enable"] == 1 {
 				das.Enable = true
-				_, _ = client.CloudDatabase.AutoScalings().Update(context.Background(), id, das)
+				_, _ = client.CloudDatabase.AutoScalings().Update(ctx, id, das)
 			} else {
-				_, _ = client.CloudDatabase.AutoScalings().Delete(context.Background(), id)
+				_, _ = client.CloudDatabase.AutoScalings().Delete(ctx, id)
 			}
 
-			err := resourceBizflyCloudCloudDatabaseInstanceRead(d, meta)
+			err := diagnosticsError(resourceBizflyCloudCloudDatabaseInstanceRead(ctx, d, meta))
 			if err != nil {
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] Update autoscaling volume of database instance %s failed: %s. Can't retry", id, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] Update autoscaling volume of database instance %s failed: %s. Can't retry", id, err))
 			}
 
 			return nil
@@ -215,95 +223,99 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 
 	if d.HasChange("volume_size") {
 		// retry
-		instance, _ := client.CloudDatabase.Instances().Get(context.Background(), id)
+		instance, _ := client.CloudDatabase.Instances().Get(ctx, id)
 
 		if newVolumeSize < instance.Volume.Size {
-			return fmt.Errorf("[ERROR] New volume_size must be greater than %v", instance.Volume.Size)
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("[ERROR] New volume_size must be greater than %v", instance.Volume.Size),
+				AttributePath: cty.GetAttrPath("volume_size"),
+			}}
 		}
 
-		retry := maxRetry
-		err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-			task, err := client.CloudDatabase.Instances().ResizeVolume(context.Background(), id, gobizfly.CloudDatabaseDatastore{
+		retries := maxRetry
+		err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *retry.RetryError {
+			task, err := client.CloudDatabase.Instances().ResizeVolume(ctx, id, gobizfly.CloudDatabaseDatastore{
 				Type:      datastore["type"],
 				VersionID: datastore["version_id"],
 			}, instanceType, newVolumeSize)
 
 			if err != nil {
-				retry--
-				if retry > 0 {
+				retries--
+				if retries > 0 {
 					time.Sleep(timeSleep)
-					return resource.RetryableError(fmt.Errorf("[ERROR] Resize volume of database instance [%s] error: %v. Retrying", id, err))
+					return retry.RetryableError(fmt.Errorf("[ERROR] Resize volume of database instance [%s] error: %v. Retrying", id, err))
 				}
 
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] Resize volume of database instance [%s] error: %v. Can't retry", id, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] Resize volume of database instance [%s] error: %v. Can't retry", id, err))
 			}
 
 			_ = d.Set("task_id", task.TaskID)
 
-			err = resourceBizflyCloudCloudDatabaseInstanceRead(d, meta)
+			err = diagnosticsError(resourceBizflyCloudCloudDatabaseInstanceRead(ctx, d, meta))
 			if err != nil {
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] Resize volume of database instance %s failed: %s. Can't retry", id, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] Resize volume of database instance %s failed: %s. Can't retry", id, err))
 			}
 
 			// wait for database instance is active again
-			_, err = waitForCloudDatabaseInstanceUpdate(d, meta)
+			err = waitForCloudDatabaseInstanceUpdate(ctx, d, meta)
 			if err != nil {
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] Resize volume of database instance %s with task id (%s) error: %s. Can't retry", id, task.TaskID, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] Resize volume of database instance %s with task id (%s) error: %s. Can't retry", id, task.TaskID, err))
 			}
 
 			return nil
 		})
 
 		if err != nil {
-			return fmt.Errorf("[ERROR] Resize volume of database instance %s failed: %s", id, err)
+			return diag.Errorf("[ERROR] Resize volume of database instance %s failed: %s", id, err)
 		}
 	}
 
 	if d.HasChange("flavor_name") || d.HasChange("instance_type") {
 		// retry
-		retry := maxRetry
+		retries := maxRetry
 
-		err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *retry.RetryError {
 			task, err := client.CloudDatabase.Instances().ResizeFlavor(
-				context.Background(), id, gobizfly.CloudDatabaseDatastore{
+				ctx, id, gobizfly.CloudDatabaseDatastore{
 					Type:      datastore["type"],
 					VersionID: datastore["version_id"],
 				}, instanceType, d.Get("flavor_name").(string))
 
 			if err != nil {
-				retry--
-				if retry > 0 {
+				retries--
+				if retries > 0 {
 					time.Sleep(timeSleep)
-					return resource.RetryableError(fmt.Errorf("[ERROR] Resize flavor of database instance [%s] failed: %v. Retrying", id, err))
+					return retry.RetryableError(fmt.Errorf("[ERROR] Resize flavor of database instance [%s] failed: %v. Retrying", id, err))
 				}
 
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] Resize flavor of database instance [%s] failed: %v. Can't retry", id, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] Resize flavor of database instance [%s] failed: %v. Can't retry", id, err))
 			}
 
 			_ = d.Set("task_id", task.TaskID)
 
-			err = resourceBizflyCloudCloudDatabaseInstanceRead(d, meta)
+			err = diagnosticsError(resourceBizflyCloudCloudDatabaseInstanceRead(ctx, d, meta))
 			if err != nil {
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] Resize flavor of database instance %s failed: %s. Can't retry", id, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] Resize flavor of database instance %s failed: %s. Can't retry", id, err))
 			}
 
 			// wait for database instance is active again
-			_, err = waitForCloudDatabaseInstanceUpdate(d, meta)
+			err = waitForCloudDatabaseInstanceUpdate(ctx, d, meta)
 			if err != nil {
-				return resource.NonRetryableError(fmt.Errorf("[ERROR] Resize flavor of database instance %s with task (%s) failed: %s. Can't retry", id, task.TaskID, err))
+				return retry.NonRetryableError(fmt.Errorf("[ERROR] Resize flavor of database instance %s with task (%s) failed: %s. Can't retry", id, task.TaskID, err))
 			}
 			return nil
 		})
 
 		if err != nil {
-			return fmt.Errorf("[ERROR] Resize flavor of database instance %s failed: %s", id, err)
+			return diag.Errorf("[ERROR] Resize flavor of database instance %s failed: %s", id, err)
 		}
 	}
 
 	if d.HasChange("init_databases") {
 		// We just create new databases without do delete any databases
 		// Because delete database is an action really dangerous
-		databases, _ := readCurrentDatabases(client, id)
+		databases, _ := readCurrentDatabases(ctx, client, id)
 		initDatabases := d.Get("init_databases").([]interface{})
 		newDatabases := []*gobizfly.CloudDatabaseDB{}
 
@@ -318,10 +330,10 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 			newDatabases = append(newDatabases, &gobizfly.CloudDatabaseDB{Name: database.(string)})
 		}
 
-		err := client.CloudDatabase.Instances().CreateDatabases(context.Background(), id, newDatabases)
+		err := client.CloudDatabase.Instances().CreateDatabases(ctx, id, newDatabases)
 
 		if err != nil {
-			return fmt.Errorf("[ERROR] Create new database for database instance [%s] failed: %v", id, err)
+			return diag.Errorf("[ERROR] Create new database for database instance [%s] failed: %v", id, err)
 		}
 	}
 
@@ -331,14 +343,14 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 		// After, we will do create new users
 		newUsers := readDatabaseUsers(d.Get("users").(*schema.Set))
 
-		err := client.CloudDatabase.Instances().DeleteUsers(context.Background(), id, newUsers)
+		err := client.CloudDatabase.Instances().DeleteUsers(ctx, id, newUsers)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Create new user for database instance [%s] failed: %v", id, err)
+			return diag.Errorf("[ERROR] Create new user for database instance [%s] failed: %v", id, err)
 		}
 
-		err = client.CloudDatabase.Instances().CreateUsers(context.Background(), id, newUsers)
+		err = client.CloudDatabase.Instances().CreateUsers(ctx, id, newUsers)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Create new user for database instance [%s] failed: %v", id, err)
+			return diag.Errorf("[ERROR] Create new user for database instance [%s] failed: %v", id, err)
 		}
 	}
 
@@ -346,9 +358,9 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 		old, new := d.GetChange("secondaries")
 
 		// Get current instance
-		instance, err := client.CloudDatabase.Instances().Get(context.Background(), id)
+		instance, err := client.CloudDatabase.Instances().Get(ctx, id)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Get database instance [%s] failed: %v", id, err)
+			return diag.Errorf("[ERROR] Get database instance [%s] failed: %v", id, err)
 		}
 
 		// Get old and new secondary counts
@@ -378,9 +390,9 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 			for _, node := range instance.Nodes {
 				if node.Role == "secondary" {
 					log.Printf("[DEBUG] Deleting secondary node %s (%s)", node.ID, node.Name)
-					_, err := client.CloudDatabase.Nodes().Delete(context.Background(), node.ID, &gobizfly.CloudDatabaseDelete{})
+					_, err := client.CloudDatabase.Nodes().Delete(ctx, node.ID, &gobizfly.CloudDatabaseDelete{})
 					if err != nil {
-						return fmt.Errorf("[ERROR] Delete secondary node %s failed: %v", node.ID, err)
+						return diag.Errorf("[ERROR] Delete secondary node %s failed: %v", node.ID, err)
 					}
 				}
 			}
@@ -398,7 +410,7 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 			}
 
 			if primaryNodeID == "" {
-				return fmt.Errorf("[ERROR] Primary node not found for database instance [%s]", id)
+				return diag.Errorf("[ERROR] Primary node not found for database instance [%s]", id)
 			}
 
 			// Create additional secondary nodes (quantity difference)
@@ -421,17 +433,17 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 				}
 			}
 
-			nodeResp, err := client.CloudDatabase.Nodes().Create(context.Background(), nodeCreate)
+			nodeResp, err := client.CloudDatabase.Nodes().Create(ctx, nodeCreate)
 			if err != nil {
-				return fmt.Errorf("[ERROR] Create secondary nodes for database instance [%s] failed: %v", id, err)
+				return diag.Errorf("[ERROR] Create secondary nodes for database instance [%s] failed: %v", id, err)
 			}
 
 			log.Printf("[DEBUG] Created secondary node %s for database instance %s", nodeResp.ID, id)
 
 			// Wait for the new node to become active
-			err = waitForCloudDatabaseNodeCreate(nodeResp.ID, 10*time.Minute, meta)
+			err = waitForCloudDatabaseNodeCreate(ctx, nodeResp.ID, d.Timeout(schema.TimeoutUpdate), meta)
 			if err != nil {
-				return fmt.Errorf("[ERROR] Wait for secondary node %s to become active failed: %s", nodeResp.ID, err)
+				return diag.Errorf("[ERROR] Wait for secondary node %s to become active failed: %s", nodeResp.ID, err)
 			}
 
 			// Attach configuration group if instance has one
@@ -443,23 +455,23 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 
 				if cfgID, ok := cfgMap["id"]; ok && cfgID != "" {
 					log.Printf("[DEBUG] Attaching configuration group %s to new secondary node %s", cfgID, nodeResp.ID)
-					_, err := client.CloudDatabase.Configurations().Attach(context.Background(), nodeResp.ID, cfgID, true)
+					_, err := client.CloudDatabase.Configurations().Attach(ctx, nodeResp.ID, cfgID, true)
 					if err != nil {
-						return fmt.Errorf("[ERROR] Failed to attach configuration group %s to new secondary node %s: %v", cfgID, nodeResp.ID, err)
+						return diag.Errorf("[ERROR] Failed to attach configuration group %s to new secondary node %s: %v", cfgID, nodeResp.ID, err)
 					}
 
 					// Restart if apply_immediately is true
 					if cfgMap["apply_immediately"] == "true" {
 						log.Printf("[DEBUG] Restarting new secondary node %s to apply configuration", nodeResp.ID)
-						_, err := client.CloudDatabase.Nodes().Restart(context.Background(), nodeResp.ID)
+						_, err := client.CloudDatabase.Nodes().Restart(ctx, nodeResp.ID)
 						if err != nil {
-							return fmt.Errorf("[ERROR] Failed to restart new secondary node %s: %v", nodeResp.ID, err)
+							return diag.Errorf("[ERROR] Failed to restart new secondary node %s: %v", nodeResp.ID, err)
 						}
 
 						// Wait for node to become active again
-						err = waitForCloudDatabaseNodeCreate(nodeResp.ID, 10*time.Minute, meta)
+						err = waitForCloudDatabaseNodeCreate(ctx, nodeResp.ID, d.Timeout(schema.TimeoutUpdate), meta)
 						if err != nil {
-							return fmt.Errorf("[ERROR] Wait for new secondary node %s to become active after restart failed: %s", nodeResp.ID, err)
+							return diag.Errorf("[ERROR] Wait for new secondary node %s to become active after restart failed: %s", nodeResp.ID, err)
 						}
 					}
 
@@ -485,9 +497,9 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 			for i := len(secondaryNodes) - 1; i >= 0 && deletedCount < deleteCount; i-- {
 				node := secondaryNodes[i]
 				log.Printf("[DEBUG] Deleting secondary node %s (%s)", node.ID, node.Name)
-				_, err := client.CloudDatabase.Nodes().Delete(context.Background(), node.ID, &gobizfly.CloudDatabaseDelete{})
+				_, err := client.CloudDatabase.Nodes().Delete(ctx, node.ID, &gobizfly.CloudDatabaseDelete{})
 				if err != nil {
-					return fmt.Errorf("[ERROR] Delete secondary node %s failed: %v", node.ID, err)
+					return diag.Errorf("[ERROR] Delete secondary node %s failed: %v", node.ID, err)
 				}
 				deletedCount++
 			}
@@ -500,9 +512,9 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 		_, new := d.GetChange("configuration_group")
 
 		// Get current instance to get all nodes
-		instance, err := client.CloudDatabase.Instances().Get(context.Background(), id)
+		instance, err := client.CloudDatabase.Instances().Get(ctx, id)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Get database instance [%s] for configuration group update failed: %v", id, err)
+			return diag.Errorf("[ERROR] Get database instance [%s] for configuration group update failed: %v", id, err)
 		}
 
 		if newCfg := new.(map[string]interface{}); len(newCfg) > 0 {
@@ -516,15 +528,15 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 				for _, node := range instance.Nodes {
 					if node.Status == nodeStatusRestartRequired {
 						log.Printf("[DEBUG] Node %s is in RESTART_REQUIRED state, restarting before attaching new configuration", node.ID)
-						_, err := client.CloudDatabase.Nodes().Restart(context.Background(), node.ID)
+						_, err := client.CloudDatabase.Nodes().Restart(ctx, node.ID)
 						if err != nil {
-							return fmt.Errorf("[ERROR] Failed to restart node %s before attaching configuration: %v", node.ID, err)
+							return diag.Errorf("[ERROR] Failed to restart node %s before attaching configuration: %v", node.ID, err)
 						}
 
 						// Wait for node to become active
-						err = waitForCloudDatabaseNodeCreate(node.ID, 5*time.Minute, meta)
+						err = waitForCloudDatabaseNodeCreate(ctx, node.ID, d.Timeout(schema.TimeoutUpdate), meta)
 						if err != nil {
-							return fmt.Errorf("[ERROR] Wait for node %s to become active after restart failed: %s", node.ID, err)
+							return diag.Errorf("[ERROR] Wait for node %s to become active after restart failed: %s", node.ID, err)
 						}
 						log.Printf("[DEBUG] Node %s is now active after restart", node.ID)
 					}
@@ -533,7 +545,7 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 				// Attach to all nodes
 				attachErrors := []error{}
 				for _, node := range instance.Nodes {
-					_, err := client.CloudDatabase.Configurations().Attach(context.Background(), node.ID, newCfgID, true)
+					_, err := client.CloudDatabase.Configurations().Attach(ctx, node.ID, newCfgID, true)
 					if err != nil {
 						log.Printf("[WARN] Failed to attach configuration group %s to node %s: %v", newCfgID, node.ID, err)
 						attachErrors = append(attachErrors, fmt.Errorf("node %s: %v", node.ID, err))
@@ -541,14 +553,14 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 				}
 
 				if len(attachErrors) > 0 {
-					return fmt.Errorf("[ERROR] Failed to attach new configuration group %s to some nodes: %v", newCfgID, attachErrors)
+					return diag.Errorf("[ERROR] Failed to attach new configuration group %s to some nodes: %v", newCfgID, attachErrors)
 				}
 
 				// Restart nodes if apply_immediately is true
 				if newCfgMap["apply_immediately"] == "true" {
 					restartErrors := []error{}
 					for _, node := range instance.Nodes {
-						_, err := client.CloudDatabase.Nodes().Restart(context.Background(), node.ID)
+						_, err := client.CloudDatabase.Nodes().Restart(ctx, node.ID)
 						if err != nil {
 							log.Printf("[WARN] Failed to restart node %s: %v", node.ID, err)
 							restartErrors = append(restartErrors, fmt.Errorf("node %s: %v", node.ID, err))
@@ -556,169 +568,133 @@ func resourceBizflyCloudCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta
 					}
 
 					if len(restartErrors) > 0 {
-						return fmt.Errorf("[ERROR] Failed to restart some nodes after configuration update: %v", restartErrors)
+						return diag.Errorf("[ERROR] Failed to restart some nodes after configuration update: %v", restartErrors)
 					}
 
 					// Wait for instance to become active again
-					_, err = waitForCloudDatabaseInstanceUpdate(d, meta)
+					err = waitForCloudDatabaseInstanceUpdate(ctx, d, meta)
 					if err != nil {
-						return fmt.Errorf("[ERROR] Wait for instance to become active after configuration update failed: %s", err)
+						return diag.Errorf("[ERROR] Wait for instance to become active after configuration update failed: %s", err)
 					}
 				}
 			}
 		}
 	}
 
-	return resourceBizflyCloudCloudDatabaseInstanceRead(d, meta)
+	return resourceBizflyCloudCloudDatabaseInstanceRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudCloudDatabaseInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCloudDatabaseInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	id := d.Id()
 
 	// retry
-	retry := maxRetry
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		task, err := client.CloudDatabase.Instances().Delete(context.Background(), id, &gobizfly.CloudDatabaseDelete{})
+	retries := maxRetry
+	return diag.FromErr(retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		task, err := client.CloudDatabase.Instances().Delete(ctx, id, &gobizfly.CloudDatabaseDelete{})
 
 		if err != nil {
-			retry--
-			if retry > 0 {
+			retries--
+			if retries > 0 {
 				time.Sleep(timeSleep)
-				return resource.RetryableError(fmt.Errorf("[ERROR] delete cloud database instance %s failed: %v. Retrying", id, err))
+				return retry.RetryableError(fmt.Errorf("[ERROR] delete cloud database instance %s failed: %v. Retrying", id, err))
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database instance %s failed: %v. Can't retry", id, err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database instance %s failed: %v. Can't retry", id, err))
 		}
 		_ = d.Set("task_id", task.TaskID)
 
 		// wait for cloud database instance to delete
-		_, err = waitForCloudDatabaseInstanceDelete(d, meta)
+		err = waitForCloudDatabaseInstanceDelete(ctx, d, meta)
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database instance %s with task %s failed: %v. Can't retry", id, task.TaskID, err))
+			return retry.NonRetryableError(fmt.Errorf("[ERROR] delete cloud database instance %s with task %s failed: %v. Can't retry", id, task.TaskID, err))
 		}
 
 		return nil
-	})
-}
-
-func waitForCloudDatabaseInstanceCreate(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for cloud database instance (%s) to be ready", d.Get("name").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"false", "BUILD"},
-		Target:         []string{"true", "ACTIVE", "HEALTHY"},
-		Refresh:        newCloudDatabaseInstanceStateRefreshFunc(d, meta),
-		Timeout:        d.Timeout(schema.TimeoutCreate),
-		Delay:          60 * time.Second,
-		MinTimeout:     20 * time.Second,
-		NotFoundChecks: 50,
-	}
-	return stateConf.WaitForState()
-}
-
-func waitForCloudDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for cloud database instance (%s) to be update", d.Get("name").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"false", "RESIZE", "RESTART_REQUIRED", "REBOOTING"},
-		Target:         []string{"true", "ACTIVE", "HEALTHY"},
-		Refresh:        newCloudDatabaseInstanceStateRefreshFunc(d, meta),
-		Timeout:        d.Timeout(schema.TimeoutUpdate),
-		Delay:          60 * time.Second,
-		MinTimeout:     10 * time.Second,
-		NotFoundChecks: 30,
-	}
-	return stateConf.WaitForState()
+	}))
 }
 
-func waitForCloudDatabaseInstanceDelete(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for cloud database instance (%s) to be delete", d.Get("name").(string))
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"false", "SHUTDOWN"},
-		Target:         []string{"true"},
-		Refresh:        deleteCloudDatabaseInstanceStateRefreshFunc(d, meta),
-		Timeout:        d.Timeout(schema.TimeoutDelete),
-		Delay:          30 * time.Second,
-		MinTimeout:     10 * time.Second,
-		NotFoundChecks: 30,
+func waitForCloudDatabaseInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cloud database instance (%s) to be ready", d.Get("name").(string)),
+		Pending:      []string{"BUILD"},
+		Target:       []string{"ACTIVE", "HEALTHY"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		Delay:        60 * time.Second,
+		PollInterval: 20 * time.Second,
 	}
-	return stateConf.WaitForState()
+	_, err := conf.ForStatus(ctx, cloudDatabaseInstanceStatusFunc(d, meta))
+	return err
 }
 
-func waitForCloudDatabaseNodeCreate(nodeID string, timeout time.Duration, meta interface{}) error {
-	log.Printf("[INFO] Waiting for cloud database node (%s) to become active", nodeID)
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"BUILD", "BACKUP", "RESIZE"},
-		Target:         []string{"ACTIVE", "HEALTHY"},
-		Refresh:        cloudDatabaseNodeStateRefreshFunc(nodeID, meta),
-		Timeout:        timeout,
-		Delay:          30 * time.Second,
-		MinTimeout:     10 * time.Second,
-		NotFoundChecks: 30,
+func waitForCloudDatabaseInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cloud database instance (%s) to be updated", d.Get("name").(string)),
+		Pending:      []string{"RESIZE", "RESTART_REQUIRED", "REBOOTING"},
+		Target:       []string{"ACTIVE", "HEALTHY"},
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        60 * time.Second,
+		PollInterval: 10 * time.Second,
 	}
-	_, err := stateConf.WaitForState()
+	_, err := conf.ForStatus(ctx, cloudDatabaseInstanceStatusFunc(d, meta))
 	return err
 }
 
-func cloudDatabaseNodeStateRefreshFunc(nodeID string, meta interface{}) resource.StateRefreshFunc {
+func waitForCloudDatabaseInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-
-	return func() (interface{}, string, error) {
-		node, err := client.CloudDatabase.Nodes().Get(context.Background(), nodeID)
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cloud database instance (%s) to be deleted", d.Get("name").(string)),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Delay:        30 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	return conf.ForDeletion(ctx, func(ctx context.Context) (interface{}, string, error) {
+		// Check task status
+		taskID := d.Get("task_id").(string)
+		task, err := client.CloudDatabase.Tasks().Get(ctx, taskID)
 		if err != nil {
-			return nil, "", fmt.Errorf("[ERROR] Retrieving cloud database node %s error: %v", nodeID, err)
+			return nil, "", fmt.Errorf("[ERROR] Retrieving cloud database task %s error: %w", taskID, err)
+		}
+		if !task.Ready {
+			return task, waiter.StatusTaskPending, nil
 		}
-		return node, node.Status, nil
-	}
-}
-
-func newCloudDatabaseInstanceStateRefreshFunc(d *schema.ResourceData, meta interface{}) resource.StateRefreshFunc {
-	client := meta.(*CombinedConfig).gobizflyClient()
 
-	return func() (interface{}, string, error) {
-		err := resourceBizflyCloudCloudDatabaseInstanceRead(d, meta)
+		ins, err := client.CloudDatabase.Instances().Get(ctx, d.Id())
 		if err != nil {
 			return nil, "", err
 		}
-
-		if attr, ok := d.GetOk("status"); ok {
-			ins, err := client.CloudDatabase.Instances().Get(context.Background(), d.Id())
-			if err != nil {
-				return nil, "", fmt.Errorf("[ERROR] Retrieving cloud database instance %s error: %v", d.Id(), err)
-			}
-			_ = ins // ensure variable is recognized as used
-			switch attr := attr.(type) {
-			case bool:
-				return &ins, strconv.FormatBool(attr), nil
-			default:
-				return &ins, attr.(string), nil
-			}
-		}
-		return nil, "", nil
-	}
+		return ins, ins.Status, nil
+	})
 }
 
-func deleteCloudDatabaseInstanceStateRefreshFunc(d *schema.ResourceData, meta interface{}) resource.StateRefreshFunc {
+func waitForCloudDatabaseNodeCreate(ctx context.Context, nodeID string, timeout time.Duration, meta interface{}) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-
-	return func() (interface{}, string, error) {
-		// Check stask status
-		taskID := d.Get("task_id").(string)
-		task, err := client.CloudDatabase.Tasks().Get(context.Background(), taskID)
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cloud database node (%s) to become active", nodeID),
+		Pending:      []string{"BUILD", "BACKUP", "RESIZE"},
+		Target:       []string{"ACTIVE", "HEALTHY"},
+		Timeout:      timeout,
+		Delay:        30 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		node, err := client.CloudDatabase.Nodes().Get(ctx, nodeID)
 		if err != nil {
-			return nil, "false", err
-		}
-
-		if !task.Ready {
-			return nil, "false", nil
+			return nil, "", fmt.Errorf("[ERROR] Retrieving cloud database node %s error: %v", nodeID, err)
 		}
+		return node, node.Status, nil
+	})
+	return err
+}
 
-		ins, err := client.CloudDatabase.Instances().Get(context.Background(), d.Id())
-		if errors.Is(err, gobizfly.ErrNotFound) {
-			return ins, "true", nil
-		} else if err != nil {
-			return nil, "false", err
+// cloudDatabaseInstanceStatusFunc refreshes the instance state and reports
+// its status.
+func cloudDatabaseInstanceStatusFunc(d *schema.ResourceData, meta interface{}) waiter.StatusFunc {
+	return func(ctx context.Context) (interface{}, string, error) {
+		if err := diagnosticsError(resourceBizflyCloudCloudDatabaseInstanceRead(ctx, d, meta)); err != nil {
+			return nil, "", err
 		}
-		return ins, "false", nil
+		return d.Id(), d.Get("status").(string), nil
 	}
 }
 
@@ -760,8 +736,8 @@ func readResourceCloudDatabaseInstanceSecondary(secondaries *schema.Set) map[str
 	return results
 }
 
-func readCurrentDatabases(client *gobizfly.Client, instanceID string) ([]interface{}, error) {
-	databases, err := client.CloudDatabase.Instances().ListDatabases(context.Background(), instanceID)
+func readCurrentDatabases(ctx context.Context, client *gobizfly.Client, instanceID string) ([]interface{}, error) {
+	databases, err := client.CloudDatabase.Instances().ListDatabases(ctx, instanceID)
 	if err != nil {
 		return nil, err
 	}
@@ -24,9 +24,9 @@ import (
 	"testing"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -39,9 +39,9 @@ func TestAccBizflyCloudCloudDatabaseinstance_Basic(t *testing.T) {
 	var instance gobizfly.CloudDatabaseInstance
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudCloudDatabaseInstanceDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudCloudDatabaseInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudCloudDatabaseInstanceBasicConfig(rInt),
@@ -144,3 +144,69 @@ func testAccBizflyCloudCloudDatabaseInstanceBasicConfig(rInt int) string {
 		}
 		`, rInt)
 }
+
+func TestBizflyCloudCloudDatabaseInstance_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudDatabaseInstance()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name":          "db-foo",
+		"instance_type": "basic",
+		"flavor_name":   "1c_2g",
+		"volume_size":   20,
+		"autoscaling": map[string]interface{}{
+			"enable":           0,
+			"volume_limited":   100,
+			"volume_threshold": 90,
+		},
+		"datastore": map[string]interface{}{
+			"type":       "MySQL",
+			"name":       "MySQL",
+			"version_id": "mysql-8",
+		},
+		"network_ids":       []interface{}{"vpc-1"},
+		"availability_zone": "HN1",
+	}, meta)
+	testCheckResourceDataAttr(t, d, "status", "ACTIVE")
+	testCheckResourceDataAttr(t, d, "volume_size", 20)
+	testCheckResourceDataAttr(t, d, "nodes.0.role", "primary")
+
+	testResourceDelete(t, r, d, meta)
+	if _, err := meta.gobizflyClient().CloudDatabase.Instances().Get(context.Background(), d.Id()); !errors.Is(err, gobizfly.ErrNotFound) {
+		t.Errorf("expected cloud database instance %s to be deleted, got: %v", d.Id(), err)
+	}
+}
+
+func TestBizflyCloudCloudDatabaseInstance_FakeAPIDeletedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudDatabaseInstance()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name":          "db-gone",
+		"instance_type": "basic",
+		"flavor_name":   "1c_2g",
+		"volume_size":   20,
+		"autoscaling": map[string]interface{}{
+			"enable":           0,
+			"volume_limited":   100,
+			"volume_threshold": 90,
+		},
+		"datastore": map[string]interface{}{
+			"type":       "MySQL",
+			"name":       "MySQL",
+			"version_id": "mysql-8",
+		},
+		"network_ids":       []interface{}{"vpc-1"},
+		"availability_zone": "HN1",
+	}, meta)
+
+	api.mu.Lock()
+	delete(api.databases, d.Id())
+	api.mu.Unlock()
+
+	testCheckResourceGone(t, r, d, meta)
+}
@@ -2,22 +2,22 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudContainerRegistry() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudContainerRegistryCreate,
-		Read:   resourceBizflyCloudContainerRegistryRead,
-		Update: resourceBizflyCloudContainerRegistryUpdate,
-		Delete: resourceBizflyCloudContainerRegistryDelete,
+		CreateContext: resourceBizflyCloudContainerRegistryCreate,
+		ReadContext:   resourceBizflyCloudContainerRegistryRead,
+		UpdateContext: resourceBizflyCloudContainerRegistryUpdate,
+		DeleteContext: resourceBizflyCloudContainerRegistryDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -43,8 +43,8 @@ func resourceBizflyCloudContainerRegistry() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudContainerRegistryCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*gobizfly.Client)
+func resourceBizflyCloudContainerRegistryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
 	name := d.Get("name").(string)
 	public := d.Get("public").(bool)
 
@@ -53,48 +53,49 @@ func resourceBizflyCloudContainerRegistryCreate(d *schema.ResourceData, m interf
 		Public: public,
 	}
 
-	err := client.ContainerRegistry.Create(context.Background(), payload)
+	err := client.ContainerRegistry.Create(ctx, payload)
 	if err != nil {
-		return fmt.Errorf("error creating container registry: %v", err)
+		return diag.Errorf("error creating container registry: %v", err)
 	}
 
 	d.SetId(name)
 
-	return resourceBizflyCloudContainerRegistryRead(d, m)
+	return resourceBizflyCloudContainerRegistryRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudContainerRegistryRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*gobizfly.Client)
+func resourceBizflyCloudContainerRegistryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
 	name := d.Id()
 
 	opts := &gobizfly.ListOptions{}
-	registries, err := client.ContainerRegistry.List(context.Background(), opts)
+	registries, err := client.ContainerRegistry.List(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("error retrieving container registries: %v", err)
+		return diag.Errorf("error retrieving container registries: %v", err)
 	}
 
 	for _, registry := range registries {
 		if registry.Name == name {
 			if err := d.Set("name", registry.Name); err != nil {
-				return fmt.Errorf("error setting name: %v", err)
+				return diag.Errorf("error setting name: %v", err)
 			}
 			if err := d.Set("public", registry.Public); err != nil {
-				return fmt.Errorf("error setting public: %v", err)
+				return diag.Errorf("error setting public: %v", err)
 			}
 			if err := d.Set("created_at", registry.CreatedAt); err != nil {
-				return fmt.Errorf("error setting created_at: %v", err)
+				return diag.Errorf("error setting created_at: %v", err)
 			}
 			return nil
 		}
 	}
 
 	// If registry is not found, remove it from state
+	log.Printf("[WARN] Container registry (%s) is not found", name)
 	d.SetId("")
 	return nil
 }
 
-func resourceBizflyCloudContainerRegistryUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*gobizfly.Client)
+func resourceBizflyCloudContainerRegistryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
 
 	if d.HasChange("public") {
 		name := d.Id()
@@ -104,22 +105,22 @@ func resourceBizflyCloudContainerRegistryUpdate(d *schema.ResourceData, m interf
 			Public: public,
 		}
 
-		err := client.ContainerRegistry.EditRepo(context.Background(), name, payload)
+		err := client.ContainerRegistry.EditRepo(ctx, name, payload)
 		if err != nil {
-			return fmt.Errorf("error updating container registry visibility: %v", err)
+			return diag.Errorf("error updating container registry visibility: %v", err)
 		}
 	}
 
-	return resourceBizflyCloudContainerRegistryRead(d, m)
+	return resourceBizflyCloudContainerRegistryRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudContainerRegistryDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*gobizfly.Client)
+func resourceBizflyCloudContainerRegistryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
 
 	log.Printf("[DEBUG] Deleting container registry: %s", d.Id())
-	err := client.ContainerRegistry.Delete(context.Background(), d.Id())
+	err := client.ContainerRegistry.Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting container registry: %v", err)
+		return diag.Errorf("error deleting container registry: %v", err)
 	}
 
 	return nil
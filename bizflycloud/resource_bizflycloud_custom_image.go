@@ -2,21 +2,23 @@ package bizflycloud
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBizflyCloudCustomImage() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudCustomImageCreate,
-		Read:   resourceBizflyCloudCustomImageRead,
-		Delete: resourceBizflyCloudCustomImageDelete,
+		CreateContext: resourceBizflyCloudCustomImageCreate,
+		ReadContext:   resourceBizflyCloudCustomImageRead,
+		DeleteContext: resourceBizflyCloudCustomImageDelete,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -73,7 +75,7 @@ func resourceBizflyCloudCustomImage() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudCustomImageCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCustomImageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	req := gobizfly.CreateCustomImagePayload{
 		Name:        d.Get("name").(string),
@@ -82,68 +84,73 @@ func resourceBizflyCloudCustomImageCreate(d *schema.ResourceData, meta interface
 		Description: d.Get("description").(string),
 	}
 
-	resp, err := client.CloudServer.CustomImages().Create(context.Background(), &req)
+	resp, err := client.CloudServer.CustomImages().Create(ctx, &req)
 	if err != nil {
 		log.Printf("[ERROR] Error create custom image: %v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(resp.Image.ID)
-	return resourceBizflyCloudCustomImageRead(d, meta)
+	return resourceBizflyCloudCustomImageRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudCustomImageRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCustomImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	resp, err := client.CloudServer.CustomImages().Get(context.Background(), d.Id())
+	resp, err := client.CloudServer.CustomImages().Get(ctx, d.Id())
 	if err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Custom image (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[ERROR] Error read custom image %s: %v", d.Id(), err)
-		return err
+		return diag.FromErr(err)
 	}
 	customImage := resp.Image
 	d.SetId(customImage.ID)
 	err = d.Set("name", customImage.Name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("size", customImage.Size)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("disk_format", customImage.DiskFormat)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("container_format", customImage.ContainerFormat)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("billing_plan", customImage.BillingPlan)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("visibility", customImage.Visibility)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("created_at", customImage.CreatedAt)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("updated_at", customImage.UpdatedAt)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("description", customImage.Description)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceBizflyCloudCustomImageDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudCustomImageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudServer.CustomImages().Delete(context.Background(), d.Id())
+	err := client.CloudServer.CustomImages().Delete(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -2,83 +2,139 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
-	"time"
+	"errors"
+	"log"
+	"net/http"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudDNS() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudDNSCreate,
-		Read:   resourceBizflyCloudDNSRead,
-		Update: resourceBizflyCloudDNSUpdate,
-		Delete: resourceBizflyCloudDNSDelete,
+		CreateContext: resourceBizflyCloudDNSCreate,
+		ReadContext:   resourceBizflyCloudDNSRead,
+		UpdateContext: resourceBizflyCloudDNSUpdate,
+		DeleteContext: resourceBizflyCloudDNSDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 1,
 		Schema:        resourceDNSSchema(),
-		Timeouts: &schema.ResourceTimeout{
-			Read: schema.DefaultTimeout(10 * time.Minute),
-		},
 	}
 }
 
-func resourceBizflyCloudDNSCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudDNSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	czp := &gobizfly.CreateZonePayload{
 		Name:        d.Get("name").(string),
 		Required:    d.Get("required").(bool),
 		Description: d.Get("description").(string),
 	}
-	zone, err := client.DNS.CreateZone(context.Background(), czp)
+	zone, err := client.DNS.CreateZone(ctx, czp)
 	if err != nil {
-		return fmt.Errorf("error creating dns zone: %v", err)
+		return diag.Errorf("error creating dns zone: %v", err)
 	}
 	d.SetId(zone.ID)
-	return resourceBizflyCloudDNSRead(d, meta)
+
+	// The create API does not take the TTL and the active flag, set them
+	// right after the zone is created. An unset active flag keeps the one the
+	// zone was created with.
+	_, ttlOk := d.GetOk("ttl")
+	active, activeOk := d.GetOkExists("active") // nolint
+	if ttlOk || (activeOk && active.(bool) != zone.Active) {
+		payload := expandDNSZoneUpdate(d)
+		if !activeOk {
+			payload.Active = zone.Active
+		}
+		if err := updateDNSZone(ctx, client, zone.ID, payload); err != nil {
+			return diag.Errorf("error updating dns zone %s: %v", zone.ID, err)
+		}
+	}
+	return resourceBizflyCloudDNSRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudDNSRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudDNSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	zone, err := client.DNS.GetZone(context.Background(), d.Id())
+	zone, err := client.DNS.GetZone(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error getting dns zone: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] DNS zone (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting dns zone: %v", err)
 	}
 	_ = d.Set("name", zone.Name)
 	_ = d.Set("active", zone.Active)
 	_ = d.Set("created_at", zone.CreatedAt)
-	_ = d.Set("update_at", zone.UpdatedAt)
+	_ = d.Set("updated_at", zone.UpdatedAt)
 	_ = d.Set("deleted", zone.Deleted)
 	_ = d.Set("ttl", zone.TTL)
 	_ = d.Set("tenant_id", zone.TenantID)
 
 	if err := d.Set("nameserver", readNameServer(zone.NameServer)); err != nil {
-		return fmt.Errorf("error setting nameserver: %w", err)
+		return diag.Errorf("error setting nameserver: %v", err)
 	}
 
 	if err := d.Set("record_set", readRecordsSet(zone.RecordsSet)); err != nil {
-		return fmt.Errorf("error setting record_set: %w", err)
+		return diag.Errorf("error setting record_set: %v", err)
 	}
 
 	return nil
 }
 
-func resourceBizflyCloudDNSUpdate(d *schema.ResourceData, meta interface{}) error {
-	return nil
+func resourceBizflyCloudDNSUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	if d.HasChanges("description", "active", "ttl") {
+		if err := updateDNSZone(ctx, client, d.Id(), expandDNSZoneUpdate(d)); err != nil {
+			return diag.Errorf("error updating dns zone %s: %v", d.Id(), err)
+		}
+	}
+	return resourceBizflyCloudDNSRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudDNSDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudDNSDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.DNS.DeleteZone(context.Background(), d.Id())
+	err := client.DNS.DeleteZone(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting dns zone: %v", err)
+		return diag.Errorf("error deleting dns zone: %v", err)
 	}
 	return nil
 }
 
+// dnsZoneUpdatePayload is the body of a zone update request. gobizfly has no
+// call for it, so updateDNSZone sends it with client.NewRequest.
+type dnsZoneUpdatePayload struct {
+	Description string `json:"description"`
+	Active      bool   `json:"active"`
+	TTL         int    `json:"ttl,omitempty"`
+}
+
+func expandDNSZoneUpdate(d *schema.ResourceData) *dnsZoneUpdatePayload {
+	return &dnsZoneUpdatePayload{
+		Description: d.Get("description").(string),
+		Active:      d.Get("active").(bool),
+		TTL:         d.Get("ttl").(int),
+	}
+}
+
+func updateDNSZone(ctx context.Context, client *gobizfly.Client, zoneID string, payload *dnsZoneUpdatePayload) error {
+	body := struct {
+		Zones *dnsZoneUpdatePayload `json:"zones"`
+	}{Zones: payload}
+	req, err := client.NewRequest(ctx, http.MethodPut, dnsServiceName, "/zone/"+zoneID, &body)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
 func readNameServer(nameServer []string) []string {
 	var results []string
 	results = append(results, nameServer...)
@@ -0,0 +1,438 @@
+package bizflycloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/constants"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dnsServiceName = "dns"
+
+	defaultDNSHealthCheckInterval = 60
+)
+
+func resourceBizflyCloudDNSRecord() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBizflyCloudDNSRecordCreate,
+		ReadContext:   resourceBizflyCloudDNSRecordRead,
+		UpdateContext: resourceBizflyCloudDNSRecordUpdate,
+		DeleteContext: resourceBizflyCloudDNSRecordDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBizflyCloudDNSRecordImport,
+		},
+		Schema:        resourceDNSRecordSchema(),
+		CustomizeDiff: resourceBizflyCloudDNSRecordCustomizeDiff,
+	}
+}
+
+func resourceBizflyCloudDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	zoneID := d.Get("zone_id").(string)
+	base := gobizfly.BaseCreateRecordPayload{
+		Name: d.Get("name").(string),
+		Type: d.Get("type").(string),
+		TTL:  d.Get("ttl").(int),
+	}
+	var payload interface{}
+	switch data := expandDNSRecordData(d).(type) {
+	case dnsRoutingPolicyRecordPayload:
+		data.Name, data.Type, data.TTL = base.Name, base.Type, base.TTL
+		payload = data
+	case []gobizfly.MXData:
+		payload = gobizfly.CreateMXRecordPayload{BaseCreateRecordPayload: base, Data: data}
+	case []gobizfly.SRVData:
+		payload = gobizfly.CreateSRVRecordPayload{BaseCreateRecordPayload: base, Data: data}
+	case []string:
+		payload = gobizfly.CreateNormalRecordPayload{BaseCreateRecordPayload: base, Data: data}
+	}
+	log.Printf("[DEBUG] Create DNS record in zone %s: %+v", zoneID, payload)
+	record, err := client.DNS.CreateRecord(ctx, zoneID, payload)
+	if err != nil {
+		return diag.Errorf("error creating dns record: %v", err)
+	}
+	d.SetId(record.ID)
+	return resourceBizflyCloudDNSRecordRead(ctx, d, meta)
+}
+
+func resourceBizflyCloudDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	record, err := getDNSRecord(ctx, client, d.Id())
+	if err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] DNS record (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting dns record: %v", err)
+	}
+	if record.ZoneID != "" {
+		_ = d.Set("zone_id", record.ZoneID)
+	}
+	_ = d.Set("name", record.Name)
+	_ = d.Set("type", record.Type)
+	_ = d.Set("ttl", record.TTL)
+	_ = d.Set("tenant_id", record.TenantID)
+	_ = d.Set("created_at", record.CreatedAt)
+	_ = d.Set("updated_at", record.UpdatedAt)
+
+	data, priority, weight, port := flattenDNSRecordData(record.Type, record.Data)
+	if err := d.Set("data", data); err != nil {
+		return diag.Errorf("error setting data: %v", err)
+	}
+	switch record.Type {
+	case constants.DNSRecordTypeMX:
+		_ = d.Set("priority", priority)
+	case constants.DNSRecordTypeSRV:
+		_ = d.Set("priority", priority)
+		_ = d.Set("weight", weight)
+		_ = d.Set("port", port)
+	}
+	if err := d.Set("routing_policy", flattenDNSRoutingPolicy(record.RoutingPolicyData)); err != nil {
+		return diag.Errorf("error setting routing_policy: %v", err)
+	}
+	return nil
+}
+
+func resourceBizflyCloudDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	base := gobizfly.BaseUpdateRecordPayload{
+		Name: d.Get("name").(string),
+		Type: d.Get("type").(string),
+		TTL:  d.Get("ttl").(int),
+	}
+	var payload interface{}
+	switch data := expandDNSRecordData(d).(type) {
+	case dnsRoutingPolicyRecordPayload:
+		data.Name, data.Type, data.TTL = base.Name, base.Type, base.TTL
+		payload = data
+	case []gobizfly.MXData:
+		payload = gobizfly.UpdateMXRecordPayload{BaseUpdateRecordPayload: base, Data: data}
+	case []gobizfly.SRVData:
+		payload = gobizfly.UpdateSRVRecordPayload{BaseUpdateRecordPayload: base, Data: data}
+	case []string:
+		payload = gobizfly.UpdateNormalRecordPayload{BaseUpdateRecordPayload: base, Data: data}
+	}
+	log.Printf("[DEBUG] Update DNS record %s: %+v", d.Id(), payload)
+	if _, err := client.DNS.UpdateRecord(ctx, d.Id(), payload); err != nil {
+		return diag.Errorf("error updating dns record %s: %v", d.Id(), err)
+	}
+	return resourceBizflyCloudDNSRecordRead(ctx, d, meta)
+}
+
+func resourceBizflyCloudDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	if err := client.DNS.DeleteRecord(ctx, d.Id()); err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] DNS record %s is already deleted", d.Id())
+			return nil
+		}
+		return diag.Errorf("error deleting dns record %s: %v", d.Id(), err)
+	}
+	return nil
+}
+
+// resourceBizflyCloudDNSRecordImport accepts an ID of the form
+// <zone_id>/<record_id>.
+func resourceBizflyCloudDNSRecordImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid dns record import ID %q, expected <zone_id>/<record_id>", d.Id())
+	}
+	_ = d.Set("zone_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceBizflyCloudDNSRecordCustomizeDiff rejects routing policies on
+// record types which cannot be routed.
+func resourceBizflyCloudDNSRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	recordType := d.Get("type").(string)
+	if len(d.Get("routing_policy").([]interface{})) == 0 {
+		return nil
+	}
+	for _, t := range constants.ValidDNSRoutingRecordTypes {
+		if t == recordType {
+			return nil
+		}
+	}
+	return fmt.Errorf("routing_policy is only supported for %s records, got %s",
+		strings.Join(constants.ValidDNSRoutingRecordTypes, ", "), recordType)
+}
+
+// expandDNSRecordData builds the record data in the shape expected by the API
+// for the record type: a list of MXData for MX records, a list of SRVData for
+// SRV records and a plain list of values for everything else. Records with a
+// routing policy, or whose routing policy is being removed, are sent as a
+// dnsRoutingPolicyRecordPayload without the name, type and TTL set.
+func expandDNSRecordData(d *schema.ResourceData) interface{} {
+	values := readStringArray(d.Get("data").([]interface{}))
+	if policies := d.Get("routing_policy").([]interface{}); len(policies) > 0 || d.HasChange("routing_policy") {
+		payload := dnsRoutingPolicyRecordPayload{Data: values}
+		if len(policies) > 0 && policies[0] != nil {
+			payload.RoutingPolicyData = expandDNSRoutingPolicy(policies[0].(map[string]interface{}))
+		}
+		return payload
+	}
+	priority := d.Get("priority").(int)
+	switch d.Get("type").(string) {
+	case constants.DNSRecordTypeMX:
+		data := make([]gobizfly.MXData, 0, len(values))
+		for _, value := range values {
+			data = append(data, gobizfly.MXData{Value: value, Priority: priority})
+		}
+		return data
+	case constants.DNSRecordTypeSRV:
+		data := make([]gobizfly.SRVData, 0, len(values))
+		for _, value := range values {
+			data = append(data, gobizfly.SRVData{
+				Target:   value,
+				Priority: priority,
+				Weight:   d.Get("weight").(int),
+				Port:     d.Get("port").(int),
+			})
+		}
+		return data
+	default:
+		return values
+	}
+}
+
+// flattenDNSRecordData is the reverse of expandDNSRecordData. MX and SRV data
+// items share their priority, weight and port, so those are taken from the
+// first item.
+func flattenDNSRecordData(recordType string, data []interface{}) ([]string, int, int, int) {
+	var (
+		values                 []string
+		priority, weight, port int
+	)
+	for i, item := range data {
+		switch v := item.(type) {
+		case string:
+			values = append(values, v)
+		case map[string]interface{}:
+			key := "value"
+			if recordType == constants.DNSRecordTypeSRV {
+				key = "target"
+			}
+			value, _ := v[key].(string)
+			values = append(values, value)
+			if i == 0 {
+				priority = dnsRecordDataInt(v["priority"])
+				weight = dnsRecordDataInt(v["weight"])
+				port = dnsRecordDataInt(v["port"])
+			}
+		}
+	}
+	return values, priority, weight, port
+}
+
+func dnsRecordDataInt(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
+
+// dnsRecord is a record together with its routing policy, which is not part
+// of gobizfly.Record.
+type dnsRecord struct {
+	gobizfly.Record
+	RoutingPolicyData dnsRoutingPolicyData `json:"routing_policy_data"`
+}
+
+// dnsRoutingPolicyRecordPayload is the create and update payload of a record
+// with a routing policy.
+type dnsRoutingPolicyRecordPayload struct {
+	Name              string               `json:"name,omitempty"`
+	Type              string               `json:"type,omitempty"`
+	TTL               int                  `json:"ttl,omitempty"`
+	Data              []string             `json:"data"`
+	RoutingPolicyData dnsRoutingPolicyData `json:"routing_policy_data"`
+}
+
+// dnsRoutingPolicyData is the routing_policy_data object of a record. Only one
+// of RoutingData (answers per resolver region), WeightedData and FailoverData
+// is set.
+type dnsRoutingPolicyData struct {
+	RoutingData  map[string][]string `json:"routing_data,omitempty"`
+	WeightedData []dnsWeightedAnswer `json:"weighted_data,omitempty"`
+	FailoverData *dnsFailoverData    `json:"failover_data,omitempty"`
+	HealthCheck  *dnsHealthCheck     `json:"healthcheck,omitempty"`
+}
+
+type dnsWeightedAnswer struct {
+	Value  string `json:"value"`
+	Weight int    `json:"weight"`
+}
+
+type dnsFailoverData struct {
+	Primary   []string `json:"primary"`
+	Secondary []string `json:"secondary"`
+}
+
+type dnsHealthCheck struct {
+	TCPConnect *dnsTCPHealthCheck  `json:"tcp_connect,omitempty"`
+	HTTPStatus *dnsHTTPHealthCheck `json:"http_status,omitempty"`
+}
+
+type dnsTCPHealthCheck struct {
+	TCPPort  int `json:"tcp_port"`
+	Interval int `json:"interval,omitempty"`
+}
+
+type dnsHTTPHealthCheck struct {
+	HTTPPort    int    `json:"http_port"`
+	URLPath     string `json:"url_path"`
+	VirtualHost string `json:"vhost,omitempty"`
+	OkCodes     []int  `json:"ok_codes,omitempty"`
+	Interval    int    `json:"interval,omitempty"`
+}
+
+// getDNSRecord is client.DNS.GetRecord, keeping the routing policy of the
+// record.
+func getDNSRecord(ctx context.Context, client *gobizfly.Client, recordID string) (*dnsRecord, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, dnsServiceName, "/record/"+recordID, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var data struct {
+		Record *dnsRecord `json:"record"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	if data.Record == nil {
+		return nil, fmt.Errorf("empty response for dns record %s", recordID)
+	}
+	return data.Record, nil
+}
+
+func expandDNSRoutingPolicy(policy map[string]interface{}) dnsRoutingPolicyData {
+	var data dnsRoutingPolicyData
+	if geo := policy["geo"].(*schema.Set).List(); len(geo) > 0 {
+		data.RoutingData = make(map[string][]string, len(geo))
+		for _, v := range geo {
+			answer := v.(map[string]interface{})
+			region := answer["region"].(string)
+			data.RoutingData[region] = append(data.RoutingData[region], readStringArray(answer["data"].([]interface{}))...)
+		}
+	}
+	for _, v := range policy["weighted"].([]interface{}) {
+		answer := v.(map[string]interface{})
+		data.WeightedData = append(data.WeightedData, dnsWeightedAnswer{
+			Value:  answer["value"].(string),
+			Weight: answer["weight"].(int),
+		})
+	}
+	if failover := policy["failover"].([]interface{}); len(failover) > 0 && failover[0] != nil {
+		answers := failover[0].(map[string]interface{})
+		data.FailoverData = &dnsFailoverData{
+			Primary:   readStringArray(answers["primary"].([]interface{})),
+			Secondary: readStringArray(answers["secondary"].([]interface{})),
+		}
+	}
+	if healthCheck := policy["health_check"].([]interface{}); len(healthCheck) > 0 && healthCheck[0] != nil {
+		data.HealthCheck = expandDNSHealthCheck(healthCheck[0].(map[string]interface{}))
+	}
+	return data
+}
+
+func expandDNSHealthCheck(healthCheck map[string]interface{}) *dnsHealthCheck {
+	interval := healthCheck["interval"].(int)
+	if interval == 0 {
+		interval = defaultDNSHealthCheckInterval
+	}
+	if healthCheck["protocol"].(string) == constants.DNSHealthCheckTCP {
+		return &dnsHealthCheck{TCPConnect: &dnsTCPHealthCheck{
+			TCPPort:  healthCheck["port"].(int),
+			Interval: interval,
+		}}
+	}
+	path := healthCheck["path"].(string)
+	if path == "" {
+		path = "/"
+	}
+	var okCodes []int
+	for _, code := range healthCheck["ok_codes"].([]interface{}) {
+		okCodes = append(okCodes, code.(int))
+	}
+	if len(okCodes) == 0 {
+		okCodes = []int{http.StatusOK}
+	}
+	return &dnsHealthCheck{HTTPStatus: &dnsHTTPHealthCheck{
+		HTTPPort:    healthCheck["port"].(int),
+		URLPath:     path,
+		VirtualHost: healthCheck["host"].(string),
+		OkCodes:     okCodes,
+		Interval:    interval,
+	}}
+}
+
+func flattenDNSRoutingPolicy(data dnsRoutingPolicyData) []map[string]interface{} {
+	if len(data.RoutingData) == 0 && len(data.WeightedData) == 0 && data.FailoverData == nil {
+		return nil
+	}
+	policy := map[string]interface{}{}
+	var geo []interface{}
+	for region, answers := range data.RoutingData {
+		geo = append(geo, map[string]interface{}{
+			"region": region,
+			"data":   answers,
+		})
+	}
+	policy["geo"] = geo
+	var weighted []map[string]interface{}
+	for _, answer := range data.WeightedData {
+		weighted = append(weighted, map[string]interface{}{
+			"value":  answer.Value,
+			"weight": answer.Weight,
+		})
+	}
+	policy["weighted"] = weighted
+	if data.FailoverData != nil {
+		policy["failover"] = []map[string]interface{}{{
+			"primary":   data.FailoverData.Primary,
+			"secondary": data.FailoverData.Secondary,
+		}}
+	}
+	if hc := data.HealthCheck; hc != nil {
+		switch {
+		case hc.TCPConnect != nil:
+			policy["health_check"] = []map[string]interface{}{{
+				"protocol": constants.DNSHealthCheckTCP,
+				"port":     hc.TCPConnect.TCPPort,
+				"interval": hc.TCPConnect.Interval,
+			}}
+		case hc.HTTPStatus != nil:
+			policy["health_check"] = []map[string]interface{}{{
+				"protocol": constants.DNSHealthCheckHTTP,
+				"port":     hc.HTTPStatus.HTTPPort,
+				"path":     hc.HTTPStatus.URLPath,
+				"host":     hc.HTTPStatus.VirtualHost,
+				"ok_codes": hc.HTTPStatus.OkCodes,
+				"interval": hc.HTTPStatus.Interval,
+			}}
+		}
+	}
+	return []map[string]interface{}{policy}
+}
@@ -0,0 +1,193 @@
+package bizflycloud
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestBizflyCloudDNSRecord_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	zone := testResourceCreate(t, resourceBizflyCloudDNS(), map[string]interface{}{
+		"name": "example.vn",
+	}, meta)
+	r := resourceBizflyCloudDNSRecord()
+
+	config := map[string]interface{}{
+		"zone_id": zone.Id(),
+		"name":    "www",
+		"type":    "A",
+		"ttl":     600,
+		"data":    []interface{}{"192.0.2.10", "192.0.2.11"},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "zone_id", zone.Id())
+	testCheckResourceDataAttr(t, d, "ttl", 600)
+	testCheckResourceDataAttr(t, d, "data.1", "192.0.2.11")
+
+	config["ttl"] = 300
+	config["data"] = []interface{}{"192.0.2.20"}
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "ttl", 300)
+	testCheckResourceDataAttr(t, d, "data.#", 1)
+	testCheckResourceDataAttr(t, d, "data.0", "192.0.2.20")
+
+	imported := testResourceImport(t, r, zone.Id()+"/"+d.Id(), meta)
+	if imported.Id() != d.Id() {
+		t.Errorf("expected imported ID to be %s, got %s", d.Id(), imported.Id())
+	}
+	testCheckResourceDataAttr(t, imported, "zone_id", zone.Id())
+	testCheckResourceDataAttr(t, imported, "name", "www")
+
+	testResourceDelete(t, r, d, meta)
+	if _, err := meta.gobizflyClient().DNS.GetRecord(context.Background(), d.Id()); !errors.Is(err, gobizfly.ErrNotFound) {
+		t.Errorf("expected dns record %s to be deleted, got: %v", d.Id(), err)
+	}
+	// a record already deleted outside terraform does not fail the delete
+	testResourceDelete(t, r, d, meta)
+}
+
+func TestBizflyCloudDNSRecord_FakeAPIMXAndSRV(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	zone := testResourceCreate(t, resourceBizflyCloudDNS(), map[string]interface{}{
+		"name": "example.vn",
+	}, meta)
+	r := resourceBizflyCloudDNSRecord()
+
+	mx := testResourceCreate(t, r, map[string]interface{}{
+		"zone_id":  zone.Id(),
+		"name":     "@",
+		"type":     "MX",
+		"data":     []interface{}{"mx1.example.vn"},
+		"priority": 10,
+	}, meta)
+	testCheckResourceDataAttr(t, mx, "data.0", "mx1.example.vn")
+	testCheckResourceDataAttr(t, mx, "priority", 10)
+
+	srv := testResourceCreate(t, r, map[string]interface{}{
+		"zone_id":  zone.Id(),
+		"name":     "_sip._tcp",
+		"type":     "SRV",
+		"data":     []interface{}{"sip.example.vn"},
+		"priority": 5,
+		"weight":   20,
+		"port":     5060,
+	}, meta)
+	testCheckResourceDataAttr(t, srv, "data.0", "sip.example.vn")
+	testCheckResourceDataAttr(t, srv, "priority", 5)
+	testCheckResourceDataAttr(t, srv, "weight", 20)
+	testCheckResourceDataAttr(t, srv, "port", 5060)
+}
+
+func TestBizflyCloudDNSRecord_ImportInvalidID(t *testing.T) {
+	r := resourceBizflyCloudDNSRecord()
+	for _, id := range []string{"record-1", "/record-1", "zone-1/"} {
+		d := r.Data(nil)
+		d.SetId(id)
+		_, err := r.Importer.StateContext(context.Background(), d, nil)
+		if err == nil || !strings.Contains(err.Error(), "<zone_id>/<record_id>") {
+			t.Errorf("expected import of %q to fail with the expected ID format, got: %v", id, err)
+		}
+	}
+}
+
+func TestBizflyCloudDNSRecord_FakeAPIRoutingPolicy(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	zone := testResourceCreate(t, resourceBizflyCloudDNS(), map[string]interface{}{
+		"name": "example.vn",
+	}, meta)
+	r := resourceBizflyCloudDNSRecord()
+
+	config := map[string]interface{}{
+		"zone_id": zone.Id(),
+		"name":    "app",
+		"type":    "A",
+		"routing_policy": []interface{}{map[string]interface{}{
+			"geo": []interface{}{
+				map[string]interface{}{"region": "HN", "data": []interface{}{"192.0.2.10"}},
+				map[string]interface{}{"region": "SG", "data": []interface{}{"198.51.100.10"}},
+			},
+			"health_check": []interface{}{map[string]interface{}{
+				"protocol": "HTTP",
+				"port":     80,
+				"path":     "/healthz",
+			}},
+		}},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.geo.#", 2)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.health_check.0.protocol", "HTTP")
+	testCheckResourceDataAttr(t, d, "routing_policy.0.health_check.0.path", "/healthz")
+	testCheckResourceDataAttr(t, d, "routing_policy.0.health_check.0.interval", defaultDNSHealthCheckInterval)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.health_check.0.ok_codes.0", 200)
+
+	config["routing_policy"] = []interface{}{map[string]interface{}{
+		"weighted": []interface{}{
+			map[string]interface{}{"value": "192.0.2.10", "weight": 80},
+			map[string]interface{}{"value": "198.51.100.10", "weight": 20},
+		},
+	}}
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.geo.#", 0)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.weighted.#", 2)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.weighted.1.weight", 20)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.health_check.#", 0)
+
+	config["routing_policy"] = []interface{}{map[string]interface{}{
+		"failover": []interface{}{map[string]interface{}{
+			"primary":   []interface{}{"192.0.2.10"},
+			"secondary": []interface{}{"198.51.100.10"},
+		}},
+		"health_check": []interface{}{map[string]interface{}{
+			"protocol": "TCP",
+			"port":     443,
+		}},
+	}}
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.weighted.#", 0)
+	testCheckResourceDataAttr(t, d, "routing_policy.0.failover.0.secondary.0", "198.51.100.10")
+	testCheckResourceDataAttr(t, d, "routing_policy.0.health_check.0.port", 443)
+
+	delete(config, "routing_policy")
+	config["data"] = []interface{}{"192.0.2.10"}
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "routing_policy.#", 0)
+	testCheckResourceDataAttr(t, d, "data.0", "192.0.2.10")
+}
+
+func TestBizflyCloudDNSRecord_RoutingPolicyValidation(t *testing.T) {
+	r := resourceBizflyCloudDNSRecord()
+	policy := map[string]interface{}{
+		"weighted": []interface{}{map[string]interface{}{"value": "mx1.example.vn", "weight": 50}},
+	}
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone_id":        "zone-1",
+		"name":           "@",
+		"type":           "MX",
+		"routing_policy": []interface{}{policy},
+	}), nil)
+	if err == nil || !strings.Contains(err.Error(), "routing_policy is only supported for") {
+		t.Errorf("expected routing policy on an MX record to be rejected, got: %v", err)
+	}
+
+	policy["geo"] = []interface{}{map[string]interface{}{"region": "HN", "data": []interface{}{"192.0.2.10"}}}
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone_id":        "zone-1",
+		"name":           "app",
+		"type":           "A",
+		"routing_policy": []interface{}{policy},
+	}))
+	if !diags.HasError() {
+		t.Error("expected geo and weighted routing policies to conflict")
+	}
+}
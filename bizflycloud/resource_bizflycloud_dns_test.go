@@ -4,12 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -22,9 +23,9 @@ func TestAccBizflyCloudDNS(t *testing.T) {
 	var extendedZone gobizfly.ExtendedZone
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudDNSDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudDNSDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudDNSConfig(rInt),
@@ -102,3 +103,140 @@ resource "bizflycloud_dns" "zone" {
 }
 `, rInt)
 }
+
+func TestBizflyCloudDNS_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudDNS()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name":        "example.vn",
+		"description": "test dns zone",
+	}, meta)
+	testCheckResourceDataAttr(t, d, "name", "example.vn")
+	testCheckResourceDataAttr(t, d, "ttl", 3600)
+	testCheckResourceDataAttr(t, d, "tenant_id", fakeAPIProjectID)
+	if n := d.Get("nameserver.#").(int); n != 2 {
+		t.Errorf("expected 2 nameservers, got %d", n)
+	}
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckResourceDataAttr(t, imported, "name", "example.vn")
+
+	testResourceDelete(t, r, d, meta)
+	if _, err := meta.gobizflyClient().DNS.GetZone(context.Background(), d.Id()); !errors.Is(err, gobizfly.ErrNotFound) {
+		t.Errorf("expected dns zone %s to be deleted, got: %v", d.Id(), err)
+	}
+}
+
+func TestBizflyCloudDNS_FakeAPIDeletedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudDNS()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name": "gone.vn",
+	}, meta)
+
+	api.mu.Lock()
+	delete(api.zones, d.Id())
+	api.mu.Unlock()
+
+	testCheckResourceGone(t, r, d, meta)
+}
+
+func TestBizflyCloudDNS_FakeAPIUpdate(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudDNS()
+
+	config := map[string]interface{}{
+		"name":   "example.vn",
+		"active": true,
+		"ttl":    600,
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "active", true)
+	testCheckResourceDataAttr(t, d, "ttl", 600)
+
+	config["active"] = false
+	config["ttl"] = 300
+	config["description"] = "updated zone"
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "active", false)
+	testCheckResourceDataAttr(t, d, "ttl", 300)
+	testCheckResourceDataAttr(t, d, "description", "updated zone")
+	if n := api.requestCount(http.MethodPut, "/dns/zone/"); n != 2 {
+		t.Errorf("expected 2 zone update requests, got %d", n)
+	}
+}
+
+func TestBizflyCloudDNS_FakeAPICreateInactive(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudDNS()
+
+	config := map[string]interface{}{
+		"name":   "example.vn",
+		"active": false,
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "active", false)
+	testCheckResourceDataAttr(t, d, "ttl", 3600)
+	api.mu.Lock()
+	active := api.zones[d.Id()].Active
+	api.mu.Unlock()
+	if active {
+		t.Errorf("expected zone %s to be created inactive", d.Id())
+	}
+	testCheckNoDiff(t, r, d, config, meta)
+
+	// required and name are only sent on create, so changing them replaces
+	// the zone
+	for key, value := range map[string]interface{}{"required": true, "name": "example.com.vn"} {
+		raw := map[string]interface{}{"name": "example.vn", "active": false}
+		raw[key] = value
+		diff, err := r.Diff(testContext(), d.State(), terraform.NewResourceConfigRaw(raw), meta)
+		if err != nil {
+			t.Fatalf("error planning %s change: %v", key, err)
+		}
+		if diff == nil || !diff.RequiresNew() {
+			t.Errorf("expected a change of %s to replace the zone", key)
+		}
+	}
+}
+
+func TestBizflyCloudDNS_FakeAPIImport(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudDNS()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name": "example.vn",
+	}, meta)
+	testResourceCreate(t, resourceBizflyCloudDNSRecord(), map[string]interface{}{
+		"zone_id": d.Id(),
+		"name":    "www",
+		"type":    "A",
+		"ttl":     600,
+		"data":    []interface{}{"192.0.2.10"},
+	}, meta)
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckResourceDataAttr(t, imported, "name", "example.vn")
+	testCheckResourceDataAttr(t, imported, "ttl", 3600)
+	testCheckResourceDataAttr(t, imported, "active", true)
+	testCheckResourceDataAttr(t, imported, "tenant_id", fakeAPIProjectID)
+	testCheckResourceDataAttr(t, imported, "nameserver.#", 2)
+	testCheckResourceDataAttr(t, imported, "record_set.#", 1)
+	testCheckResourceDataAttr(t, imported, "record_set.0.name", "www")
+	testCheckResourceDataAttr(t, imported, "record_set.0.ttl", 600)
+	if imported.Get("updated_at").(string) == "" {
+		t.Error("expected updated_at to be set")
+	}
+}
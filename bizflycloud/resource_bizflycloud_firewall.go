@@ -19,21 +19,23 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudFirewall() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudFirewallCreate,
-		Read:   resourceBizflyCloudFirewallRead,
-		Update: resourceBizflyCloudFirewallUpdate,
-		Delete: resourceBizflyCloudFirewallDelete,
+		CreateContext: resourceBizflyCloudFirewallCreate,
+		ReadContext:   resourceBizflyCloudFirewallRead,
+		UpdateContext: resourceBizflyCloudFirewallUpdate,
+		DeleteContext: resourceBizflyCloudFirewallDelete,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -112,23 +114,28 @@ func firewallRequestBuilder(d *schema.ResourceData) gobizfly.FirewallRequestPayl
 	}
 	return firewallOpts
 }
-func resourceBizflyCloudFirewallCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudFirewallCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	firewallOpts := firewallRequestBuilder(d)
-	firewall, err := client.CloudServer.Firewalls().Create(context.Background(), &firewallOpts)
+	firewall, err := client.CloudServer.Firewalls().Create(ctx, &firewallOpts)
 	if err != nil {
-		return fmt.Errorf("error creating firewall: %v", err)
+		return diag.Errorf("error creating firewall: %v", err)
 	}
 	d.SetId(firewall.ID)
-	return resourceBizflyCloudFirewallRead(d, meta)
+	return resourceBizflyCloudFirewallRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudFirewallRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudFirewallRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	firewall, err := client.CloudServer.Firewalls().Get(context.Background(), d.Id())
+	firewall, err := client.CloudServer.Firewalls().Get(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error retrieving firewall: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Firewall (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving firewall: %v", err)
 	}
 	_ = d.Set("name", firewall.Name)
 	_ = d.Set("rules_count", firewall.RulesCount)
@@ -144,25 +151,25 @@ func resourceBizflyCloudFirewallRead(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceBizflyCloudFirewallDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudFirewallDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	_, err := client.CloudServer.Firewalls().Delete(context.Background(), d.Id())
+	_, err := client.CloudServer.Firewalls().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting firewall: %v", err)
+		return diag.Errorf("error deleting firewall: %v", err)
 	}
 	return nil
 }
 
-func resourceBizflyCloudFirewallUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudFirewallUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	firewallOpts := firewallRequestBuilder(d)
-	firewall, err := client.CloudServer.Firewalls().Update(context.Background(), d.Id(), &firewallOpts)
+	firewall, err := client.CloudServer.Firewalls().Update(ctx, d.Id(), &firewallOpts)
 	if err != nil {
-		return fmt.Errorf("error updating firewall: %v", err)
+		return diag.Errorf("error updating firewall: %v", err)
 	}
 	d.SetId(firewall.ID)
-	return resourceBizflyCloudFirewallRead(d, meta)
+	return resourceBizflyCloudFirewallRead(ctx, d, meta)
 }
 
 func flatternFirewallRules(rules *schema.Set) []gobizfly.FirewallRuleCreateRequest {
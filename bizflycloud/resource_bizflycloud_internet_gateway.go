@@ -2,25 +2,36 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log"
+	"net/http"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceInternetGateway() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: resourceInternetGatewaySchema(),
-		Read:   resourceInternetGatewayRead,
-		Create: resourceInternetGatewayCreate,
-		Update: resourceInternetGatewayUpdate,
-		Delete: resourceInternetGatewayDelete,
+		Schema:        resourceInternetGatewaySchema(),
+		CustomizeDiff: resourceTagsCustomizeDiff,
+		ReadContext:   resourceInternetGatewayRead,
+		CreateContext: resourceInternetGatewayCreate,
+		UpdateContext: resourceInternetGatewayUpdate,
+		DeleteContext: resourceInternetGatewayDelete,
 	}
 }
 
+// internetGatewayUpdatePayload is gobizfly.UpdateInternetGatewayPayload with
+// the tags of the internet gateway, which gobizfly does not send.
+type internetGatewayUpdatePayload struct {
+	gobizfly.UpdateInternetGatewayPayload
+	Tags []string `json:"tags"`
+}
+
 func createIGWBuilder(d *schema.ResourceData) gobizfly.CreateInternetGatewayPayload {
 	opts := gobizfly.CreateInternetGatewayPayload{}
 	if v, ok := d.GetOk("name"); ok {
@@ -40,29 +51,39 @@ func createIGWBuilder(d *schema.ResourceData) gobizfly.CreateInternetGatewayPayl
 	return opts
 }
 
-func resourceInternetGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceInternetGatewayCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	otps := createIGWBuilder(d)
-	createdIGW, err := client.CloudServer.InternetGateways().Create(context.Background(), otps)
+	createdIGW, err := client.CloudServer.InternetGateways().Create(ctx, otps)
 	if err != nil {
-		return fmt.Errorf("error creating internet gateway: %v", err)
+		return diag.Errorf("error creating internet gateway: %v", err)
 	}
 	d.SetId(createdIGW.ID)
-	return resourceInternetGatewayRead(d, meta)
+	if tags := expandTagsAll(d, meta); len(tags) > 0 {
+		if err := updateInternetGateway(ctx, client, d, tags); err != nil {
+			return diag.Errorf("error tagging internet gateway %s: %v", d.Id(), err)
+		}
+	}
+	return resourceInternetGatewayRead(ctx, d, meta)
 }
 
-func resourceInternetGatewayRead(d *schema.ResourceData, meta interface{}) error {
+func resourceInternetGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	igw, err := client.CloudServer.InternetGateways().Get(context.Background(), d.Id())
+	igw, err := client.CloudServer.InternetGateways().Get(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error retrieving internet gateway: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Internet gateway (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving internet gateway: %v", err)
 	}
 	_ = d.Set("name", igw.Name)
 	_ = d.Set("description", igw.Description)
 	_ = d.Set("status", igw.Status)
 	_ = d.Set("project_id", igw.ProjectID)
 	_ = d.Set("availability_zones", igw.AvailabilityZones)
-	_ = d.Set("tags", igw.Tags)
+	setTags(d, meta, igw.Tags)
 	_ = d.Set("created_at", igw.CreatedAt)
 	_ = d.Set("updated_at", igw.UpdatedAt)
 	if len(igw.InterfacesInfo) > 0 {
@@ -78,37 +99,46 @@ func resourceInternetGatewayRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceInternetGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceInternetGatewayUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	if d.HasChanges("name", "description", "vpc_network_id") {
-		opts := gobizfly.UpdateInternetGatewayPayload{}
-		if v, ok := d.GetOk("name"); ok {
-			opts.Name = v.(string)
-		}
-		if v, ok := d.GetOk("description"); ok {
-			desc := v.(string)
-			opts.Description = desc
+	if d.HasChanges("name", "description", "vpc_network_id", "tags", "tags_all") {
+		if err := updateInternetGateway(ctx, client, d, expandTagsAll(d, meta)); err != nil {
+			return diag.Errorf("error updating internet gateway: %v", err)
 		}
-		if v, ok := d.GetOk("vpc_network_id"); ok {
-			vpcNetworkID := v.(string)
-			if vpcNetworkID != "" {
-				netIDs := []string{vpcNetworkID}
-				opts.NetworkIDs = netIDs
-			}
-		}
-		_, err := client.CloudServer.InternetGateways().Update(context.Background(), d.Id(), opts)
-		if err != nil {
-			return fmt.Errorf("error updating internet gateway: %v", err)
+	}
+	return resourceInternetGatewayRead(ctx, d, meta)
+}
+
+// updateInternetGateway is client.CloudServer.InternetGateways().Update, also
+// sending the tags of the internet gateway.
+func updateInternetGateway(ctx context.Context, client *gobizfly.Client, d *schema.ResourceData, tags []string) error {
+	opts := gobizfly.UpdateInternetGatewayPayload{}
+	if v, ok := d.GetOk("name"); ok {
+		opts.Name = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		desc := v.(string)
+		opts.Description = desc
+	}
+	if v, ok := d.GetOk("vpc_network_id"); ok {
+		vpcNetworkID := v.(string)
+		if vpcNetworkID != "" {
+			netIDs := []string{vpcNetworkID}
+			opts.NetworkIDs = netIDs
 		}
 	}
-	return resourceInternetGatewayRead(d, meta)
+	payload := &internetGatewayUpdatePayload{
+		UpdateInternetGatewayPayload: opts,
+		Tags:                         tags,
+	}
+	return updateResourceTags(ctx, client, http.MethodPut, serverServiceName, "/internet-gateways/"+d.Id(), payload)
 }
 
-func resourceInternetGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceInternetGatewayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudServer.InternetGateways().Delete(context.Background(), d.Id())
+	err := client.CloudServer.InternetGateways().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting internet gateway: %v", err)
+		return diag.Errorf("error deleting internet gateway: %v", err)
 	}
 	return nil
 }
@@ -0,0 +1,52 @@
+package bizflycloud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBizflyCloudInternetGateway_FakeAPITags(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMetaWithDefaultTags(t, "team=platform")
+	r := resourceInternetGateway()
+
+	config := map[string]interface{}{
+		"name": "igw-tagged",
+		"tags": []interface{}{"app=web"},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "name", "igw-tagged")
+	testCheckTags(t, d, "tags", "app=web")
+	testCheckTags(t, d, "tags_all", "app=web", "team=platform")
+	testCheckNoDiff(t, r, d, config, meta)
+	gatewayTags := func() []string {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		return api.gateways[d.Id()].Tags
+	}
+	if got := gatewayTags(); !reflect.DeepEqual(got, []string{"app=web", "team=platform"}) {
+		t.Errorf("expected the internet gateway to be created with the default tags, got %v", got)
+	}
+
+	config["tags"] = []interface{}{"app=api"}
+	d = testResourceUpdate(t, r, d, config, meta)
+	if got := gatewayTags(); !reflect.DeepEqual(got, []string{"app=api", "team=platform"}) {
+		t.Errorf("expected the internet gateway tags to be updated, got %v", got)
+	}
+	testCheckResourceDataAttr(t, d, "name", "igw-tagged")
+	testCheckTags(t, d, "tags", "app=api")
+	testCheckNoDiff(t, r, d, config, meta)
+
+	// a change of the provider default tags alone updates the internet gateway
+	meta = api.providerMetaWithDefaultTags(t, "team=data")
+	d = testResourceUpdate(t, r, d, config, meta)
+	if got := gatewayTags(); !reflect.DeepEqual(got, []string{"app=api", "team=data"}) {
+		t.Errorf("expected the internet gateway default tags to be updated, got %v", got)
+	}
+	testCheckTags(t, d, "tags_all", "app=api", "team=data")
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckTags(t, imported, "tags", "app=api")
+	testCheckNoDiff(t, r, imported, config, meta)
+}
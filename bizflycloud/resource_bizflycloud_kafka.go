@@ -24,28 +24,32 @@ import (
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudKafka() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudKafkaCreate,
-		Read:   resourceBizflyCloudKafkaRead,
-		Update: resourceBizflyCloudKafkaUpdate,
-		Delete: resourceBizflyCloudKafkaDelete,
+		CreateContext: resourceBizflyCloudKafkaCreate,
+		ReadContext:   resourceBizflyCloudKafkaRead,
+		UpdateContext: resourceBizflyCloudKafkaUpdate,
+		DeleteContext: resourceBizflyCloudKafkaDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 		SchemaVersion: 1,
 		Schema:        resourceKafkaSchema(),
 	}
 }
 
-func resourceBizflyCloudKafkaCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudKafkaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	req := &gobizfly.KafkaInitClusterRequest{
@@ -59,34 +63,34 @@ func resourceBizflyCloudKafkaCreate(d *schema.ResourceData, meta interface{}) er
 		PublicAccess:     d.Get("public_access").(bool),
 	}
 
-	_, err := client.Kafka.Create(context.Background(), req)
+	_, err := client.Kafka.Create(ctx, req)
 	if err != nil {
-		return fmt.Errorf("error creating kafka cluster: %w", err)
+		return diag.Errorf("error creating kafka cluster: %v", err)
 	}
 
 	// Wait for cluster to be created and become Active
 	// The wait function will set the cluster ID when found
-	_, waitErr := waitForKafkaClusterProvision(d, meta)
+	waitErr := waitForKafkaClusterProvision(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
 	if waitErr != nil {
-		return fmt.Errorf("error waiting for kafka cluster creation: %w", waitErr)
+		return diag.Errorf("error waiting for kafka cluster creation: %v", waitErr)
 	}
 
-	return resourceBizflyCloudKafkaRead(d, meta)
+	return resourceBizflyCloudKafkaRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudKafkaRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudKafkaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	if d.Id() == "" {
 		return nil
 	}
 
-	cluster, err := client.Kafka.Get(context.Background(), d.Id())
+	cluster, err := client.Kafka.Get(ctx, d.Id())
 	if err != nil {
 		if errors.Is(err, gobizfly.ErrNotFound) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error retrieving kafka cluster %s: %w", d.Id(), err)
+		return diag.Errorf("error retrieving kafka cluster %s: %v", d.Id(), err)
 	}
 
 	_ = d.Set("name", cluster.Name)
@@ -105,11 +109,11 @@ func resourceBizflyCloudKafkaRead(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
-func resourceBizflyCloudKafkaUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudKafkaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Id()
 	if clusterID == "" {
-		return fmt.Errorf("missing kafka cluster id for update")
+		return diag.Errorf("missing kafka cluster id for update")
 	}
 
 	// Handle flavor change (resize by flavor)
@@ -119,9 +123,12 @@ func resourceBizflyCloudKafkaUpdate(d *schema.ResourceData, meta interface{}) er
 			Type:   "flavor",
 			Flavor: newVal.(string),
 		}
-		_, err := client.Kafka.Resize(context.Background(), clusterID, payload)
+		_, err := client.Kafka.Resize(ctx, clusterID, payload)
 		if err != nil {
-			return fmt.Errorf("error resizing kafka cluster flavor: %w", err)
+			return diag.Errorf("error resizing kafka cluster flavor: %v", err)
+		}
+		if err := waitForKafkaClusterProvision(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error waiting for kafka cluster resize: %v", err)
 		}
 	}
 
@@ -132,9 +139,12 @@ func resourceBizflyCloudKafkaUpdate(d *schema.ResourceData, meta interface{}) er
 			Type:       "volume",
 			VolumeSize: newVal.(int),
 		}
-		_, err := client.Kafka.Resize(context.Background(), clusterID, payload)
+		_, err := client.Kafka.Resize(ctx, clusterID, payload)
 		if err != nil {
-			return fmt.Errorf("error resizing kafka cluster volume: %w", err)
+			return diag.Errorf("error resizing kafka cluster volume: %v", err)
+		}
+		if err := waitForKafkaClusterProvision(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error waiting for kafka cluster resize: %v", err)
 		}
 	}
 
@@ -145,58 +155,82 @@ func resourceBizflyCloudKafkaUpdate(d *schema.ResourceData, meta interface{}) er
 		newNodes := newVal.(int)
 		if newNodes > oldNodes {
 			addReq := &gobizfly.KafkaAddNodeRequest{Nodes: newNodes - oldNodes, Type: "increase"}
-			_, err := client.Kafka.AddNode(context.Background(), clusterID, addReq)
+			_, err := client.Kafka.AddNode(ctx, clusterID, addReq)
 			if err != nil {
-				return fmt.Errorf("error adding node(s) to kafka cluster: %w", err)
+				return diag.Errorf("error adding node(s) to kafka cluster: %v", err)
+			}
+			if err := waitForKafkaClusterProvision(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.Errorf("error waiting for kafka cluster node(s) to be added: %v", err)
 			}
 		} else if newNodes < oldNodes {
-			return fmt.Errorf("decreasing kafka nodes is not supported via terraform update")
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "decreasing kafka nodes is not supported via terraform update",
+				AttributePath: cty.GetAttrPath("nodes"),
+			}}
 		}
 	}
 
-	// Wait for any updates to finish
-	_, waitErr := waitForKafkaClusterProvision(d, meta)
-	if waitErr != nil {
-		return fmt.Errorf("error waiting for kafka cluster update: %w", waitErr)
-	}
-
-	return resourceBizflyCloudKafkaRead(d, meta)
+	return resourceBizflyCloudKafkaRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudKafkaDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudKafkaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	if d.Id() == "" {
 		return nil
 	}
-	_, err := client.Kafka.Delete(context.Background(), d.Id())
+	_, err := client.Kafka.Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting kafka cluster %s: %w", d.Id(), err)
+		return diag.Errorf("error deleting kafka cluster %s: %v", d.Id(), err)
 	}
 	// Wait for deletion
-	err = waitForKafkaClusterDeleted(d, meta)
+	err = waitForKafkaClusterDeleted(ctx, d, meta)
 	if err != nil {
-		return fmt.Errorf("error waiting for kafka cluster deletion: %w", err)
+		return diag.Errorf("error waiting for kafka cluster deletion: %v", err)
 	}
 	return nil
 }
 
-func waitForKafkaClusterProvision(d *schema.ResourceData, meta interface{}) (interface{}, error) {
+func waitForKafkaClusterProvision(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	name := d.Get("name").(string)
+	refresh := kafkaStatusFunc(d, name, meta)
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("kafka cluster (%s) to be provisioned", name),
+		Pending:      []string{"Creating", "Pending", "Provisioning", "PENDING_PROVISION", "PROVISIONING", "Resizing", "RESIZING"},
+		Target:       []string{"Active", "Failed", "Error", "PROVISIONED", "ACTIVE"},
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 3 * time.Second,
+	}
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		cluster, status, err := refresh(ctx)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			// Not listed yet
+			return nil, "Pending", nil
+		}
+		return cluster, status, err
+	})
+	return err
+}
+
+func waitForKafkaClusterDeleted(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	name := d.Get("name").(string)
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Creating", "Pending", "Provisioning", "PENDING_PROVISION", "PROVISIONING", "Resizing", "RESIZING"},
-		Target:     []string{"Active", "Failed", "Error", "PROVISIONED", "ACTIVE"},
-		Refresh:    newKafkaStatusRefreshFunc(d, name, meta),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	return stateConf.WaitForState()
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("kafka cluster (%s) to be deleted", name),
+		Pending:      []string{"Destroying", "Deleting", "DESTROYING", "DELETING"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Delay:        5 * time.Second,
+		PollInterval: 3 * time.Second,
+	}
+	return conf.ForDeletion(ctx, kafkaStatusFunc(d, name, meta))
 }
 
-func newKafkaStatusRefreshFunc(d *schema.ResourceData, name string, meta interface{}) resource.StateRefreshFunc {
+// kafkaStatusFunc looks the cluster up by name, since its ID is not known
+// until it is listed, and fails with gobizfly.ErrNotFound when it is not.
+func kafkaStatusFunc(d *schema.ResourceData, name string, meta interface{}) waiter.StatusFunc {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
-		clusters, err := client.Kafka.List(context.Background(), &gobizfly.KafkaClusterListOptions{Name: name})
+	return func(ctx context.Context) (interface{}, string, error) {
+		clusters, err := client.Kafka.List(ctx, &gobizfly.KafkaClusterListOptions{Name: name})
 		if err != nil {
 			return nil, "", err
 		}
@@ -209,21 +243,6 @@ func newKafkaStatusRefreshFunc(d *schema.ResourceData, name string, meta interfa
 				return c, c.Status, nil
 			}
 		}
-		// Not found yet
-		return nil, "", nil
+		return nil, "", gobizfly.ErrNotFound
 	}
 }
-
-func waitForKafkaClusterDeleted(d *schema.ResourceData, meta interface{}) error {
-	name := d.Get("name").(string)
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Destroying", "Deleting", "DESTROYING", "DELETING"},
-		Target:     []string{},
-		Refresh:    newKafkaStatusRefreshFunc(d, name, meta),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	_, err := stateConf.WaitForState()
-	return err
-}
@@ -21,12 +21,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -41,9 +42,9 @@ func TestAccBizflyCloudKafka_Basic(t *testing.T) {
 	resourceName := "bizflycloud_kafka.foobar"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudKafkaDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudKafkaDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudKafkaBasicConfig(rInt),
@@ -71,9 +72,9 @@ func TestAccBizflyCloudKafka_Update(t *testing.T) {
 	resourceName := "bizflycloud_kafka.foobar"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudKafkaDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudKafkaDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudKafkaBasicConfig(rInt),
@@ -103,9 +104,9 @@ func TestAccBizflyCloudKafka_ResizeFlavor(t *testing.T) {
 	resourceName := "bizflycloud_kafka.foobar"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudKafkaDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudKafkaDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudKafkaBasicConfig(rInt),
@@ -131,9 +132,9 @@ func TestAccBizflyCloudKafka_ResizeVolume(t *testing.T) {
 	resourceName := "bizflycloud_kafka.foobar"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudKafkaDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudKafkaDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudKafkaBasicConfig(rInt),
@@ -159,9 +160,9 @@ func TestAccBizflyCloudKafka_AddNode(t *testing.T) {
 	resourceName := "bizflycloud_kafka.foobar"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudKafkaDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudKafkaDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudKafkaBasicConfig(rInt),
@@ -305,3 +306,72 @@ resource "bizflycloud_kafka" "foobar" {
 }
 `, rInt)
 }
+
+func TestBizflyCloudKafka_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudKafka()
+
+	config := map[string]interface{}{
+		"name":              "kafka-foo",
+		"version_id":        "3.7.0",
+		"nodes":             1,
+		"flavor":            "2c_4g",
+		"volume_size":       20,
+		"availability_zone": "HN1",
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "status", "Active")
+	testCheckResourceDataAttr(t, d, "nodes", 1)
+
+	config["nodes"] = 3
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "nodes", 3)
+	testCheckResourceDataAttr(t, d, "status", "Active")
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckResourceDataAttr(t, imported, "flavor", "2c_4g")
+
+	testResourceDelete(t, r, d, meta)
+	if _, err := meta.gobizflyClient().Kafka.Get(context.Background(), d.Id()); !errors.Is(err, gobizfly.ErrNotFound) {
+		t.Errorf("expected kafka cluster %s to be deleted, got: %v", d.Id(), err)
+	}
+}
+
+func TestBizflyCloudKafka_FakeAPIUpdateTimeout(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudKafka()
+
+	config := map[string]interface{}{
+		"name":              "kafka-slow",
+		"version_id":        "3.7.0",
+		"nodes":             1,
+		"flavor":            "2c_4g",
+		"volume_size":       20,
+		"availability_zone": "HN1",
+	}
+	d := testResourceCreate(t, r, config, meta)
+
+	// Keep the cluster resizing well past the configured update timeout.
+	api.mu.Lock()
+	api.pendingPolls = 1000
+	api.mu.Unlock()
+
+	config["nodes"] = 2
+	config["timeouts"] = map[string]interface{}{"update": "1m"}
+	state := d.State()
+	diff, err := r.Diff(testContext(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning update: %v", err)
+	}
+	_, diags := r.Apply(testContext(), state, diff, meta)
+	if !diags.HasError() {
+		t.Fatal("expected update to time out")
+	}
+	if err := diagnosticsError(diags); !strings.Contains(err.Error(), "timeout while waiting for kafka cluster (kafka-slow)") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -26,20 +26,25 @@ import (
 
 	"github.com/bizflycloud/gobizfly"
 	"github.com/bizflycloud/terraform-provider-bizflycloud/constants"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
 	"github.com/google/go-cmp/cmp"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const kubernetesServiceName = "kubernetes_engine"
+
 func resourceBizflyCloudKubernetes() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyClusterCreate,
-		Read:   resourceBizflyCloudClusterRead,
-		Delete: resourceBizflyCloudClusterDelete,
-		Update: resourceBizflyCloudClusterUpdate,
+		CreateContext: resourceBizflyClusterCreate,
+		ReadContext:   resourceBizflyCloudClusterRead,
+		DeleteContext: resourceBizflyCloudClusterDelete,
+		UpdateContext: resourceBizflyCloudClusterUpdate,
+		CustomizeDiff: customdiff.All(resourceTagsCustomizeDiff, resourceKubernetesWorkerPoolTagsCustomizeDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
@@ -70,6 +75,7 @@ func resourceBizflyCloudKubernetes() *schema.Resource {
 				Required: true,
 				Elem:     &schema.Resource{Schema: workerPoolSchema()},
 			},
+			"worker_pool_tags_all": tagsAllSchema(),
 			"worker_pools_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -79,6 +85,7 @@ func resourceBizflyCloudKubernetes() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"tags_all": tagsAllSchema(),
 			"vpc_network_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -123,6 +130,8 @@ func resourceBizflyCloudKubernetes() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
@@ -225,20 +234,17 @@ func taintsSchema() map[string]*schema.Schema {
 	}
 }
 
-func resourceBizflyClusterCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	// Build up creation options
-	tags := make([]string, 0)
-	for j := 0; j < len(d.Get("tags").([]interface{})); j++ {
-		tagPattern := fmt.Sprintf("tags.%d", j)
-		tags = append(tags, d.Get(tagPattern).(string))
-	}
+	tags := expandTagsAll(d, meta)
 
 	log.Println("[DEBUG] creating cluster")
 	workerPools := make([]gobizfly.WorkerPool, 0)
 	workerPool := readWorkerPoolFromConfig(d)
 	if workerPool != nil {
+		workerPool.Tags = meta.(*CombinedConfig).withDefaultTags(d.Get("worker_pool.0.tags").([]interface{}))
 		workerPools = append(workerPools, workerPool.WorkerPool)
 	}
 	ccrq := &gobizfly.ClusterCreateRequest{
@@ -253,33 +259,33 @@ func resourceBizflyClusterCreate(d *schema.ResourceData, meta interface{}) error
 		Tags:         tags,
 	}
 	log.Printf("[DEBUG] Create Cluster configuration: %#v\n", ccrq)
-	cluster, err := client.KubernetesEngine.Create(context.Background(), ccrq)
+	cluster, err := client.KubernetesEngine.Create(ctx, ccrq)
 	if err != nil {
-		return fmt.Errorf("error creating cluster: %v", err)
+		return diag.Errorf("error creating cluster: %v", err)
 	}
 	log.Println("[DEBUG] set id " + cluster.UID)
 	d.SetId(cluster.UID)
-	_, err = waitForClusterUpdate(d, meta)
+	err = waitForClusterUpdate(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] wait for create cluster %v error: %v", cluster.UID, err)
+		return diag.Errorf("[ERROR] wait for create cluster %v error: %v", cluster.UID, err)
 	}
-	return resourceBizflyCloudClusterRead(d, meta)
+	return resourceBizflyCloudClusterRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudClusterRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	log.Printf("[DEBUG] cluster ID %s", d.Id())
-	cluster, err := client.KubernetesEngine.Get(context.Background(), d.Id())
+	cluster, err := client.KubernetesEngine.Get(ctx, d.Id())
 	if err != nil {
 		if errors.Is(err, gobizfly.ErrNotFound) {
 			log.Printf("[WARN] Cluster %s is not found", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error retrieving cluster: %v", err)
+		return diag.Errorf("error retrieving cluster: %v", err)
 	}
 	clusterID := cluster.UID
-	upgradeVersion, err := client.KubernetesEngine.GetUpgradeClusterVersion(context.Background(), clusterID)
+	upgradeVersion, err := client.KubernetesEngine.GetUpgradeClusterVersion(ctx, clusterID)
 	if err != nil {
 		log.Printf("Error get upragde cluster %s version failed: %+v", clusterID, err)
 		upgradeVersion = &gobizfly.UpgradeClusterVersionResponse{}
@@ -290,16 +296,16 @@ func resourceBizflyCloudClusterRead(d *schema.ResourceData, meta interface{}) er
 	if defaultWorkerPool != nil && defaultWorkerPool.UID != "" {
 		defaultWorkerPoolID = defaultWorkerPool.UID
 	}
-	workerPool, err := client.KubernetesEngine.GetClusterWorkerPool(context.Background(), clusterID, defaultWorkerPoolID)
+	workerPool, err := client.KubernetesEngine.GetClusterWorkerPool(ctx, clusterID, defaultWorkerPoolID)
 	if err != nil {
-		return fmt.Errorf("[ERROR] GetClusterWorkerPool %v error: %v", defaultWorkerPoolID, err)
+		return diag.Errorf("[ERROR] GetClusterWorkerPool %v error: %v", defaultWorkerPoolID, err)
 	}
-	workerPoolsConfig := parseWorkerPools(workerPool)
+	workerPoolsConfig := parseDefaultWorkerPool(d, meta, workerPool)
 	log.Printf("[DEBUG] workerPoolsConfig %v", workerPoolsConfig)
 	// set config
 	err = d.Set("worker_pool", workerPoolsConfig)
 	if err != nil {
-		return fmt.Errorf("[ERROR] read cluster.worker_pool error %v", err)
+		return diag.Errorf("[ERROR] read cluster.worker_pool error %v", err)
 	}
 	_ = d.Set("name", cluster.Name)
 	_ = d.Set("version", cluster.Version.ID)
@@ -315,26 +321,30 @@ func resourceBizflyCloudClusterRead(d *schema.ResourceData, meta interface{}) er
 	_ = d.Set("current_version", cluster.Version.K8SVersion)
 	_ = d.Set("next_version", upgradeVersion.UpgradeTo)
 	_ = d.Set("enabled_upgrade_version", false)
-	_ = d.Set("tags", cluster.Tags)
+	setTags(d, meta, cluster.Tags)
+	_ = d.Set("worker_pool_tags_all", workerPool.Tags)
 	_ = d.Set("package_id", cluster.ClusterPackage.ID)
 	return nil
 }
 
-func resourceBizflyCloudClusterDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.KubernetesEngine.Delete(context.Background(), d.Id())
+	err := client.KubernetesEngine.Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting cluster: %v", err)
+		return diag.Errorf("error deleting cluster: %v", err)
+	}
+	if err := waitForClusterDelete(ctx, d, meta); err != nil {
+		return diag.Errorf("error deleting cluster: %v", err)
 	}
 	return nil
 }
 
-func resourceBizflyCloudClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Id()
-	_, err := client.KubernetesEngine.Get(context.Background(), clusterID)
+	_, err := client.KubernetesEngine.Get(ctx, clusterID)
 	if err != nil {
-		return fmt.Errorf("error updating cluster: %v", err)
+		return diag.Errorf("error updating cluster: %v", err)
 	}
 	if d.HasChange("auto_upgrade") {
 		_, new_auto_upgrade := d.GetChange("auto_upgrade")
@@ -343,9 +353,14 @@ func resourceBizflyCloudClusterUpdate(d *schema.ResourceData, meta interface{})
 			AutoUpgrade: &update_auto_upgrade,
 		}
 		log.Printf("[DEBUG] Update cluster payload: %+v", updateClusterPayload)
-		_, err = client.KubernetesEngine.UpdateCluster(context.Background(), clusterID, &updateClusterPayload)
+		_, err = client.KubernetesEngine.UpdateCluster(ctx, clusterID, &updateClusterPayload)
 		if err != nil {
-			return fmt.Errorf("error updating auto_upgrade: %+v", err)
+			return diag.Errorf("error updating auto_upgrade: %+v", err)
+		}
+	}
+	if d.HasChanges("tags", "tags_all") {
+		if err := patchResourceTags(ctx, client, kubernetesServiceName, "/_/"+clusterID, expandTagsAll(d, meta)); err != nil {
+			return diag.Errorf("error updating tags of cluster %s: %v", clusterID, err)
 		}
 	}
 	if d.HasChange("enabled_upgrade_version") {
@@ -353,18 +368,19 @@ func resourceBizflyCloudClusterUpdate(d *schema.ResourceData, meta interface{})
 			log.Printf("[DEBUG] Cluster version is latest.")
 		} else {
 			payload := gobizfly.UpgradeClusterVersionRequest{}
-			err := client.KubernetesEngine.UpgradeClusterVersion(context.Background(), clusterID, &payload)
+			err := client.KubernetesEngine.UpgradeClusterVersion(ctx, clusterID, &payload)
 			if err != nil {
-				return fmt.Errorf("error upgrading cluster version: %+v", err)
+				return diag.Errorf("error upgrading cluster version: %+v", err)
 			}
 		}
 	}
+	renewedPool := false
 	if d.HasChange("worker_pool") {
 		newWorkerPool := readWorkerPoolFromConfig(d)
 		poolID := newWorkerPool.UID
-		oldWorkerPool, err := client.KubernetesEngine.GetClusterWorkerPool(context.Background(), clusterID, poolID)
+		oldWorkerPool, err := client.KubernetesEngine.GetClusterWorkerPool(ctx, clusterID, poolID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] GetClusterWorkerPool pool %v error: %v", poolID, clusterID)
+			return diag.Errorf("[ERROR] GetClusterWorkerPool pool %v error: %v", poolID, clusterID)
 		}
 		isUpdateName := newWorkerPool.Name != oldWorkerPool.Name
 		isUpdateFlavor := newWorkerPool.Flavor != oldWorkerPool.Flavor
@@ -377,39 +393,40 @@ func resourceBizflyCloudClusterUpdate(d *schema.ResourceData, meta interface{})
 		isRenew := isUpdateName || isUpdateFlavor || isUpdateBillingPlan || isUpdateAvailabilityZone ||
 			isUpdateNetworkPlan || isUpdateProfileType || isUpdateVolumeSize || isUpdateVolumeType
 		if isRenew {
+			renewedPool = true
 			// Check create new default pool.
+			newWorkerPool.Tags = meta.(*CombinedConfig).withDefaultTags(d.Get("worker_pool.0.tags").([]interface{}))
 			addPoolReq := &gobizfly.AddWorkerPoolsRequest{
 				WorkerPools: []gobizfly.WorkerPool{
 					newWorkerPool.WorkerPool,
 				},
 			}
 			// Add new default pool
-			addedPool, err := client.KubernetesEngine.AddWorkerPools(context.Background(), clusterID, addPoolReq)
+			addedPool, err := client.KubernetesEngine.AddWorkerPools(ctx, clusterID, addPoolReq)
 			if err != nil {
-				return fmt.Errorf("[ERROR] add new worker pool for cluster %v error: %v", clusterID, err)
+				return diag.Errorf("[ERROR] add new worker pool for cluster %v error: %v", clusterID, err)
 			}
 			// Delete old default pool
-			err = client.KubernetesEngine.DeleteClusterWorkerPool(context.Background(), clusterID, poolID)
+			err = client.KubernetesEngine.DeleteClusterWorkerPool(ctx, clusterID, poolID)
 			if err != nil {
 				if errors.Is(err, gobizfly.ErrNotFound) {
 					log.Printf("[INFO] Worker pool %s is deleted", poolID)
 				} else {
-					return fmt.Errorf("[ERROR] remove old worker pool out of cluster %v error: %v", clusterID, err)
+					return diag.Errorf("[ERROR] remove old worker pool out of cluster %v error: %v", clusterID, err)
 				}
 			}
 			newPoolID := addedPool[0].UID
 			// Wait for new pool
-			_, err = waitForPoolUpdate(d, newPoolID, meta)
+			err = waitForPoolUpdate(ctx, d, newPoolID, meta, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
-				return fmt.Errorf("error waiting for new pool %v update: %+v", newPoolID, err)
+				return diag.Errorf("error waiting for new pool %v update: %+v", newPoolID, err)
 			}
 			// Update new pool into state
-			newPool, err := client.KubernetesEngine.GetClusterWorkerPool(context.Background(), clusterID, newPoolID)
+			newPool, err := client.KubernetesEngine.GetClusterWorkerPool(ctx, clusterID, newPoolID)
 			if err != nil {
-				return fmt.Errorf("[ERROR] Get new default pool %v error: %v", newPoolID, err)
+				return diag.Errorf("[ERROR] Get new default pool %v error: %v", newPoolID, err)
 			}
-			newPoolConfig := parseWorkerPools(newPool)
-			_ = d.Set("worker_pool", newPoolConfig)
+			_ = d.Set("worker_pool", parseDefaultWorkerPool(d, meta, newPool))
 		} else {
 			// Check that the pool has any change
 			isUpdateLabels := !cmp.Equal(newWorkerPool.Labels, oldWorkerPool.Labels)
@@ -428,24 +445,32 @@ func resourceBizflyCloudClusterUpdate(d *schema.ResourceData, meta interface{})
 					Taints:      newWorkerPool.Taints,
 				}
 				log.Printf("[DEBUG] update pool %+v to %+v", poolID, updateRequest)
-				err := client.KubernetesEngine.UpdateClusterWorkerPool(context.Background(), clusterID,
+				err := client.KubernetesEngine.UpdateClusterWorkerPool(ctx, clusterID,
 					poolID, updateRequest)
 				if err != nil {
-					return fmt.Errorf("error update pool: %+v", err)
+					return diag.Errorf("error update pool: %+v", err)
 				}
-				_, err = waitForPoolUpdate(d, poolID, meta)
+				err = waitForPoolUpdate(ctx, d, poolID, meta, d.Timeout(schema.TimeoutUpdate))
 				if err != nil {
-					return fmt.Errorf("error waiting for pool update: %+v", err)
+					return diag.Errorf("error waiting for pool update: %+v", err)
 				}
 			}
 		}
 	}
+	// the tags of a new default pool are set when it is added
+	if !renewedPool && d.HasChanges("worker_pool.0.tags", "worker_pool_tags_all") {
+		poolID := d.Get("worker_pool.0.id").(string)
+		tags := meta.(*CombinedConfig).withDefaultTags(d.Get("worker_pool.0.tags").([]interface{}))
+		if err := patchResourceTags(ctx, client, kubernetesServiceName, "/_/"+clusterID+"/"+poolID, tags); err != nil {
+			return diag.Errorf("error updating tags of worker pool %s: %v", poolID, err)
+		}
+	}
 	// wait for update cluster
-	_, err = waitForClusterUpdate(d, meta)
+	err = waitForClusterUpdate(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] wait for update cluster %v error: %v", clusterID, err)
+		return diag.Errorf("[ERROR] wait for update cluster %v error: %v", clusterID, err)
 	}
-	return resourceBizflyCloudClusterRead(d, meta)
+	return resourceBizflyCloudClusterRead(ctx, d, meta)
 }
 
 func readWorkerPoolFromConfig(l *schema.ResourceData) *gobizfly.ExtendedWorkerPool {
@@ -455,11 +480,7 @@ func readWorkerPoolFromConfig(l *schema.ResourceData) *gobizfly.ExtendedWorkerPo
 			break
 		}
 		pattern := fmt.Sprintf("worker_pool.%d.", i)
-		tags := make([]string, 0)
-		for j := 0; j < len(l.Get("tags").([]interface{})); j++ {
-			tagPattern := pattern + fmt.Sprintf("tags.%d", j)
-			tags = append(tags, l.Get(tagPattern).(string))
-		}
+		tags := readStringArray(l.Get(pattern + "tags").([]interface{}))
 		labels := readLabelsConfig(l, pattern)
 		taints := readTaintsConfig(l, pattern)
 		pool := &gobizfly.ExtendedWorkerPool{
@@ -522,28 +543,24 @@ func readLabelsConfig(l *schema.ResourceData, pattern string) map[string]string
 	return labels
 }
 
-func waitForPoolUpdate(d *schema.ResourceData, poolID string, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for pool updating %s", poolID)
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"PENDING_PROVISION", "PROVISIONING", "PENDING_UPDATE", "UPDATING"},
-		Target:     []string{"PROVISIONED"},
-		Refresh:    newPoolStatusRefreshFunc(d, poolID, meta),
-		Timeout:    1200 * time.Second,
-		Delay:      20 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	return stateConf.WaitForState()
-}
-
-func newPoolStatusRefreshFunc(d *schema.ResourceData, poolID string, meta interface{}) resource.StateRefreshFunc {
+func waitForPoolUpdate(ctx context.Context, d *schema.ResourceData, poolID string, meta interface{}, timeout time.Duration) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
-		pool, err := client.KubernetesEngine.GetClusterWorkerPool(context.Background(), d.Id(), poolID)
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("pool %s to be updated", poolID),
+		Pending:      []string{"PENDING_PROVISION", "PROVISIONING", "PENDING_UPDATE", "UPDATING"},
+		Target:       []string{"PROVISIONED"},
+		Timeout:      timeout,
+		Delay:        20 * time.Second,
+		PollInterval: 3 * time.Second,
+	}
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		pool, err := client.KubernetesEngine.GetClusterWorkerPool(ctx, d.Id(), poolID)
 		if err != nil {
 			return nil, "", err
 		}
 		return pool, pool.ProvisionStatus, nil
-	}
+	})
+	return err
 }
 
 func parseWorkerPoolTaints(taints []gobizfly.Taint) []map[string]interface{} {
@@ -559,6 +576,23 @@ func parseWorkerPoolTaints(taints []gobizfly.Taint) []map[string]interface{} {
 	return results
 }
 
+// parseDefaultWorkerPool is parseWorkerPools for the worker pool embedded in
+// the cluster, leaving out of its tags those which are only there as provider
+// default tags.
+func parseDefaultWorkerPool(d *schema.ResourceData, meta interface{}, workerPool *gobizfly.WorkerPoolWithNodes) []map[string]interface{} {
+	config := parseWorkerPools(workerPool)
+	if len(config) > 0 {
+		config[0]["tags"] = meta.(*CombinedConfig).withoutDefaultTags(d.Get("worker_pool.0.tags").([]interface{}), workerPool.Tags)
+	}
+	return config
+}
+
+// resourceKubernetesWorkerPoolTagsCustomizeDiff plans worker_pool_tags_all as
+// the tags of the embedded worker pool merged with the provider default tags.
+func resourceKubernetesWorkerPoolTagsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return planTagsAll(d, meta, "worker_pool.0.tags", "worker_pool_tags_all")
+}
+
 func parseWorkerPools(workerPool *gobizfly.WorkerPoolWithNodes) []map[string]interface{} {
 	results := make([]map[string]interface{}, 0)
 	if workerPool == nil {
@@ -587,27 +621,44 @@ func parseWorkerPools(workerPool *gobizfly.WorkerPoolWithNodes) []map[string]int
 	return results
 }
 
-func waitForClusterUpdate(d *schema.ResourceData, meta interface{}) (interface{}, error) {
+func waitForClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Id()
-	log.Printf("[INFO] Waiting for cluster updating %s", clusterID)
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"PENDING_PROVISION", "PROVISIONING", "PENDING_UPDATE", "UPDATING"},
-		Target:     []string{"PROVISIONED", "PROVISION_ERROR", "UPDATE_ERROR", "UPGRADE_ERROR", "DESTROY_ERROR"},
-		Refresh:    newClusterStatusRefreshFunc(d, meta),
-		Timeout:    1200 * time.Second,
-		Delay:      20 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	return stateConf.WaitForState()
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cluster %s to be updated", clusterID),
+		Pending:      []string{"PENDING_PROVISION", "PROVISIONING", "PENDING_UPDATE", "UPDATING"},
+		Target:       []string{"PROVISIONED", "PROVISION_ERROR", "UPDATE_ERROR", "UPGRADE_ERROR", "DESTROY_ERROR"},
+		Timeout:      timeout,
+		Delay:        20 * time.Second,
+		PollInterval: 3 * time.Second,
+	}
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		cluster, err := client.KubernetesEngine.Get(ctx, clusterID)
+		if err != nil {
+			return nil, "", err
+		}
+		return cluster, cluster.ProvisionStatus, nil
+	})
+	return err
 }
 
-func newClusterStatusRefreshFunc(d *schema.ResourceData, meta interface{}) resource.StateRefreshFunc {
+func waitForClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
-		cluster, err := client.KubernetesEngine.Get(context.Background(), d.Id())
+	clusterID := d.Id()
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("cluster %s to be deleted", clusterID),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Delay:        10 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+	return conf.ForDeletion(ctx, func(ctx context.Context) (interface{}, string, error) {
+		cluster, err := client.KubernetesEngine.Get(ctx, clusterID)
 		if err != nil {
 			return nil, "", err
 		}
+		if cluster.ProvisionStatus == "DESTROY_ERROR" {
+			return cluster, "", fmt.Errorf("cluster %s failed to be deleted", clusterID)
+		}
 		return cluster, cluster.ProvisionStatus, nil
-	}
+	})
 }
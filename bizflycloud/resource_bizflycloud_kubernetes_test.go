@@ -22,9 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"reflect"
 	"testing"
 )
 
@@ -38,9 +40,9 @@ func TestAccBizflyCloudCluster(t *testing.T) {
 	var cluster gobizfly.FullCluster
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudClusterDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudClusterDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudClusterConfig(rInt),
@@ -132,3 +134,113 @@ resource "bizflycloud_kubernetes" "xyz" {
 ]
 `, rInt)
 }
+
+func TestBizflyCloudCluster_FakeAPIWorkerPoolTags(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMetaWithDefaultTags(t, "team=platform")
+	r := resourceBizflyCloudKubernetes()
+
+	pool := map[string]interface{}{
+		"name":              "pool-1",
+		"flavor":            "8c_8g",
+		"profile_type":      "premium",
+		"volume_type":       "SSD",
+		"volume_size":       40,
+		"availability_zone": "HN1",
+		"desired_size":      1,
+		"tags":              []interface{}{"app=web"},
+	}
+	config := map[string]interface{}{
+		"name":           "cluster-1",
+		"version":        "5f6425f3d0d3befd40e7a31f",
+		"package_id":     "package-1",
+		"vpc_network_id": "vpc-1",
+		"worker_pool":    []interface{}{pool},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "worker_pool.0.tags.#", 1)
+	testCheckTags(t, d, "worker_pool_tags_all", "app=web", "team=platform")
+	testCheckNoDiff(t, r, d, config, meta)
+	poolTags := func() []string {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		return api.clusters[d.Id()].WorkerPools[0].Tags
+	}
+	if got := poolTags(); !reflect.DeepEqual(got, []string{"app=web", "team=platform"}) {
+		t.Errorf("expected the pool to be created with the default tags, got %v", got)
+	}
+
+	pool["tags"] = []interface{}{"app=api"}
+	d = testResourceUpdate(t, r, d, config, meta)
+	if got := poolTags(); !reflect.DeepEqual(got, []string{"app=api", "team=platform"}) {
+		t.Errorf("expected the pool tags to be updated, got %v", got)
+	}
+	testCheckResourceDataAttr(t, d, "worker_pool.0.tags.0", "app=api")
+	testCheckNoDiff(t, r, d, config, meta)
+
+	// a change of the provider default tags alone updates the pool
+	meta = api.providerMetaWithDefaultTags(t, "team=data")
+	d = testResourceUpdate(t, r, d, config, meta)
+	if got := poolTags(); !reflect.DeepEqual(got, []string{"app=api", "team=data"}) {
+		t.Errorf("expected the pool default tags to be updated, got %v", got)
+	}
+	testCheckTags(t, d, "worker_pool_tags_all", "app=api", "team=data")
+	testCheckNoDiff(t, r, d, config, meta)
+}
+
+func TestBizflyCloudCluster_FakeAPIDeleteWaits(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudKubernetes()
+
+	config := map[string]interface{}{
+		"name":           "cluster-1",
+		"version":        "5f6425f3d0d3befd40e7a31f",
+		"package_id":     "package-1",
+		"vpc_network_id": "vpc-1",
+		"worker_pool": []interface{}{
+			map[string]interface{}{
+				"name":              "pool-1",
+				"flavor":            "8c_8g",
+				"profile_type":      "premium",
+				"volume_type":       "SSD",
+				"volume_size":       40,
+				"availability_zone": "HN1",
+				"desired_size":      1,
+			},
+		},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	id := d.Id()
+	testResourceDelete(t, r, d, meta)
+	api.mu.Lock()
+	_, ok := api.clusters[id]
+	api.mu.Unlock()
+	if ok {
+		t.Errorf("expected the delete to wait until the cluster %s is gone", id)
+	}
+	testCheckResourceGone(t, r, d, meta)
+}
+
+func TestBizflyCloudKubernetesWorkerPool_FakeAPIDeleteWaits(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	cluster := &gobizfly.FullCluster{}
+	cluster.UID = "cluster-1"
+	cluster.WorkerPools = []gobizfly.ExtendedWorkerPool{{UID: "pool-1", ProvisionStatus: "PROVISIONED"}}
+	api.clusters[cluster.UID] = cluster
+
+	r := resourceBizflyCloudKubernetesWorkerPool()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"cluster_id": cluster.UID})
+	d.SetId("pool-1")
+	testResourceDelete(t, r, d, meta)
+	if got := len(cluster.WorkerPools); got != 0 {
+		t.Errorf("expected the worker pool to be deleted, %d pools left", got)
+	}
+	if got := api.requestCount("GET", "/kubernetes_engine/_/cluster-1/pool-1"); got == 0 {
+		t.Error("expected the delete to wait until the worker pool is gone")
+	}
+}
@@ -9,20 +9,22 @@ import (
 
 	"github.com/bizflycloud/gobizfly"
 	"github.com/bizflycloud/terraform-provider-bizflycloud/constants"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
 	"github.com/google/go-cmp/cmp"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBizflyCloudKubernetesWorkerPool() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudKubernetesWorkerPoolCreate,
-		Read:   resourceBizflyCloudKubernetesWorkerPoolRead,
-		Delete: resourceBizflyCloudKubernetesWorkerPoolDelete,
-		Update: resourceBizflyCloudKubernetesWorkerPoolUpdate,
+		CreateContext: resourceBizflyCloudKubernetesWorkerPoolCreate,
+		ReadContext:   resourceBizflyCloudKubernetesWorkerPoolRead,
+		DeleteContext: resourceBizflyCloudKubernetesWorkerPoolDelete,
+		UpdateContext: resourceBizflyCloudKubernetesWorkerPoolUpdate,
+		CustomizeDiff: resourceTagsCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
@@ -75,6 +77,7 @@ func resourceBizflyCloudKubernetesWorkerPool() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"tags_all": tagsAllSchema(),
 			"labels": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -108,11 +111,13 @@ func resourceBizflyCloudKubernetesWorkerPool() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
-func resourceBizflyCloudKubernetesWorkerPoolCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudKubernetesWorkerPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	clusterID := d.Get("cluster_id").(string)
 	workerPool := parseWorkerPoolFromConfig(d, meta)
@@ -122,31 +127,31 @@ func resourceBizflyCloudKubernetesWorkerPoolCreate(d *schema.ResourceData, meta
 		},
 	}
 	log.Printf("[DEBUG] add worker pool payload: %+v", payload)
-	addedWorkerPools, err := client.KubernetesEngine.AddWorkerPools(context.Background(), clusterID, &payload)
+	addedWorkerPools, err := client.KubernetesEngine.AddWorkerPools(ctx, clusterID, &payload)
 	if err != nil {
-		return fmt.Errorf("[ERROR] add worker pools for cluster %v error: %v", clusterID, err)
+		return diag.Errorf("[ERROR] add worker pools for cluster %v error: %v", clusterID, err)
 	}
 	poolID := addedWorkerPools[0].UID
-	_, err = waitForWorkerPoolChange(d, poolID, meta)
+	err = waitForWorkerPoolChange(ctx, poolID, meta, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("[ERROR] Wait for worker pool create error: %v", err)
+		return diag.Errorf("[ERROR] Wait for worker pool create error: %v", err)
 	}
 	d.SetId(poolID)
-	return resourceBizflyCloudKubernetesWorkerPoolRead(d, meta)
+	return resourceBizflyCloudKubernetesWorkerPoolRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudKubernetesWorkerPoolRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudKubernetesWorkerPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	workerPoolID := d.Id()
 	log.Printf("[DEBUG] worker pool ID %s", workerPoolID)
-	workerPool, err := client.KubernetesEngine.GetDetailWorkerPool(context.Background(), workerPoolID)
+	workerPool, err := client.KubernetesEngine.GetDetailWorkerPool(ctx, workerPoolID)
 	if err != nil {
 		if errors.Is(err, gobizfly.ErrNotFound) {
 			log.Printf("[WARN] Worker pool %s is not found", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("[ERROR] Get worker pool %v error: %v", workerPoolID, err)
+		return diag.Errorf("[ERROR] Get worker pool %v error: %v", workerPoolID, err)
 	}
 	_ = d.Set("id", workerPool.UID)
 	_ = d.Set("cluster_id", workerPool.ShootID)
@@ -160,7 +165,7 @@ func resourceBizflyCloudKubernetesWorkerPoolRead(d *schema.ResourceData, meta in
 	_ = d.Set("enable_autoscaling", workerPool.EnableAutoScaling)
 	_ = d.Set("min_size", workerPool.MinSize)
 	_ = d.Set("max_size", workerPool.MaxSize)
-	_ = d.Set("tags", workerPool.Tags)
+	setTags(d, meta, workerPool.Tags)
 	_ = d.Set("labels", workerPool.Labels)
 	_ = d.Set("taints", parseWorkerPoolTaints(workerPool.Taints))
 	_ = d.Set("network_plan", workerPool.NetworkPlan)
@@ -169,21 +174,21 @@ func resourceBizflyCloudKubernetesWorkerPoolRead(d *schema.ResourceData, meta in
 	return nil
 }
 
-func resourceBizflyCloudKubernetesWorkerPoolUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudKubernetesWorkerPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	changedKeys := []string{"labels", "taints", "min_size", "max_size", "desired_size"}
 	if d.HasChanges(changedKeys...) {
 		clusterID := d.Get("cluster_id").(string)
 		poolID := d.Id()
 		newWorkerPool := parseWorkerPoolFromConfig(d, meta)
-		oldWorkerPool, err := client.KubernetesEngine.GetClusterWorkerPool(context.Background(), clusterID, poolID)
+		oldWorkerPool, err := client.KubernetesEngine.GetClusterWorkerPool(ctx, clusterID, poolID)
 		if err != nil {
 			if errors.Is(err, gobizfly.ErrNotFound) {
 				log.Printf("[WARN] Worker pool %s of cluster %v is not found", d.Id(), clusterID)
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("[ERROR] Get worker pool %v of cluster %v error: %v", poolID, clusterID, err)
+			return diag.Errorf("[ERROR] Get worker pool %v of cluster %v error: %v", poolID, clusterID, err)
 		}
 		// Check that the pool has any change
 		isUpdateLabels := !cmp.Equal(newWorkerPool.Labels, oldWorkerPool.Labels)
@@ -202,40 +207,46 @@ func resourceBizflyCloudKubernetesWorkerPoolUpdate(d *schema.ResourceData, meta
 				Taints:      newWorkerPool.Taints,
 			}
 			log.Printf("[DEBUG] update pool %+v to %+v", poolID, updateRequest)
-			err := client.KubernetesEngine.UpdateClusterWorkerPool(context.Background(), clusterID,
+			err := client.KubernetesEngine.UpdateClusterWorkerPool(ctx, clusterID,
 				poolID, updateRequest)
 			if err != nil {
-				return fmt.Errorf("error update pool: %+v", err)
+				return diag.Errorf("error update pool: %+v", err)
 			}
-			_, err = waitForWorkerPoolChange(d, poolID, meta)
+			err = waitForWorkerPoolChange(ctx, poolID, meta, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
-				return fmt.Errorf("[ERROR] Wait for worker pool update error: %v", err)
+				return diag.Errorf("[ERROR] Wait for worker pool update error: %v", err)
 			}
 		}
 	}
-	return resourceBizflyCloudKubernetesWorkerPoolRead(d, meta)
+	if d.HasChanges("tags", "tags_all") {
+		path := "/_/" + d.Get("cluster_id").(string) + "/" + d.Id()
+		if err := patchResourceTags(ctx, client, kubernetesServiceName, path, expandTagsAll(d, meta)); err != nil {
+			return diag.Errorf("error updating tags of worker pool %s: %v", d.Id(), err)
+		}
+	}
+	return resourceBizflyCloudKubernetesWorkerPoolRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudKubernetesWorkerPoolDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudKubernetesWorkerPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	workerPoolID := d.Id()
 	clusterID := d.Get("cluster_id").(string)
 	client := meta.(*CombinedConfig).gobizflyClient()
-	if err := client.KubernetesEngine.DeleteClusterWorkerPool(context.Background(), clusterID, workerPoolID); err != nil {
+	if err := client.KubernetesEngine.DeleteClusterWorkerPool(ctx, clusterID, workerPoolID); err != nil {
 		if errors.Is(err, gobizfly.ErrNotFound) {
 			log.Printf("[WARN] Worker pool %s is not found", workerPoolID)
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("[ERROR] Delete worker pool %v of cluster %v error: %v", workerPoolID, clusterID, err)
+		return diag.Errorf("[ERROR] Delete worker pool %v of cluster %v error: %v", workerPoolID, clusterID, err)
+	}
+	if err := waitForWorkerPoolDelete(ctx, d, meta); err != nil {
+		return diag.Errorf("[ERROR] Delete worker pool %v of cluster %v error: %v", workerPoolID, clusterID, err)
 	}
 	return nil
 }
 
 func parseWorkerPoolFromConfig(d *schema.ResourceData, meta interface{}) gobizfly.ExtendedWorkerPool {
-	tags := make([]string, 0)
-	for _, tag := range d.Get("tags").([]interface{}) {
-		tags = append(tags, tag.(string))
-	}
+	tags := expandTagsAll(d, meta)
 	labels := readLabelsConfig(d, "")
 	taints := readTaintsConfig(d, "")
 	pool := gobizfly.ExtendedWorkerPool{
@@ -261,26 +272,41 @@ func parseWorkerPoolFromConfig(d *schema.ResourceData, meta interface{}) gobizfl
 	return pool
 }
 
-func waitForWorkerPoolChange(d *schema.ResourceData, poolID string, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for pool updating %s", poolID)
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"PENDING_PROVISION", "PROVISIONING", "PENDING_UPDATE", "UPDATING"},
-		Target:     []string{"PROVISIONED"},
-		Refresh:    workerPoolStatusRefreshFunc(d, poolID, meta),
-		Timeout:    1200 * time.Second,
-		Delay:      20 * time.Second,
-		MinTimeout: 3 * time.Second,
+func waitForWorkerPoolChange(ctx context.Context, poolID string, meta interface{}, timeout time.Duration) error {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("pool %s to be updated", poolID),
+		Pending:      []string{"PENDING_PROVISION", "PROVISIONING", "PENDING_UPDATE", "UPDATING"},
+		Target:       []string{"PROVISIONED"},
+		Timeout:      timeout,
+		Delay:        20 * time.Second,
+		PollInterval: 3 * time.Second,
 	}
-	return stateConf.WaitForState()
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		pool, err := client.KubernetesEngine.GetDetailWorkerPool(ctx, poolID)
+		if err != nil {
+			return nil, "", err
+		}
+		return pool, pool.ProvisionStatus, nil
+	})
+	return err
 }
 
-func workerPoolStatusRefreshFunc(d *schema.ResourceData, poolID string, meta interface{}) resource.StateRefreshFunc {
+func waitForWorkerPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
-		pool, err := client.KubernetesEngine.GetDetailWorkerPool(context.Background(), poolID)
+	poolID := d.Id()
+	clusterID := d.Get("cluster_id").(string)
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("pool %s to be deleted", poolID),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Delay:        10 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+	return conf.ForDeletion(ctx, func(ctx context.Context) (interface{}, string, error) {
+		pool, err := client.KubernetesEngine.GetClusterWorkerPool(ctx, clusterID, poolID)
 		if err != nil {
 			return nil, "", err
 		}
 		return pool, pool.ProvisionStatus, nil
-	}
+	})
 }
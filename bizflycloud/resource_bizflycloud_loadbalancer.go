@@ -2,21 +2,23 @@ package bizflycloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
 	"github.com/bizflycloud/terraform-provider-bizflycloud/constants"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	loadbalancerActiveInitDelay = 1 * time.Second
-	loadbalancerActiveFactor    = 1.2
-	loadbalancerActiveSteps     = 19
+	loadbalancerActiveInitDelay    = 1 * time.Second
+	loadbalancerActivePollInterval = 3 * time.Second
 
 	activeStatus = "ACTIVE"
 	errorStatus  = "ERROR"
@@ -50,12 +52,12 @@ func getLoadBalancerMutex(lbID string) *sync.Mutex {
 func resourceBizflyCloudLoadBalancer() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudLoadBalancerCreate,
-		Read:   resourceBizflyCloudLoadBalancerRead,
-		Update: resourceBizflyCloudLoadBalancerUpdate,
-		Delete: resourceBizflyCloudLoadBalancerDelete,
+		CreateContext: resourceBizflyCloudLoadBalancerCreate,
+		ReadContext:   resourceBizflyCloudLoadBalancerRead,
+		UpdateContext: resourceBizflyCloudLoadBalancerUpdate,
+		DeleteContext: resourceBizflyCloudLoadBalancerDelete,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -108,11 +110,13 @@ func resourceBizflyCloudLoadBalancer() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
-func resourceBizflyCloudLoadBalancerCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	lbcr := gobizfly.LoadBalancerCreateRequest{
 		Name:         d.Get("name").(string),
@@ -121,19 +125,24 @@ func resourceBizflyCloudLoadBalancerCreate(d *schema.ResourceData, meta interfac
 		Description:  d.Get("description").(string),
 		VPCNetworkID: d.Get("vpc_network_id").(string),
 	}
-	lb, err := client.CloudLoadBalancer.Create(context.Background(), &lbcr)
+	lb, err := client.CloudLoadBalancer.Create(ctx, &lbcr)
 	if err != nil {
-		return fmt.Errorf("error creating load balancer: %v", err)
+		return diag.Errorf("error creating load balancer: %v", err)
 	}
 	d.SetId(lb.ID)
-	return resourceBizflyCloudLoadBalancerRead(d, meta)
+	return resourceBizflyCloudLoadBalancerRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudLoadBalancerRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	lb, err := waitLoadbalancerActiveProvisioningStatus(client, d.Id(), loadbalancerResource)
+	lb, err := waitLoadbalancerActiveProvisioningStatus(ctx, client, d.Id(), loadbalancerResource, d.Timeout(schema.TimeoutRead))
 	if err != nil {
-		return fmt.Errorf("error retrieving load balancer: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Load balancer (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving load balancer: %v", err)
 	}
 	_ = d.Set("name", lb.Name)
 	_ = d.Set("description", lb.Description)
@@ -150,93 +159,112 @@ func resourceBizflyCloudLoadBalancerRead(d *schema.ResourceData, meta interface{
 	}
 
 	if err := d.Set("pools", pools); err != nil {
-		return fmt.Errorf("error setting pools: %v", err)
+		return diag.Errorf("error setting pools: %v", err)
 	}
 	listeners := schema.NewSet(schema.HashString, []interface{}{})
 	for _, v := range lb.Listeners {
 		listeners.Add(v.ID)
 	}
 	if err := d.Set("listeners", listeners); err != nil {
-		return fmt.Errorf("error setting listeners: %v", err)
+		return diag.Errorf("error setting listeners: %v", err)
 	}
 	return nil
 }
 
-func resourceBizflyCloudLoadBalancerUpdate(d *schema.ResourceData, meta interface{}) error {
-	return resourceBizflyCloudLoadBalancerRead(d, meta)
+func resourceBizflyCloudLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceBizflyCloudLoadBalancerRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudLoadBalancerDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	lb, err := waitLoadbalancerActiveProvisioningStatus(client, d.Id(), loadbalancerResource)
+	lb, err := waitLoadbalancerActiveProvisioningStatus(ctx, client, d.Id(), loadbalancerResource, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return fmt.Errorf("error retrieving load balancer: %v", err)
+		return diag.Errorf("error retrieving load balancer: %v", err)
 	}
 	ldr := gobizfly.LoadBalancerDeleteRequest{
 		ID:      lb.ID,
 		Cascade: true,
 	}
-	_ = client.CloudLoadBalancer.Delete(context.Background(), &ldr)
+	if err := client.CloudLoadBalancer.Delete(ctx, &ldr); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "error deleting load balancer",
+			Detail:   fmt.Sprintf("deleting load balancer %s failed, it may have to be removed manually: %v", lb.ID, err),
+		}}
+	}
+	if err := waitForLoadBalancerDeleted(ctx, client, lb.ID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error waiting for load balancer %s to be deleted: %v", lb.ID, err)
+	}
 	return nil
 }
 
-func waitLoadbalancerActiveProvisioningStatus(client *gobizfly.Client, ID string, resourceType string) (*gobizfly.LoadBalancer, error) {
-	backoff := wait.Backoff{
-		Duration: loadbalancerActiveInitDelay,
-		Factor:   loadbalancerActiveFactor,
-		Steps:    loadbalancerActiveSteps,
+func waitForLoadBalancerDeleted(ctx context.Context, client *gobizfly.Client, ID string, timeout time.Duration) error {
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("loadbalancer %s to be deleted", ID),
+		Timeout:      timeout,
+		Delay:        loadbalancerActiveInitDelay,
+		PollInterval: loadbalancerActivePollInterval,
 	}
-	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+	return conf.ForDeletion(ctx, func(ctx context.Context) (interface{}, string, error) {
+		lb, err := client.CloudLoadBalancer.Get(ctx, ID)
+		if err != nil {
+			return nil, "", err
+		}
+		return lb, lb.ProvisioningStatus, nil
+	})
+}
+
+func waitLoadbalancerActiveProvisioningStatus(ctx context.Context, client *gobizfly.Client, ID string, resourceType string, timeout time.Duration) (*gobizfly.LoadBalancer, error) {
+	_, err := waitActiveProvisioningStatus(ctx, fmt.Sprintf("%s %s", resourceType, ID), timeout, func(ctx context.Context) (interface{}, string, error) {
 		switch resourceType {
-		case loadbalancerResource:
-			lb, err := client.CloudLoadBalancer.Get(context.Background(), ID)
-			if err != nil {
-				return false, err
-			}
-			switch lb.ProvisioningStatus {
-			case activeStatus:
-				return true, nil
-			case errorStatus:
-				return true, fmt.Errorf("loadbalancer %s has gone into ERROR state", ID)
-			default:
-				return false, nil
-			}
 		case poolResource:
-			pool, err := client.CloudLoadBalancer.Pools().Get(context.Background(), ID)
+			pool, err := client.CloudLoadBalancer.Pools().Get(ctx, ID)
 			if err != nil {
-				return false, err
-			}
-			switch pool.ProvisoningStatus {
-			case activeStatus:
-				return true, nil
-			case errorStatus:
-				return true, fmt.Errorf("pool %s has gone into ERROR state", ID)
-			default:
-				return false, nil
+				return nil, "", err
 			}
+			return pool, pool.ProvisoningStatus, nil
 		case listenerResource:
-			listener, err := client.CloudLoadBalancer.Listeners().Get(context.Background(), ID)
+			listener, err := client.CloudLoadBalancer.Listeners().Get(ctx, ID)
 			if err != nil {
-				return false, err
-			}
-			switch listener.ProvisoningStatus {
-			case activeStatus:
-				return true, nil
-			case errorStatus:
-				return true, fmt.Errorf("listener %s has gone into ERROR state", ID)
-			default:
-				return false, nil
+				return nil, "", err
 			}
+			return listener, listener.ProvisoningStatus, nil
 		default:
-			return false, nil
+			lb, err := client.CloudLoadBalancer.Get(ctx, ID)
+			if err != nil {
+				return nil, "", err
+			}
+			return lb, lb.ProvisioningStatus, nil
 		}
-
 	})
-
-	if err == wait.ErrWaitTimeout {
-		err = fmt.Errorf("loadbalancer failed to go into ACTIVE provisioning status within allotted time")
+	if err != nil {
 		return nil, err
 	}
-	lb, err := client.CloudLoadBalancer.Get(context.Background(), ID)
+	lb, err := client.CloudLoadBalancer.Get(ctx, ID)
 	return lb, err
 }
+
+// waitActiveProvisioningStatus polls a load balancer object until its
+// provisioning status is ACTIVE, and fails as soon as it goes into ERROR.
+func waitActiveProvisioningStatus(ctx context.Context, name string, timeout time.Duration, refresh waiter.StatusFunc) (interface{}, error) {
+	conf := waiter.Config{
+		Description:  fmt.Sprintf("%s to go into ACTIVE provisioning status", name),
+		Target:       []string{activeStatus, errorStatus},
+		Timeout:      timeout,
+		Delay:        loadbalancerActiveInitDelay,
+		PollInterval: loadbalancerActivePollInterval,
+	}
+	var status string
+	result, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		result, s, err := refresh(ctx)
+		status = s
+		return result, s, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == errorStatus {
+		return nil, fmt.Errorf("%s has gone into ERROR state", name)
+	}
+	return result, nil
+}
@@ -2,28 +2,31 @@ package bizflycloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
 	"github.com/bizflycloud/terraform-provider-bizflycloud/constants"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBizflyCloudLoadBalancerL7Policy() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflycloudLoadbalancerL7PolicyCreate,
-		Read:   resourceBizflycloudLoadbalancerL7PolicyRead,
-		Update: resourceBizflycloudLoadbalancerL7PolicyUpdate,
-		Delete: resourceBizflycloudLoadbalancerL7PolicyDelete,
+		CreateContext: resourceBizflycloudLoadbalancerL7PolicyCreate,
+		ReadContext:   resourceBizflycloudLoadbalancerL7PolicyRead,
+		UpdateContext: resourceBizflycloudLoadbalancerL7PolicyUpdate,
+		DeleteContext: resourceBizflycloudLoadbalancerL7PolicyDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -120,20 +123,20 @@ func getL7PolicyRuleSchema() map[string]*schema.Schema {
 }
 
 // Create L7 policy
-func resourceBizflycloudLoadbalancerL7PolicyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflycloudLoadbalancerL7PolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	listenerID := d.Get("listener_id").(string)
 
 	// Get load balancer ID from listener to use mutex
-	listener, err := client.CloudLoadBalancer.Listeners().Get(context.Background(), listenerID)
+	listener, err := client.CloudLoadBalancer.Listeners().Get(ctx, listenerID)
 	if err != nil {
-		return fmt.Errorf("error retrieving listener %s: %v", listenerID, err)
+		return diag.Errorf("error retrieving listener %s: %v", listenerID, err)
 	}
 	if listener == nil {
-		return fmt.Errorf("error retrieving listener %s: listener object is nil", listenerID)
+		return diag.Errorf("error retrieving listener %s: listener object is nil", listenerID)
 	}
 	if len(listener.LoadBalancers) == 0 {
-		return fmt.Errorf("error retrieving listener %s: listener has no load balancers", listenerID)
+		return diag.Errorf("error retrieving listener %s: listener has no load balancers", listenerID)
 	}
 	lbID := listener.LoadBalancers[0].ID
 
@@ -142,43 +145,48 @@ func resourceBizflycloudLoadbalancerL7PolicyCreate(d *schema.ResourceData, meta
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	_, err = waitListenerActiveProvisioningStatus(client, listenerID)
+	_, err = waitListenerActiveProvisioningStatus(ctx, client, listenerID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		log.Printf("[ERROR] wait listener active provisioning status failed: %v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	createReq, err := getCreateL7PolicyFromConfig(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[DEBUG] create l7 policy payload for listener %s: %#v", listenerID, *createReq)
-	l7Policy, createErr := client.CloudLoadBalancer.L7Policies().Create(context.Background(), listenerID, createReq)
+	l7Policy, createErr := client.CloudLoadBalancer.L7Policies().Create(ctx, listenerID, createReq)
 	if createErr != nil {
-		return fmt.Errorf("error creating l7 policy for listener %s: %v", listenerID, createErr)
+		return diag.Errorf("error creating l7 policy for listener %s: %v", listenerID, createErr)
 	}
 	if l7Policy == nil {
-		return fmt.Errorf("error creating l7 policy for listener %s: l7 policy object is nil", listenerID)
+		return diag.Errorf("error creating l7 policy for listener %s: l7 policy object is nil", listenerID)
 	}
 	d.SetId(l7Policy.ID)
-	return resourceBizflycloudLoadbalancerL7PolicyRead(d, meta)
+	return resourceBizflycloudLoadbalancerL7PolicyRead(ctx, d, meta)
 }
 
 // Read L7 policy
-func resourceBizflycloudLoadbalancerL7PolicyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflycloudLoadbalancerL7PolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*CombinedConfig).gobizflyClient()
 	policyID := d.Id()
 	log.Printf("[DEBUG] test read l7 policy %s", policyID)
-	l7Policy, err := client.CloudLoadBalancer.L7Policies().Get(context.Background(), policyID)
+	l7Policy, err := client.CloudLoadBalancer.L7Policies().Get(ctx, policyID)
 	if err != nil {
-		return fmt.Errorf("error retrieving l7 policy %s: %v", policyID, err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] L7 policy (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving l7 policy %s: %v", policyID, err)
 	}
 	if l7Policy == nil {
-		return fmt.Errorf("error retrieving l7 policy %s: l7 policy object is nil", policyID)
+		return diag.Errorf("error retrieving l7 policy %s: l7 policy object is nil", policyID)
 	}
-	l7PolicyRules, err := client.CloudLoadBalancer.L7Policies().ListL7PolicyRules(context.Background(), policyID)
+	l7PolicyRules, err := client.CloudLoadBalancer.L7Policies().ListL7PolicyRules(ctx, policyID)
 	if err != nil {
-		return fmt.Errorf("error listing l7 policy %s rules: %v", policyID, err)
+		return diag.Errorf("error listing l7 policy %s rules: %v", policyID, err)
 	}
 	rules := parseL7PolicyRules(l7PolicyRules)
 	_ = d.Set("name", l7Policy.Name)
@@ -193,20 +201,20 @@ func resourceBizflycloudLoadbalancerL7PolicyRead(d *schema.ResourceData, meta in
 }
 
 // Update L7 policy
-func resourceBizflycloudLoadbalancerL7PolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflycloudLoadbalancerL7PolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	listenerID := d.Get("listener_id").(string)
 
 	// Get load balancer ID from listener to use mutex
-	listener, err := client.CloudLoadBalancer.Listeners().Get(context.Background(), listenerID)
+	listener, err := client.CloudLoadBalancer.Listeners().Get(ctx, listenerID)
 	if err != nil {
-		return fmt.Errorf("error retrieving listener %s: %v", listenerID, err)
+		return diag.Errorf("error retrieving listener %s: %v", listenerID, err)
 	}
 	if listener == nil {
-		return fmt.Errorf("error retrieving listener %s: listener object is nil", listenerID)
+		return diag.Errorf("error retrieving listener %s: listener object is nil", listenerID)
 	}
 	if len(listener.LoadBalancers) == 0 {
-		return fmt.Errorf("error retrieving listener %s: listener has no load balancers", listenerID)
+		return diag.Errorf("error retrieving listener %s: listener has no load balancers", listenerID)
 	}
 	lbID := listener.LoadBalancers[0].ID
 
@@ -215,49 +223,49 @@ func resourceBizflycloudLoadbalancerL7PolicyUpdate(d *schema.ResourceData, meta
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	_, err = waitListenerActiveProvisioningStatus(client, listenerID)
+	_, err = waitListenerActiveProvisioningStatus(ctx, client, listenerID, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
 		log.Printf("[ERROR] wait listener active provisioning status failed: %v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	updateReq, err := getUpdateL7PolicyFromConfig(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	rules, err := getUpdateL7PolicyRulesFromConfig(d, client)
+	rules, err := getUpdateL7PolicyRulesFromConfig(ctx, d, client)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	updateReq.Rules = rules
 	policyID := d.Id()
-	_, err = waitL7PolicyActiveProvisioningStatus(client, policyID)
+	_, err = waitL7PolicyActiveProvisioningStatus(ctx, client, policyID, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[DEBUG] update l7 policy %s payload for listener %s: %#v", policyID, listenerID, *updateReq)
-	_, createErr := client.CloudLoadBalancer.L7Policies().Update(context.Background(), policyID, updateReq)
+	_, createErr := client.CloudLoadBalancer.L7Policies().Update(ctx, policyID, updateReq)
 	if createErr != nil {
-		return fmt.Errorf("error updating l7 policy %s for listener %s: %v", policyID, listenerID, createErr)
+		return diag.Errorf("error updating l7 policy %s for listener %s: %v", policyID, listenerID, createErr)
 	}
-	return resourceBizflycloudLoadbalancerL7PolicyRead(d, meta)
+	return resourceBizflycloudLoadbalancerL7PolicyRead(ctx, d, meta)
 }
 
 // Destroy L7 policy
-func resourceBizflycloudLoadbalancerL7PolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflycloudLoadbalancerL7PolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyID := d.Id()
 	listenerID := d.Get("listener_id").(string)
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	// Get load balancer ID from listener to use mutex
-	listener, err := client.CloudLoadBalancer.Listeners().Get(context.Background(), listenerID)
+	listener, err := client.CloudLoadBalancer.Listeners().Get(ctx, listenerID)
 	if err != nil {
-		return fmt.Errorf("error retrieving listener %s: %v", listenerID, err)
+		return diag.Errorf("error retrieving listener %s: %v", listenerID, err)
 	}
 	if listener == nil {
-		return fmt.Errorf("error retrieving listener %s: listener object is nil", listenerID)
+		return diag.Errorf("error retrieving listener %s: listener object is nil", listenerID)
 	}
 	if len(listener.LoadBalancers) == 0 {
-		return fmt.Errorf("error retrieving listener %s: listener has no load balancers", listenerID)
+		return diag.Errorf("error retrieving listener %s: listener has no load balancers", listenerID)
 	}
 	lbID := listener.LoadBalancers[0].ID
 
@@ -266,14 +274,14 @@ func resourceBizflycloudLoadbalancerL7PolicyDelete(d *schema.ResourceData, meta
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	_, err = waitListenerActiveProvisioningStatus(client, listenerID)
+	_, err = waitListenerActiveProvisioningStatus(ctx, client, listenerID, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		log.Printf("[ERROR] wait listener active provisioning status failed: %v", err)
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.CloudLoadBalancer.L7Policies().Delete(context.Background(), policyID)
+	err = client.CloudLoadBalancer.L7Policies().Delete(ctx, policyID)
 	if err != nil {
-		return fmt.Errorf("error deleting l7 policy: %v", err)
+		return diag.Errorf("error deleting l7 policy: %v", err)
 	}
 	return nil
 }
@@ -419,7 +427,7 @@ func getUpdateL7PolicyFromConfig(d *schema.ResourceData) (*gobizfly.UpdateL7Poli
 }
 
 // get update l7 policy rules payload
-func getUpdateL7PolicyRulesFromConfig(d *schema.ResourceData, client *gobizfly.Client) ([]gobizfly.UpdateL7PolicyRuleRequest, error) {
+func getUpdateL7PolicyRulesFromConfig(ctx context.Context, d *schema.ResourceData, client *gobizfly.Client) ([]gobizfly.UpdateL7PolicyRuleRequest, error) {
 	_, newRules := d.GetChange("rules")
 	rulesReq, err := castUpdateL7PolicyRulesRequest(newRules)
 	if err != nil {
@@ -440,7 +448,7 @@ func getUpdateL7PolicyRulesFromConfig(d *schema.ResourceData, client *gobizfly.C
 			Value:       ruleReq.Value,
 		}
 		log.Printf("[DEBUG] Create l7 policy payload: policyID=%s - payload=%#v", policyID, newRulePayload)
-		newRule, err := client.CloudLoadBalancer.L7Policies().CreateL7PolicyRule(context.Background(), policyID, newRulePayload)
+		newRule, err := client.CloudLoadBalancer.L7Policies().CreateL7PolicyRule(ctx, policyID, newRulePayload)
 		if err != nil {
 			log.Printf("[Error] create l7 policy %s rule: %v", policyID, err)
 			return nil, fmt.Errorf("error creating l7 policy %s rule: %v", policyID, err)
@@ -504,65 +512,31 @@ func parseL7PolicyRules(rules []gobizfly.DetailL7PolicyRule) []map[string]interf
 }
 
 // Check listener active status
-func waitListenerActiveProvisioningStatus(client *gobizfly.Client, listenerID string) (*gobizfly.CloudLoadBalancerListener, error) {
-	var lb *gobizfly.CloudLoadBalancerListener
-	backoff := wait.Backoff{
-		Duration: loadbalancerActiveInitDelay,
-		Factor:   loadbalancerActiveFactor,
-		Steps:    loadbalancerActiveSteps,
-	}
-	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
-		listener, err := client.CloudLoadBalancer.Listeners().Get(context.Background(), listenerID)
+func waitListenerActiveProvisioningStatus(ctx context.Context, client *gobizfly.Client, listenerID string, timeout time.Duration) (*gobizfly.CloudLoadBalancerListener, error) {
+	result, err := waitActiveProvisioningStatus(ctx, fmt.Sprintf("listener %s", listenerID), timeout, func(ctx context.Context) (interface{}, string, error) {
+		listener, err := client.CloudLoadBalancer.Listeners().Get(ctx, listenerID)
 		if err != nil {
-			return true, err
-		}
-		switch listener.ProvisoningStatus {
-		case activeStatus:
-			return true, nil
-		case errorStatus:
-			return true, fmt.Errorf("listener %s has gone into ERROR state", listenerID)
-		default:
-			return false, nil
+			return nil, "", err
 		}
+		return listener, listener.ProvisoningStatus, nil
 	})
 	if err != nil {
-		if err == wait.ErrWaitTimeout {
-			err = fmt.Errorf("listener failed to go into ACTIVE provisioning status within allotted time")
-		}
 		return nil, err
 	}
-
-	return lb, err
+	return result.(*gobizfly.CloudLoadBalancerListener), nil
 }
 
 // Check L7 policy active status
-func waitL7PolicyActiveProvisioningStatus(client *gobizfly.Client, policyID string) (*gobizfly.DetailL7Policy, error) {
-	var policy *gobizfly.DetailL7Policy
-	backoff := wait.Backoff{
-		Duration: loadbalancerActiveInitDelay,
-		Factor:   loadbalancerActiveFactor,
-		Steps:    loadbalancerActiveSteps,
-	}
-	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
-		policy, err := client.CloudLoadBalancer.L7Policies().Get(context.Background(), policyID)
+func waitL7PolicyActiveProvisioningStatus(ctx context.Context, client *gobizfly.Client, policyID string, timeout time.Duration) (*gobizfly.DetailL7Policy, error) {
+	result, err := waitActiveProvisioningStatus(ctx, fmt.Sprintf("L7 policy %s", policyID), timeout, func(ctx context.Context) (interface{}, string, error) {
+		policy, err := client.CloudLoadBalancer.L7Policies().Get(ctx, policyID)
 		if err != nil {
-			return true, err
-		}
-		switch policy.ProvisioningStatus {
-		case activeStatus:
-			return true, nil
-		case errorStatus:
-			return true, fmt.Errorf("L7 policy %s has gone into ERROR state", policyID)
-		default:
-			return false, nil
+			return nil, "", err
 		}
+		return policy, policy.ProvisioningStatus, nil
 	})
 	if err != nil {
-		if err == wait.ErrWaitTimeout {
-			err = fmt.Errorf("L7 policy failed to go into ACTIVE provisioning status within allotted time")
-		}
 		return nil, err
 	}
-
-	return policy, err
+	return result.(*gobizfly.DetailL7Policy), nil
 }
@@ -2,24 +2,26 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
 	"github.com/bizflycloud/terraform-provider-bizflycloud/constants"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBizflyCloudLoadBalancerListener() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudLoadBalancerListenerCreate,
-		Read:   resourceBizflyCloudLoadBalancerListenerRead,
-		Update: resourceBizflyCloudLoadBalancerListenerUpdate,
-		Delete: resourceBizflyCloudLoadBalancerListenerDelete,
+		CreateContext: resourceBizflyCloudLoadBalancerListenerCreate,
+		ReadContext:   resourceBizflyCloudLoadBalancerListenerRead,
+		UpdateContext: resourceBizflyCloudLoadBalancerListenerUpdate,
+		DeleteContext: resourceBizflyCloudLoadBalancerListenerDelete,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -89,11 +91,13 @@ func resourceBizflyCloudLoadBalancerListener() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
-func resourceBizflyCloudLoadBalancerListenerCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerListenerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	lbID := d.Get("load_balancer_id").(string)
 
@@ -102,7 +106,7 @@ func resourceBizflyCloudLoadBalancerListenerCreate(d *schema.ResourceData, meta
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	_, _ = waitLoadbalancerActiveProvisioningStatus(client, lbID, loadbalancerResource)
+	_, _ = waitLoadbalancerActiveProvisioningStatus(ctx, client, lbID, loadbalancerResource, d.Timeout(schema.TimeoutCreate))
 	lName := d.Get("name").(string)
 	lPoolDefaultID := d.Get("default_pool_id").(string)
 	lPoolTLSRef := d.Get("default_tls_ref").(string)
@@ -119,28 +123,33 @@ func resourceBizflyCloudLoadBalancerListenerCreate(d *schema.ResourceData, meta
 		TimeoutMemberData:      &serverTimeout,
 		TimeoutMemberConnect:   &serverConnectTimeout,
 	}
-	listener, err := client.CloudLoadBalancer.Listeners().Create(context.Background(), lbID, &lcr)
+	listener, err := client.CloudLoadBalancer.Listeners().Create(ctx, lbID, &lcr)
 	if err != nil {
-		return fmt.Errorf("error creating listener: %v", err)
+		return diag.Errorf("error creating listener: %v", err)
 	}
 	if listener == nil {
-		return fmt.Errorf("error creating listener for loadbalancer %s: listener object is nil", lbID)
+		return diag.Errorf("error creating listener for loadbalancer %s: listener object is nil", lbID)
 	}
 	d.SetId(listener.ID)
-	return resourceBizflyCloudLoadBalancerListenerRead(d, meta)
+	return resourceBizflyCloudLoadBalancerListenerRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudLoadBalancerListenerRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerListenerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	listener, err := client.CloudLoadBalancer.Listeners().Get(context.Background(), d.Id())
+	listener, err := client.CloudLoadBalancer.Listeners().Get(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error retrieving listener: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Load balancer listener (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving listener: %v", err)
 	}
 	if listener == nil {
-		return fmt.Errorf("error retrieving listener %s: listener object is nil", d.Id())
+		return diag.Errorf("error retrieving listener %s: listener object is nil", d.Id())
 	}
 	if len(listener.LoadBalancers) == 0 {
-		return fmt.Errorf("error retrieving listener %s: listener has no load balancers", d.Id())
+		return diag.Errorf("error retrieving listener %s: listener has no load balancers", d.Id())
 	}
 	l7policyIDs := make([]string, 0)
 	for _, policy := range listener.L7Policies {
@@ -165,7 +174,7 @@ func resourceBizflyCloudLoadBalancerListenerRead(d *schema.ResourceData, meta in
 	return nil
 }
 
-func resourceBizflyCloudLoadBalancerListenerUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerListenerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	lbID := d.Get("load_balancer_id").(string)
 
@@ -174,7 +183,7 @@ func resourceBizflyCloudLoadBalancerListenerUpdate(d *schema.ResourceData, meta
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	_, _ = waitLoadbalancerActiveProvisioningStatus(client, lbID, loadbalancerResource)
+	_, _ = waitLoadbalancerActiveProvisioningStatus(ctx, client, lbID, loadbalancerResource, d.Timeout(schema.TimeoutUpdate))
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	tlsRef := d.Get("default_tls_ref").(string)
@@ -191,14 +200,14 @@ func resourceBizflyCloudLoadBalancerListenerUpdate(d *schema.ResourceData, meta
 		TimeoutMemberData:      &serverTimeout,
 		TimeoutMemberConnect:   &serverConnectTimeout,
 	}
-	_, err := client.CloudLoadBalancer.Listeners().Update(context.Background(), d.Id(), &lur)
+	_, err := client.CloudLoadBalancer.Listeners().Update(ctx, d.Id(), &lur)
 	if err != nil {
-		return fmt.Errorf("error updating listener: %v", err)
+		return diag.Errorf("error updating listener: %v", err)
 	}
-	return resourceBizflyCloudLoadBalancerListenerRead(d, meta)
+	return resourceBizflyCloudLoadBalancerListenerRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudLoadBalancerListenerDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerListenerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	lbID := d.Get("load_balancer_id").(string)
 
@@ -207,10 +216,10 @@ func resourceBizflyCloudLoadBalancerListenerDelete(d *schema.ResourceData, meta
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	_, _ = waitLoadbalancerActiveProvisioningStatus(client, lbID, loadbalancerResource)
-	err := client.CloudLoadBalancer.Listeners().Delete(context.Background(), d.Id())
+	_, _ = waitLoadbalancerActiveProvisioningStatus(ctx, client, lbID, loadbalancerResource, d.Timeout(schema.TimeoutDelete))
+	err := client.CloudLoadBalancer.Listeners().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting listener: %v", err)
+		return diag.Errorf("error deleting listener: %v", err)
 	}
 	return nil
 }
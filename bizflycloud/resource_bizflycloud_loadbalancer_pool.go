@@ -2,28 +2,31 @@ package bizflycloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
 	"github.com/bizflycloud/terraform-provider-bizflycloud/constants"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBizflyCloudLoadBalancerPool() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudLoadBalancerPoolCreate,
-		Update: resourceBizflyCloudLoadBalancerPoolUpdate,
-		Read:   resourceBizflyCloudLoadBalancerPoolRead,
-		Delete: resourceBizflyCloudLoadBalancerPoolDelete,
+		CreateContext: resourceBizflyCloudLoadBalancerPoolCreate,
+		UpdateContext: resourceBizflyCloudLoadBalancerPoolUpdate,
+		ReadContext:   resourceBizflyCloudLoadBalancerPoolRead,
+		DeleteContext: resourceBizflyCloudLoadBalancerPoolDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -222,7 +225,7 @@ func getPersistentSchema() map[string]*schema.Schema {
 }
 
 // Create pool resource
-func resourceBizflyCloudLoadBalancerPoolCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	lbID := d.Get("load_balancer_id").(string)
 
@@ -231,24 +234,24 @@ func resourceBizflyCloudLoadBalancerPoolCreate(d *schema.ResourceData, meta inte
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	_, err := waitLoadbalancerActiveProvisioningStatus(client, lbID, loadbalancerResource)
+	_, err := waitLoadbalancerActiveProvisioningStatus(ctx, client, lbID, loadbalancerResource, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	createReq := getCreatePoolPayloadFromConfig(d)
 	log.Printf("[DEBUG] Create pool payload: %+v", createReq)
-	pool, err := client.CloudLoadBalancer.Pools().Create(context.Background(), lbID, &createReq)
+	pool, err := client.CloudLoadBalancer.Pools().Create(ctx, lbID, &createReq)
 	if err != nil {
-		return fmt.Errorf("error creating pool for loadbalancer %s: %+v", lbID, err)
+		return diag.Errorf("error creating pool for loadbalancer %s: %+v", lbID, err)
 	}
 	if pool == nil {
 		/// need retry here to get the pool object
-		return fmt.Errorf("error creating pool for loadbalancer %s: pool object is nil", lbID)
+		return diag.Errorf("error creating pool for loadbalancer %s: pool object is nil", lbID)
 	}
 	poolID := pool.ID
-	err = checkLoadbalancerPoolActiveStatus(client, poolID, lbID)
+	err = checkLoadbalancerPoolActiveStatus(ctx, client, poolID, lbID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(poolID)
 
@@ -260,46 +263,51 @@ func resourceBizflyCloudLoadBalancerPoolCreate(d *schema.ResourceData, meta inte
 		}
 		persistent := getSessionPersistentPayloadFromConfig(d)
 		updateReq.SessionPersistence = persistent
-		_, err = client.CloudLoadBalancer.Pools().Update(context.Background(), poolID, updateReq)
+		_, err = client.CloudLoadBalancer.Pools().Update(ctx, poolID, updateReq)
 		if err != nil {
 			log.Printf("[ERROR] Update pool %s failed: %v", poolID, err)
-			return err
+			return diag.FromErr(err)
 		}
 
-		err = checkLoadbalancerPoolActiveStatus(client, poolID, lbID)
+		err = checkLoadbalancerPoolActiveStatus(ctx, client, poolID, lbID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	// Create health monitor
 	healthMonitorPayload := getCreateHealthMonitorPayloadFromConfig(d)
 	if healthMonitorPayload != nil {
-		_, err = client.CloudLoadBalancer.HealthMonitors().Create(context.Background(), poolID, healthMonitorPayload)
+		_, err = client.CloudLoadBalancer.HealthMonitors().Create(ctx, poolID, healthMonitorPayload)
 		if err != nil {
 			log.Printf("[ERROR] Create health monitor for pool %s failed: %+v", poolID, err)
-			return err
+			return diag.FromErr(err)
 		}
-		err = checkLoadbalancerPoolActiveStatus(client, poolID, lbID)
+		err = checkLoadbalancerPoolActiveStatus(ctx, client, poolID, lbID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
-	return resourceBizflyCloudLoadBalancerPoolRead(d, meta)
+	return resourceBizflyCloudLoadBalancerPoolRead(ctx, d, meta)
 }
 
 // Read pool resource
-func resourceBizflyCloudLoadBalancerPoolRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	pool, err := client.CloudLoadBalancer.Pools().Get(context.Background(), d.Id())
+	pool, err := client.CloudLoadBalancer.Pools().Get(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error retrieving load balancer pool %s: %v", d.Id(), err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Load balancer pool (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving load balancer pool %s: %v", d.Id(), err)
 	}
 	if pool == nil {
-		return fmt.Errorf("error retrieving load balancer pool %s: pool object is nil", d.Id())
+		return diag.Errorf("error retrieving load balancer pool %s: pool object is nil", d.Id())
 	}
 	if len(pool.LoadBalancers) == 0 {
-		return fmt.Errorf("error retrieving load balancer pool %s: pool has no load balancers", d.Id())
+		return diag.Errorf("error retrieving load balancer pool %s: pool has no load balancers", d.Id())
 	}
 	healthMonitor := convertHealthMonitor(pool.HealthMonitor)
 	persistent := convertSessionPersistent(pool.SessionPersistence)
@@ -317,7 +325,7 @@ func resourceBizflyCloudLoadBalancerPoolRead(d *schema.ResourceData, meta interf
 }
 
 // Change pool resource
-func resourceBizflyCloudLoadBalancerPoolUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	poolID := d.Id()
 	lbID := d.Get("load_balancer_id").(string)
@@ -327,20 +335,20 @@ func resourceBizflyCloudLoadBalancerPoolUpdate(d *schema.ResourceData, meta inte
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	err := checkLoadbalancerPoolActiveStatus(client, poolID, lbID)
+	err := checkLoadbalancerPoolActiveStatus(ctx, client, poolID, lbID, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	// Update pool
 	updateReq := getUpdatePoolPayloadFromConfig(d)
-	_, err = client.CloudLoadBalancer.Pools().Update(context.Background(), poolID, &updateReq)
+	_, err = client.CloudLoadBalancer.Pools().Update(ctx, poolID, &updateReq)
 	if err != nil {
 		log.Printf("[ERROR] Update pool %s failed: %+v", poolID, err)
-		return err
+		return diag.FromErr(err)
 	}
-	err = checkLoadbalancerPoolActiveStatus(client, poolID, lbID)
+	err = checkLoadbalancerPoolActiveStatus(ctx, client, poolID, lbID, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// update health monitor of pool
@@ -350,23 +358,23 @@ func resourceBizflyCloudLoadBalancerPoolUpdate(d *schema.ResourceData, meta inte
 			if healthMonitorID == "" {
 				// Create health monitor for pool
 				createHealthMonitorReq := getCreateHealthMonitorPayloadFromConfig(d)
-				_, err = client.CloudLoadBalancer.HealthMonitors().Create(context.Background(), poolID, createHealthMonitorReq)
+				_, err = client.CloudLoadBalancer.HealthMonitors().Create(ctx, poolID, createHealthMonitorReq)
 				if err != nil {
 					log.Printf("[ERROR] Create health monitor %s failed: %v", healthMonitorID, err)
-					return err
+					return diag.FromErr(err)
 				}
 			} else {
 				// Update health monitor for pool
-				_, err = client.CloudLoadBalancer.HealthMonitors().Update(context.Background(), healthMonitorID, healthMonitorReq)
+				_, err = client.CloudLoadBalancer.HealthMonitors().Update(ctx, healthMonitorID, healthMonitorReq)
 				if err != nil {
 					log.Printf("[ERROR] Update health monitor %s failed: %v", healthMonitorID, err)
-					return err
+					return diag.FromErr(err)
 				}
 			}
 
-			err = checkLoadbalancerPoolActiveStatus(client, poolID, lbID)
+			err = checkLoadbalancerPoolActiveStatus(ctx, client, poolID, lbID, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -380,16 +388,16 @@ func resourceBizflyCloudLoadBalancerPoolUpdate(d *schema.ResourceData, meta inte
 			memberID := castedMember["id"].(string)
 			if err != nil {
 				log.Printf("[ERROR] check pool active status before delete member")
-				return err
+				return diag.FromErr(err)
 			}
-			err = client.CloudLoadBalancer.Members().Delete(context.Background(), poolID, memberID)
+			err = client.CloudLoadBalancer.Members().Delete(ctx, poolID, memberID)
 			if err != nil {
-				return fmt.Errorf("error deleting old member %s: %v", memberID, err)
+				return diag.Errorf("error deleting old member %s: %v", memberID, err)
 			}
 
-			err = checkLoadbalancerPoolActiveStatus(client, poolID, lbID)
+			err = checkLoadbalancerPoolActiveStatus(ctx, client, poolID, lbID, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		// Create new members for pool
@@ -402,22 +410,22 @@ func resourceBizflyCloudLoadBalancerPoolUpdate(d *schema.ResourceData, meta inte
 				ProtocolPort: castedMember["protocol_port"].(int),
 				Backup:       castedMember["backup"].(bool),
 			}
-			_, err = client.CloudLoadBalancer.Members().Create(context.Background(), poolID, updateMemberReq)
+			_, err = client.CloudLoadBalancer.Members().Create(ctx, poolID, updateMemberReq)
 			if err != nil {
-				return fmt.Errorf("error creating new member: %v", err)
+				return diag.Errorf("error creating new member: %v", err)
 			}
 
-			err = checkLoadbalancerPoolActiveStatus(client, poolID, lbID)
+			err = checkLoadbalancerPoolActiveStatus(ctx, client, poolID, lbID, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
-	return resourceBizflyCloudLoadBalancerPoolRead(d, meta)
+	return resourceBizflyCloudLoadBalancerPoolRead(ctx, d, meta)
 }
 
 // Delete pool resource
-func resourceBizflyCloudLoadBalancerPoolDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudLoadBalancerPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	lbID := d.Get("load_balancer_id").(string)
 
@@ -426,10 +434,10 @@ func resourceBizflyCloudLoadBalancerPoolDelete(d *schema.ResourceData, meta inte
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	_, _ = waitLoadbalancerActiveProvisioningStatus(client, lbID, loadbalancerResource)
-	err := client.CloudLoadBalancer.Pools().Delete(context.Background(), d.Id())
+	_, _ = waitLoadbalancerActiveProvisioningStatus(ctx, client, lbID, loadbalancerResource, d.Timeout(schema.TimeoutDelete))
+	err := client.CloudLoadBalancer.Pools().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting load balancer pool: %v", err)
+		return diag.Errorf("error deleting load balancer pool: %v", err)
 	}
 	return nil
 }
@@ -654,45 +662,28 @@ func convertSessionPersistent(sessionPersistent *gobizfly.SessionPersistence) []
 }
 
 // Wait loadbalancer pool active status
-func waitPoolActiveProvisioningStatus(client *gobizfly.Client, poolID string) (*gobizfly.CloudLoadBalancerPool, error) {
-	var pool *gobizfly.CloudLoadBalancerPool
-	backoff := wait.Backoff{
-		Duration: loadbalancerActiveInitDelay,
-		Factor:   loadbalancerActiveFactor,
-		Steps:    loadbalancerActiveSteps,
-	}
-	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
-		pool, err := client.CloudLoadBalancer.Pools().Get(context.Background(), poolID)
+func waitPoolActiveProvisioningStatus(ctx context.Context, client *gobizfly.Client, poolID string, timeout time.Duration) (*gobizfly.CloudLoadBalancerPool, error) {
+	result, err := waitActiveProvisioningStatus(ctx, fmt.Sprintf("loadbalancer pool %s", poolID), timeout, func(ctx context.Context) (interface{}, string, error) {
+		pool, err := client.CloudLoadBalancer.Pools().Get(ctx, poolID)
 		if err != nil {
-			return true, err
-		}
-		switch pool.ProvisoningStatus {
-		case activeStatus:
-			return true, nil
-		case errorStatus:
-			return true, fmt.Errorf("loadbalancer Pool %s has gone into ERROR state", poolID)
-		default:
-			return false, nil
+			return nil, "", err
 		}
+		return pool, pool.ProvisoningStatus, nil
 	})
 	if err != nil {
-		if err == wait.ErrWaitTimeout {
-			err = fmt.Errorf("loadbalancer pool %s failed to go into ACTIVE provisioning status within allotted time", poolID)
-		}
 		return nil, err
 	}
-
-	return pool, err
+	return result.(*gobizfly.CloudLoadBalancerPool), nil
 }
 
 // Check loadbalancer and pool resource active status
-func checkLoadbalancerPoolActiveStatus(client *gobizfly.Client, poolID, loadbalancerID string) error {
-	_, err := waitPoolActiveProvisioningStatus(client, poolID)
+func checkLoadbalancerPoolActiveStatus(ctx context.Context, client *gobizfly.Client, poolID, loadbalancerID string, timeout time.Duration) error {
+	_, err := waitPoolActiveProvisioningStatus(ctx, client, poolID, timeout)
 	if err != nil {
 		log.Printf("[ERROR] wait pool %s active status: %v", poolID, err)
 		return err
 	}
-	_, err = waitLoadbalancerActiveProvisioningStatus(client, loadbalancerID, loadbalancerResource)
+	_, err = waitLoadbalancerActiveProvisioningStatus(ctx, client, loadbalancerID, loadbalancerResource, timeout)
 	if err != nil {
 		log.Printf("[ERROR] wait loadbalancer %s active status: %v", loadbalancerID, err)
 		return err
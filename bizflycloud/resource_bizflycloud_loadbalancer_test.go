@@ -0,0 +1,51 @@
+package bizflycloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bizflycloud/gobizfly"
+)
+
+func TestBizflyCloudLoadBalancer_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudLoadBalancer()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name":        "lb-foo",
+		"description": "test load balancer",
+		"type":        "small",
+	}, meta)
+	testCheckResourceDataAttr(t, d, "name", "lb-foo")
+	testCheckResourceDataAttr(t, d, "provisioning_status", activeStatus)
+	testCheckResourceDataAttr(t, d, "operating_status", "ONLINE")
+	testCheckResourceDataAttr(t, d, "vip_address", "198.51.100.10")
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckResourceDataAttr(t, imported, "type", "small")
+
+	testResourceDelete(t, r, d, meta)
+	if _, err := meta.gobizflyClient().CloudLoadBalancer.Get(context.Background(), d.Id()); !errors.Is(err, gobizfly.ErrNotFound) {
+		t.Errorf("expected load balancer %s to be deleted, got: %v", d.Id(), err)
+	}
+}
+
+func TestBizflyCloudLoadBalancer_FakeAPIDeletedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudLoadBalancer()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name": "lb-gone",
+	}, meta)
+
+	api.mu.Lock()
+	delete(api.loadBalancers, d.Id())
+	api.mu.Unlock()
+
+	testCheckResourceGone(t, r, d, meta)
+}
@@ -3,23 +3,23 @@ package bizflycloud
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudNetworkInterface() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudNetworkInterfaceCreate,
-		Read:   resourceBizflyCloudNetworkInterfaceRead,
-		Update: resourceBizflyCloudNetworkInterfaceUpdate,
-		Delete: resourceBizflyCloudNetworkInterfaceDelete,
+		CreateContext: resourceBizflyCloudNetworkInterfaceCreate,
+		ReadContext:   resourceBizflyCloudNetworkInterfaceRead,
+		UpdateContext: resourceBizflyCloudNetworkInterfaceUpdate,
+		DeleteContext: resourceBizflyCloudNetworkInterfaceDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 1,
 		Schema:        resourceNetworkInterfaceSchema(),
@@ -29,39 +29,39 @@ func resourceBizflyCloudNetworkInterface() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudNetworkInterfaceCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudNetworkInterfaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	networkID := d.Get("network_id").(string)
 	if networkID == "" {
-		return fmt.Errorf("invalid network id specified")
+		return diag.Errorf("invalid network id specified")
 	}
 	createPayload := &gobizfly.CreateNetworkInterfacePayload{
 		Name:    d.Get("name").(string),
 		FixedIP: d.Get("fixed_ip").(string),
 	}
-	networkInterface, err := client.CloudServer.NetworkInterfaces().Create(context.Background(), networkID, createPayload)
+	networkInterface, err := client.CloudServer.NetworkInterfaces().Create(ctx, networkID, createPayload)
 	if err != nil {
-		return fmt.Errorf("error when create network interface: %v", err)
+		return diag.Errorf("error when create network interface: %v", err)
 	}
 	d.SetId(networkInterface.ID)
 	firewallIDs := readStringArray(d.Get("firewall_ids").(*schema.Set).List())
 	log.Printf("[DEBUG] Firewall IDs: %v", firewallIDs)
-	if attachFirewallsForPort(client, networkInterface.ID, firewallIDs) != nil {
-		return fmt.Errorf("error when attach firewall for network interface: %v", err)
+	if attachFirewallsForPort(ctx, client, networkInterface.ID, firewallIDs) != nil {
+		return diag.Errorf("error when attach firewall for network interface: %v", err)
 	}
-	return resourceBizflyCloudNetworkInterfaceRead(d, meta)
+	return resourceBizflyCloudNetworkInterfaceRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudNetworkInterfaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	networkInterface, err := client.CloudServer.NetworkInterfaces().Get(context.Background(), d.Id())
+	networkInterface, err := client.CloudServer.NetworkInterfaces().Get(ctx, d.Id())
 	if !d.IsNewResource() && errors.Is(err, gobizfly.ErrNotFound) {
 		log.Printf("[WARN] Network interface %s is not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf("error reading network interface network %s: %w", d.Id(), err)
+		return diag.Errorf("error reading network interface network %s: %v", d.Id(), err)
 	}
 	d.SetId(networkInterface.ID)
 	_ = d.Set("name", networkInterface.Name)
@@ -72,46 +72,46 @@ func resourceBizflyCloudNetworkInterfaceRead(d *schema.ResourceData, meta interf
 	_ = d.Set("updated_at", networkInterface.UpdatedAt)
 	_ = d.Set("server_id", networkInterface.DeviceID)
 	if err := d.Set("fixed_ips", readFixedIps(networkInterface.FixedIps)); err != nil {
-		return fmt.Errorf("error setting fixed_ips: %w", err)
+		return diag.Errorf("error setting fixed_ips: %v", err)
 	}
 	if err := d.Set("firewall_ids", networkInterface.SecurityGroups); err != nil {
-		return fmt.Errorf("error setting security_groups: %w", err)
+		return diag.Errorf("error setting security_groups: %v", err)
 	}
 	return nil
 }
 
-func resourceBizflyCloudNetworkInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudNetworkInterfaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	if d.HasChange("name") {
 		updatePayload := &gobizfly.UpdateNetworkInterfacePayload{
 			Name: d.Get("name").(string),
 		}
-		_, err := client.CloudServer.NetworkInterfaces().Update(context.Background(), d.Id(), updatePayload)
+		_, err := client.CloudServer.NetworkInterfaces().Update(ctx, d.Id(), updatePayload)
 		if err != nil {
-			return fmt.Errorf("error when update network interface: %s, %v", d.Id(), err)
+			return diag.Errorf("error when update network interface: %s, %v", d.Id(), err)
 		}
 	}
 	if d.HasChange("firewall_ids") {
-		if err := updateFirewallForNetworkInterface(d, client, d.Id()); err != nil {
-			return fmt.Errorf("error when update firewall for network interface: %s, %v", d.Id(), err)
+		if err := updateFirewallForNetworkInterface(ctx, d, client, d.Id()); err != nil {
+			return diag.Errorf("error when update firewall for network interface: %s, %v", d.Id(), err)
 		}
 	}
-	return resourceBizflyCloudNetworkInterfaceRead(d, meta)
+	return resourceBizflyCloudNetworkInterfaceRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudNetworkInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudNetworkInterfaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudServer.NetworkInterfaces().Delete(context.Background(), d.Id())
+	err := client.CloudServer.NetworkInterfaces().Delete(ctx, d.Id())
 	if err != nil && !strings.Contains(err.Error(), "Resource not found") {
-		return fmt.Errorf("error when delete network interface: %v", err)
+		return diag.Errorf("error when delete network interface: %v", err)
 	}
 	return nil
 }
 
-func updateFirewallForNetworkInterface(d *schema.ResourceData, client *gobizfly.Client,
+func updateFirewallForNetworkInterface(ctx context.Context, d *schema.ResourceData, client *gobizfly.Client,
 	networkInterfaceID string) error {
 	firewallIDs := readStringArray(d.Get("firewall_ids").(*schema.Set).List())
-	if err := attachFirewallsForPort(client, networkInterfaceID, firewallIDs); err != nil {
+	if err := attachFirewallsForPort(ctx, client, networkInterfaceID, firewallIDs); err != nil {
 		log.Printf("[ERROR] Error attaching firewalls %s to network interface %s: %s", firewallIDs, networkInterfaceID, err)
 		return err
 	}
@@ -130,12 +130,12 @@ func updateFirewallForNetworkInterface(d *schema.ResourceData, client *gobizfly.
 	}
 
 	log.Printf("[DEBUG] Add firewalls %s to network interface %s", addFirewallIDsArray, networkInterfaceID)
-	if err := attachFirewallsForPort(client, networkInterfaceID, addFirewallIDsArray); err != nil {
+	if err := attachFirewallsForPort(ctx, client, networkInterfaceID, addFirewallIDsArray); err != nil {
 		log.Printf("[ERROR] Error attaching firewalls %s to network interface %s: %s", addFirewallIDs, networkInterfaceID, err)
 		return err
 	}
 	log.Printf("[DEBUG] Remove firewalls %s from network interface %s", removeFirewallIDsArray, networkInterfaceID)
-	if err := detachFirewallsForPort(client, networkInterfaceID, removeFirewallIDsArray); err != nil {
+	if err := detachFirewallsForPort(ctx, client, networkInterfaceID, removeFirewallIDsArray); err != nil {
 		log.Printf("[ERROR] Error detaching firewalls %s from network interface %s: %s", removeFirewallIDs, networkInterfaceID, err)
 		return err
 	}
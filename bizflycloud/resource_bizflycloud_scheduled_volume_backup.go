@@ -2,20 +2,21 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
-	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudScheduledVolumeBackup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudScheduledVolumeBackupCreate,
-		Read:   resourceBizflyCloudScheduledVolumeBackupRead,
-		Update: resourceBizflyCloudScheduledVolumeBackupUpdate,
-		Delete: resourceBizflyCloudScheduledVolumeBackupDelete,
+		CreateContext: resourceBizflyCloudScheduledVolumeBackupCreate,
+		ReadContext:   resourceBizflyCloudScheduledVolumeBackupRead,
+		UpdateContext: resourceBizflyCloudScheduledVolumeBackupUpdate,
+		DeleteContext: resourceBizflyCloudScheduledVolumeBackupDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,7 +65,7 @@ func resourceBizflyCloudScheduledVolumeBackup() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudScheduledVolumeBackupCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudScheduledVolumeBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	log.Println("[DEBUG] Creating scheduled volume backup")
@@ -75,25 +76,25 @@ func resourceBizflyCloudScheduledVolumeBackupCreate(d *schema.ResourceData, meta
 		Hour:       d.Get("scheduled_hour").(int),
 	}
 	log.Printf("[DEBUG] Create scheduled volume backup payload: %#v\n", brq)
-	backup, err := client.CloudServer.ScheduledVolumeBackups().Create(context.Background(), brq)
+	backup, err := client.CloudServer.ScheduledVolumeBackups().Create(ctx, brq)
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Error creating scheduled volume backup: %s", err)
+		return diag.Errorf("[DEBUG] Error creating scheduled volume backup: %s", err)
 	}
 	log.Println("[DEBUG] set id " + backup.ID)
 	d.SetId(backup.ID)
-	return resourceBizflyCloudScheduledVolumeBackupRead(d, meta)
+	return resourceBizflyCloudScheduledVolumeBackupRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudScheduledVolumeBackupRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudScheduledVolumeBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	log.Printf("[DEBUG] Reading scheduled volume backup %s", d.Id())
-	backup, err := client.CloudServer.ScheduledVolumeBackups().Get(context.Background(), d.Id())
+	backup, err := client.CloudServer.ScheduledVolumeBackups().Get(ctx, d.Id())
 	if err != nil {
 		if err == gobizfly.ErrNotFound {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("[DEBUG] Error reading scheduled volume backup: %s", err)
+		return diag.Errorf("[DEBUG] Error reading scheduled volume backup: %s", err)
 	}
 	_ = d.Set("id", backup.ID)
 	_ = d.Set("volume_id", backup.ResourceID)
@@ -109,16 +110,16 @@ func resourceBizflyCloudScheduledVolumeBackupRead(d *schema.ResourceData, meta i
 	return nil
 }
 
-func resourceBizflyCloudScheduledVolumeBackupDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudScheduledVolumeBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudServer.ScheduledVolumeBackups().Delete(context.Background(), d.Id())
+	err := client.CloudServer.ScheduledVolumeBackups().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Error deleting scheduled volume backup: %s", err)
+		return diag.Errorf("[DEBUG] Error deleting scheduled volume backup: %s", err)
 	}
 	return nil
 }
 
-func resourceBizflyCloudScheduledVolumeBackupUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudScheduledVolumeBackupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	log.Printf("[DEBUG] Updating scheduled volume backup %s", d.Id())
 	brq := &gobizfly.UpdateBackupPayload{}
@@ -131,9 +132,9 @@ func resourceBizflyCloudScheduledVolumeBackupUpdate(d *schema.ResourceData, meta
 	if d.HasChange("size") {
 		brq.Size = d.Get("size").(string)
 	}
-	_, err := client.CloudServer.ScheduledVolumeBackups().Update(context.Background(), d.Id(), brq)
+	_, err := client.CloudServer.ScheduledVolumeBackups().Update(ctx, d.Id(), brq)
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Error updating scheduled volume backup: %s", err)
+		return diag.Errorf("[DEBUG] Error updating scheduled volume backup: %s", err)
 	}
-	return resourceBizflyCloudScheduledVolumeBackupRead(d, meta)
+	return resourceBizflyCloudScheduledVolumeBackupRead(ctx, d, meta)
 }
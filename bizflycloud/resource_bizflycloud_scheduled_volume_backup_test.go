@@ -22,8 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"testing"
 )
 
@@ -39,8 +39,8 @@ func TestAccBizflyCloudScheduledVolumeBackup(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudScheduledVolumeBackupDestroy,
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudScheduledVolumeBackupDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudScheduledVolumeBackupConfig(),
@@ -22,14 +22,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/terraform-provider-bizflycloud/waiter"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
@@ -39,26 +40,31 @@ const (
 	lanType            = "LAN"
 	lanWanType         = "LAN_WAN"
 	freeWan            = "free"
+
+	serverServiceName = "cloud_server"
 )
 
 func resourceBizflyCloudServer() *schema.Resource {
 	return &schema.Resource{
-		Create:        resourceBizflyCloudServerCreate,
-		Read:          resourceBizflyCloudServerRead,
-		Update:        resourceBizflyCloudServerUpdate,
-		Delete:        resourceBizflyCloudServerDelete,
+		CreateContext: resourceBizflyCloudServerCreate,
+		ReadContext:   resourceBizflyCloudServerRead,
+		UpdateContext: resourceBizflyCloudServerUpdate,
+		DeleteContext: resourceBizflyCloudServerDelete,
+		CustomizeDiff: resourceBizflyCloudServerCustomizeDiff,
 		SchemaVersion: 1,
 		Schema:        resourceServerSchema(),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 	}
 }
 
-func resourceBizflyCloudServerCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	// Build up creation options
@@ -79,6 +85,7 @@ func resourceBizflyCloudServerCreate(d *schema.ResourceData, meta interface{}) e
 		NetworkPlan:      d.Get("network_plan").(string),
 		BillingPlan:      d.Get("billing_plan").(string),
 		UserData:         d.Get("user_data").(string),
+		DataDisks:        expandServerDataDisks(d.Get("data_disk").([]interface{})),
 	}
 	var (
 		isCreatedWan         bool
@@ -91,10 +98,18 @@ func resourceBizflyCloudServerCreate(d *schema.ResourceData, meta interface{}) e
 	defaultPublicIPv4List := d.Get("default_public_ipv4").([]interface{})
 	defaultPublicIPv6List := d.Get("default_public_ipv6").([]interface{})
 	if len(defaultPublicIPv4List) > 1 {
-		return fmt.Errorf("only one default public ipv4 is allowed")
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "only one default public ipv4 is allowed",
+			AttributePath: cty.GetAttrPath("default_public_ipv4"),
+		}}
 	}
 	if len(defaultPublicIPv6List) > 1 {
-		return fmt.Errorf("only one default public ipv6 is allowed")
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "only one default public ipv6 is allowed",
+			AttributePath: cty.GetAttrPath("default_public_ipv6"),
+		}}
 	}
 	for _, v := range defaultPublicIPv4List {
 		freeWan := v.(map[string]interface{})
@@ -116,27 +131,39 @@ func resourceBizflyCloudServerCreate(d *schema.ResourceData, meta interface{}) e
 	scr.NetworkInterfaces = networkInterfaceIDs
 	scr.IsCreatedWan = &isCreatedWan
 	scr.IPv6 = usingV6Wan
-	log.Printf("[DEBUG] Create Cloud Server configuration: %#v", scr)
+	logRequest := *scr
+	if logRequest.UserData != "" {
+		// user_data commonly carries credentials, keep it out of the logs.
+		logRequest.UserData = "<sensitive>"
+	}
+	log.Printf("[DEBUG] Create Cloud Server configuration: %#v", logRequest)
 
-	tasks, err := client.CloudServer.Create(context.Background(), scr)
+	tasks, err := client.CloudServer.Create(ctx, scr)
 	if err != nil {
-		return fmt.Errorf("error creating server: %s", err)
+		return diag.Errorf("error creating server: %s", err)
 	}
 	// Set ID of server with task ID, we need to change to the real ID after server is created
 	d.SetId(tasks.Task[0])
 	log.Printf("[INFO] Server is creating with task ID: %s", d.Id())
 	// wait for cloud server to become active
-	_, err = waitForServerCreate(d, meta)
+	err = waitForServerCreate(ctx, d, meta)
 	if err != nil {
-		return fmt.Errorf("error creating cloud server with task id (%s): %s", d.Id(), err)
+		return diag.Errorf("error creating cloud server with task id (%s): %s", d.Id(), err)
+	}
+	if len(scr.DataDisks) > 0 {
+		server, err := client.CloudServer.Get(ctx, d.Id())
+		if err != nil {
+			return diag.Errorf("error retrieving server: %v", err)
+		}
+		setServerDataDiskIDs(d, server.AttachedVolumes)
 	}
 
-	ports, err := client.CloudServer.NetworkInterfaces().List(context.Background(), &gobizfly.ListNetworkInterfaceOptions{
+	ports, err := client.CloudServer.NetworkInterfaces().List(ctx, &gobizfly.ListNetworkInterfaceOptions{
 		Type:   lanWanType,
 		Status: "ACTIVE",
 	})
 	if err != nil {
-		return fmt.Errorf("error listing ports: %v", err)
+		return diag.Errorf("error listing ports: %v", err)
 	}
 	var wg sync.WaitGroup
 	errChan := make(chan error, len(ports))
@@ -149,7 +176,7 @@ func resourceBizflyCloudServerCreate(d *schema.ResourceData, meta interface{}) e
 				wg.Add(1)
 				go func(portID string) {
 					defer wg.Done()
-					if err := attachFirewallsForPort(client, portID, freeWanV6FirewallIDs); err != nil {
+					if err := attachFirewallsForPort(ctx, client, portID, freeWanV6FirewallIDs); err != nil {
 						errChan <- fmt.Errorf("error attaching firewall for port %s: %v", portID, err)
 					}
 				}(port.ID)
@@ -157,7 +184,7 @@ func resourceBizflyCloudServerCreate(d *schema.ResourceData, meta interface{}) e
 				wg.Add(1)
 				go func(portID string) {
 					defer wg.Done()
-					if err := attachFirewallsForPort(client, portID, freeWanV4FirewallIDs); err != nil {
+					if err := attachFirewallsForPort(ctx, client, portID, freeWanV4FirewallIDs); err != nil {
 						errChan <- fmt.Errorf("error attaching firewall for port %s: %v", portID, err)
 					}
 				}(port.ID)
@@ -181,7 +208,7 @@ func resourceBizflyCloudServerCreate(d *schema.ResourceData, meta interface{}) e
 						payload := gobizfly.ActionNetworkInterfacePayload{
 							Action: action,
 						}
-						if _, err := client.CloudServer.NetworkInterfaces().Action(context.Background(), netInterfaceID, &payload); err != nil {
+						if _, err := client.CloudServer.NetworkInterfaces().Action(ctx, netInterfaceID, &payload); err != nil {
 							errChan <- fmt.Errorf("error %s network interface %s: %v", action, netInterfaceID, err)
 						}
 					}(portID, action)
@@ -191,33 +218,33 @@ func resourceBizflyCloudServerCreate(d *schema.ResourceData, meta interface{}) e
 	}
 	wg.Wait()
 	if len(errChan) > 0 {
-		return <-errChan
+		return diag.FromErr(<-errChan)
 	}
-	return resourceBizflyCloudServerRead(d, meta)
+	return resourceBizflyCloudServerRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudServerRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	server, err := client.CloudServer.Get(context.Background(), d.Id())
+	server, err := client.CloudServer.Get(ctx, d.Id())
 	if err != nil {
 		if errors.Is(err, gobizfly.ErrNotFound) {
 			log.Printf("[WARN] Bizfly Cloud Server (%s) is not found", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error retrieving server: %v", err)
+		return diag.Errorf("error retrieving server: %v", err)
 	}
-	rootDisk, err := getServerRootDisk(client, server.ID)
+	rootDisk, err := getServerRootDisk(ctx, client, server.ID)
 	if err != nil {
 		log.Printf("[WARN] get rootdisk of server %s failed: %+v", server.ID, err)
-		return err
+		return diag.FromErr(err)
 	}
-	networkInterfaces, _ := client.CloudServer.NetworkInterfaces().List(context.Background(), &gobizfly.ListNetworkInterfaceOptions{
+	networkInterfaces, _ := client.CloudServer.NetworkInterfaces().List(ctx, &gobizfly.ListNetworkInterfaceOptions{
 		Type: lanWanType,
 	})
-	firewalls, err := client.CloudServer.Firewalls().List(context.Background(), &gobizfly.ListOptions{})
+	firewalls, err := client.CloudServer.Firewalls().List(ctx, &gobizfly.ListOptions{})
 	if err != nil {
-		return fmt.Errorf("error listing firewalls: %v", err)
+		return diag.Errorf("error listing firewalls: %v", err)
 	}
 	userFirewallIDs := make([]string, len(firewalls))
 	for i, firewall := range firewalls {
@@ -279,7 +306,14 @@ func resourceBizflyCloudServerRead(d *schema.ResourceData, meta interface{}) err
 	_ = d.Set("vpc_network_ids", vpcNetworkIDs)
 	_ = d.Set("network_interfaces", serverNetworkInterfaces)
 	if err = d.Set("volume_ids", flatternBizflyCloudVolumeIDs(server.AttachedVolumes)); err != nil {
-		return fmt.Errorf("error setting `volume_ids`: %+v", err)
+		return diag.Errorf("error setting `volume_ids`: %+v", err)
+	}
+	dataDisks, err := readServerDataDisks(ctx, client, d.Get("data_disk").([]interface{}), server.AttachedVolumes)
+	if err != nil {
+		return diag.Errorf("error reading data disks of server %s: %v", server.ID, err)
+	}
+	if err := d.Set("data_disk", dataDisks); err != nil {
+		return diag.Errorf("error setting `data_disk`: %+v", err)
 	}
 	_ = d.Set("root_disk_id", rootDisk.ID)
 	// Hardcode in here
@@ -293,96 +327,156 @@ func resourceBizflyCloudServerRead(d *schema.ResourceData, meta interface{}) err
 	_ = d.Set("root_disk_volume_type", rootDisk.VolumeType)
 	_ = d.Set("root_disk_size", rootDisk.Size)
 
-	var state string
-	switch server.Status {
-	case "ACTIVE":
-		state = "running"
-	case "SHUTOFF":
-		state = "stopped"
-	default:
-		state = server.Status
+	_ = d.Set("state", serverState(server.Status))
+
+	// The console is only available while the server runs, and its URL
+	// expires, so a new one is fetched on every read. Each fetch issues a
+	// new console token, which is why it is opt-in.
+	_ = d.Set("console_url", "")
+	if server.Status == "ACTIVE" && d.Get("fetch_console_url").(bool) {
+		console, err := client.CloudServer.GetVNC(ctx, server.ID)
+		if err != nil {
+			log.Printf("[WARN] Error getting console of server %s: %v", server.ID, err)
+		} else {
+			_ = d.Set("console_url", console.URL)
+		}
 	}
-	_ = d.Set("state", state)
 	return nil
 }
 
-func resourceBizflyCloudServerUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	id := d.Id()
 	if d.HasChange("name") {
 		// Rename server
 		newName := d.Get("name").(string)
-		if err := client.CloudServer.Rename(context.Background(), id, newName); err != nil {
-			return fmt.Errorf("error when rename server [%s]: %v", id, err)
+		if err := client.CloudServer.Rename(ctx, id, newName); err != nil {
+			return diag.Errorf("error when rename server [%s]: %v", id, err)
 		}
 	}
 	if d.HasChange("flavor_name") {
 		// Resize server to new flavor
-		task, err := client.CloudServer.Resize(context.Background(), id, d.Get("flavor_name").(string))
+		task, err := client.CloudServer.Resize(ctx, id, d.Get("flavor_name").(string))
 		if err != nil {
-			return fmt.Errorf("error when resize server [%s]: %v", id, err)
+			return diag.Errorf("error when resize server [%s]: %v", id, err)
 		}
 		// wait for server is active again
-		_, err = waitForServerUpdate(d, meta, task.TaskID)
+		err = waitForServerUpdate(ctx, d, meta, task.TaskID)
 		if err != nil {
-			return fmt.Errorf("error updating cloud server with task id (%s): %s", d.Id(), err)
+			return diag.Errorf("error updating cloud server with task id (%s): %s", d.Id(), err)
+		}
+	}
+	if d.HasChanges("os_type", "os_id") && d.Get("rebuild_on_image_change").(bool) {
+		// Reinstall the server from the new image, keeping its disks and
+		// network interfaces. The os_type is checked when planning.
+		task, err := client.CloudServer.Rebuild(ctx, id, d.Get("os_id").(string))
+		if err != nil {
+			return diag.Errorf("error when rebuild server [%s]: %v", id, err)
+		}
+		// wait for server is active again
+		err = waitForServerUpdate(ctx, d, meta, task.TaskID)
+		if err != nil {
+			return diag.Errorf("error updating cloud server with task id (%s): %s", d.Id(), err)
 		}
 	}
 	if d.HasChange("category") {
 		// Change category of the server
-		task, err := client.CloudServer.ChangeCategory(context.Background(), id, d.Get("category").(string))
+		task, err := client.CloudServer.ChangeCategory(ctx, id, d.Get("category").(string))
 		if err != nil {
-			return fmt.Errorf("error when change category of server [%s]: %v", id, err)
+			return diag.Errorf("error when change category of server [%s]: %v", id, err)
 		}
 		// wait for server is active again
-		_, err = waitForServerUpdate(d, meta, task.TaskID)
+		err = waitForServerUpdate(ctx, d, meta, task.TaskID)
 		if err != nil {
-			return fmt.Errorf("error updating cloud server with task id (%s): %s", d.Id(), err)
+			return diag.Errorf("error updating cloud server with task id (%s): %s", d.Id(), err)
 		}
 	}
 
 	if d.HasChanges("network_plan") {
 		_, newNetworkPlan := d.GetChange("network_plan")
-		err := client.CloudServer.ChangeNetworkPlan(context.Background(), d.Id(), newNetworkPlan.(string))
+		err := client.CloudServer.ChangeNetworkPlan(ctx, d.Id(), newNetworkPlan.(string))
 		if err != nil {
-			return fmt.Errorf("error changing network plan of server [%s]: %v", d.Id(), err)
+			return diag.Errorf("error changing network plan of server [%s]: %v", d.Id(), err)
 		}
 	}
 	if d.HasChange("billing_plan") {
 		_, newBillingPlan := d.GetChange("billing_plan")
-		err := client.CloudServer.SwitchBillingPlan(context.Background(), d.Id(), newBillingPlan.(string))
+		err := client.CloudServer.SwitchBillingPlan(ctx, d.Id(), newBillingPlan.(string))
 		if err != nil {
-			return fmt.Errorf("error changing billing plan of server [%s]: %v", d.Id(), err)
+			return diag.Errorf("error changing billing plan of server [%s]: %v", d.Id(), err)
 		}
 	}
 	if d.HasChange("default_public_ipv4") {
-		if err := updateFreeWantPort(d, client, "default_public_ipv4"); err != nil {
+		if err := updateFreeWantPort(ctx, d, client, "default_public_ipv4"); err != nil {
 			log.Printf("[ERROR] Error updating free wan port: %v", err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if d.HasChange("default_public_ipv6") {
-		if err := updateFreeWantPort(d, client, "default_public_ipv6"); err != nil {
+		if err := updateFreeWantPort(ctx, d, client, "default_public_ipv6"); err != nil {
 			log.Printf("[ERROR] Error updating free wan port: %v", err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if d.HasChange("state") {
 		oldState, newState := d.GetChange("state")
 		if oldState.(string) == "ERROR" {
-			return fmt.Errorf("cannot change server state because server state is %s", oldState.(string))
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("cannot change server state because server state is %s", oldState.(string)),
+				AttributePath: cty.GetAttrPath("state"),
+			}}
 		}
+		var status string
 		switch newState.(string) {
 		case "running":
-			_, err := client.CloudServer.Start(context.Background(), d.Id())
+			_, err := client.CloudServer.Start(ctx, d.Id())
 			if err != nil {
-				return fmt.Errorf("error changing state of server: %v", err)
+				return diag.Errorf("error changing state of server: %v", err)
 			}
+			status = "ACTIVE"
 		case "stopped":
-			_, err := client.CloudServer.Stop(context.Background(), d.Id())
+			_, err := client.CloudServer.Stop(ctx, d.Id())
 			if err != nil {
-				return fmt.Errorf("error changing state of server: %v", err)
+				return diag.Errorf("error changing state of server: %v", err)
 			}
+			status = "SHUTOFF"
+		}
+		if err := waitForServerStatus(ctx, client, waiter.Config{
+			Description: fmt.Sprintf("server (%s) to be %s", d.Id(), newState.(string)),
+			Target:      []string{status},
+			Timeout:     d.Timeout(schema.TimeoutUpdate),
+			Delay:       5 * time.Second,
+		}, d.Id()); err != nil {
+			return diag.Errorf("error changing state of server: %v", err)
+		}
+	}
+	var diags diag.Diagnostics
+	if d.HasChange("reboot_trigger") && d.Get("state").(string) != "running" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "server is not rebooted",
+			Detail:        fmt.Sprintf("reboot_trigger changed but server %s is %s, only a running server is rebooted", d.Id(), d.Get("state").(string)),
+			AttributePath: cty.GetAttrPath("reboot_trigger"),
+		})
+	} else if d.HasChange("reboot_trigger") {
+		var err error
+		if d.Get("reboot_type").(string) == "hard" {
+			_, err = client.CloudServer.HardReboot(ctx, d.Id())
+		} else {
+			_, err = client.CloudServer.SoftReboot(ctx, d.Id())
+		}
+		if err != nil {
+			return diag.Errorf("error rebooting server [%s]: %v", d.Id(), err)
+		}
+		if err := waitForServerStatus(ctx, client, waiter.Config{
+			Description: fmt.Sprintf("server (%s) to be rebooted", d.Id()),
+			Pending:     []string{"REBOOT", "HARD_REBOOT"},
+			Target:      []string{"ACTIVE"},
+			Timeout:     d.Timeout(schema.TimeoutUpdate),
+			Delay:       10 * time.Second,
+		}, d.Id()); err != nil {
+			return diag.Errorf("error rebooting server [%s]: %v", d.Id(), err)
 		}
 	}
 	if d.HasChange("root_disk_size") {
@@ -390,31 +484,40 @@ func resourceBizflyCloudServerUpdate(d *schema.ResourceData, meta interface{}) e
 		oldRootDiskSize, newRootDiskSize := d.GetChange("root_disk_size")
 		oldRootDiskSizeInt, newRootDiskSizeInt := oldRootDiskSize.(int), newRootDiskSize.(int)
 		if oldRootDiskSizeInt >= newRootDiskSizeInt {
-			return fmt.Errorf("new rootdisk size must be greater than %d to extend rootdisk", oldRootDiskSizeInt)
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("new rootdisk size must be greater than %d to extend rootdisk", oldRootDiskSizeInt),
+				AttributePath: cty.GetAttrPath("root_disk_size"),
+			}}
 		}
-		task, err := client.CloudServer.Volumes().ExtendVolume(context.Background(), rootDiskID, newRootDiskSizeInt)
+		task, err := client.CloudServer.Volumes().ExtendVolume(ctx, rootDiskID, newRootDiskSizeInt)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
-		_, err = waitToExtendVolume(d, meta, task.TaskID)
+		err = waitToExtendVolume(ctx, d, meta, task.TaskID)
 		if err != nil {
-			return fmt.Errorf("wait to check extend rootdisk error: %v", err)
+			return diag.Errorf("wait to check extend rootdisk error: %v", err)
 		}
 	}
 	if d.HasChange("network_interfaces") {
-		if err := changeServerNetworkInterfaces(d, client); err != nil {
-			return err
+		if err := changeServerNetworkInterfaces(ctx, d, client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange("data_disk") {
+		if err := updateServerDataDisks(ctx, d, meta); err != nil {
+			return diag.FromErr(err)
 		}
 	}
-	return resourceBizflyCloudServerRead(d, meta)
+	return append(diags, resourceBizflyCloudServerRead(ctx, d, meta)...)
 }
 
-func resourceBizflyCloudServerDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	// destroy the cloud server
-	server, err := client.CloudServer.Get(context.Background(), d.Id())
+	server, err := client.CloudServer.Get(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error retrieving cloud server: %v", err)
+		return diag.Errorf("error retrieving cloud server: %v", err)
 	}
 	var rootDiskID string
 	for _, v := range server.AttachedVolumes {
@@ -422,169 +525,310 @@ func resourceBizflyCloudServerDelete(d *schema.ResourceData, meta interface{}) e
 			rootDiskID = v.ID
 		}
 	}
-	task, err := client.CloudServer.Delete(context.Background(), d.Id(), []string{rootDiskID})
+	deletedVolumeIDs := []string{rootDiskID}
+	for _, v := range d.Get("data_disk").([]interface{}) {
+		disk := v.(map[string]interface{})
+		if disk["delete_on_termination"].(bool) && disk["id"].(string) != "" {
+			deletedVolumeIDs = append(deletedVolumeIDs, disk["id"].(string))
+		}
+	}
+	task, err := client.CloudServer.Delete(ctx, d.Id(), deletedVolumeIDs)
 	if err != nil {
-		return fmt.Errorf("error delete cloud server %v", err)
+		return diag.Errorf("error delete cloud server %v", err)
 	}
 
-	_, err = waitforServerDelete(d, meta, task.TaskID)
+	err = waitforServerDelete(ctx, d, meta, task.TaskID)
 	if err != nil && !errors.Is(err, gobizfly.ErrNotFound) {
-		return fmt.Errorf("error delete cloud server with task id (%s): %s", d.Id(), err)
+		return diag.Errorf("error delete cloud server with task id (%s): %s", d.Id(), err)
 	}
 	return nil
 }
 
-func waitForServerCreate(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	log.Printf("[INFO] Waiting for server with task id (%s) to be created", d.Id())
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"BUILD"},
-		Target:         []string{"ACTIVE"},
-		Refresh:        newServerStateRefreshFunc(d, "status", meta),
-		Timeout:        1200 * time.Second,
-		Delay:          20 * time.Second,
-		MinTimeout:     3 * time.Second,
-		NotFoundChecks: 100,
+func waitForServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	taskID := d.Id()
+	result, err := waitForServerTask(ctx, client, waiter.Config{
+		Description: fmt.Sprintf("server with task id (%s) to be created", taskID),
+		Timeout:     d.Timeout(schema.TimeoutCreate),
+		Delay:       20 * time.Second,
+	}, taskID)
+	if err != nil {
+		return err
+	}
+	// server is ready now, set ID for resourceData
+	d.SetId(result.Result.ID)
+	return waitForServerStatus(ctx, client, waiter.Config{
+		Description: fmt.Sprintf("server (%s) to become active", d.Id()),
+		Pending:     []string{"BUILD"},
+		Target:      []string{"ACTIVE"},
+		Timeout:     d.Timeout(schema.TimeoutCreate),
+	}, d.Id())
+}
+
+// resourceBizflyCloudServerCustomizeDiff rejects the changes which cannot be
+// made in place, before any other change of the same plan is applied: a
+// rebuild only takes an image, and a data disk can only grow and keeps its
+// volume type.
+func resourceBizflyCloudServerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChanges("os_type", "os_id") && d.Get("rebuild_on_image_change").(bool) && d.NewValueKnown("os_type") {
+		if osType := d.Get("os_type").(string); osType != "image" {
+			return fmt.Errorf("cannot rebuild server from os_type %s, only image is supported", osType)
+		}
+	}
+	if !d.HasChange("data_disk") {
+		return nil
+	}
+	o, n := d.GetChange("data_disk")
+	oldDisks, newDisks := o.([]interface{}), n.([]interface{})
+	for i := 0; i < len(oldDisks) && i < len(newDisks); i++ {
+		oldDisk, newDisk := oldDisks[i].(map[string]interface{}), newDisks[i].(map[string]interface{})
+		if newDisk["size"].(int) < oldDisk["size"].(int) {
+			return fmt.Errorf("data_disk.%d: new size must be greater than %d to extend the disk", i, oldDisk["size"])
+		}
+		if newDisk["volume_type"] != oldDisk["volume_type"] {
+			return fmt.Errorf("data_disk.%d: volume_type cannot be changed, remove the disk and add a new one instead", i)
+		}
 	}
-	return stateConf.WaitForState()
+	return nil
 }
 
-func waitforServerDelete(d *schema.ResourceData, meta interface{}, taskID string) (interface{}, error) {
-	log.Printf("[INFO] Waiting for server with task id (%s) to be deleted", d.Id())
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"false"},
-		Target:     []string{"true"},
-		Refresh:    waitToDeleteServerRefreshFunc(d, meta, taskID),
-		Timeout:    600 * time.Second,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+func expandServerDataDisks(disks []interface{}) []*gobizfly.ServerDisk {
+	result := make([]*gobizfly.ServerDisk, 0, len(disks))
+	for _, v := range disks {
+		disk := v.(map[string]interface{})
+		volumeType := disk["volume_type"].(string)
+		result = append(result, &gobizfly.ServerDisk{
+			Size:       disk["size"].(int),
+			VolumeType: &volumeType,
+		})
 	}
-	return stateConf.WaitForState()
+	return result
 }
 
-func waitForServerUpdate(d *schema.ResourceData, meta interface{}, taskID string) (interface{}, error) {
-	log.Printf("[INFO] Waiting for server with task id (%s) to be created", d.Id())
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"HARD_REBOOT", "MIGRATING", "REBUILD", "RESIZE"},
-		Target:     []string{"ACTIVE"},
-		Refresh:    updateServerStateRefreshFunc(d, "status", meta, taskID),
-		Timeout:    600 * time.Second,
-		Delay:      20 * time.Second,
-		MinTimeout: 3 * time.Second,
+// setServerDataDiskIDs records the volumes created for the data_disk blocks
+// with the server. The disks are matched in order, each with the first
+// attached data disk of its size which is not matched yet.
+func setServerDataDiskIDs(d *schema.ResourceData, attached []gobizfly.AttachedVolume) {
+	claimed := make(map[string]bool)
+	disks := d.Get("data_disk").([]interface{})
+	for _, v := range disks {
+		disk := v.(map[string]interface{})
+		for _, volume := range attached {
+			if volume.AttachedType != attachTypeDataDisk || claimed[volume.ID] || volume.Size != disk["size"].(int) {
+				continue
+			}
+			claimed[volume.ID] = true
+			disk["id"] = volume.ID
+			break
+		}
 	}
-	return stateConf.WaitForState()
+	_ = d.Set("data_disk", disks)
 }
 
-func newServerStateRefreshFunc(d *schema.ResourceData, attribute string, meta interface{}) resource.StateRefreshFunc {
-	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
-		// Get task result from cloud server API
-		resp, err := client.CloudServer.GetTask(context.Background(), d.Id())
-		if err != nil {
-			return nil, "", err
+// readServerDataDisks refreshes the data_disk blocks, dropping the disks which
+// are no longer attached to the server.
+func readServerDataDisks(ctx context.Context, client *gobizfly.Client, disks []interface{}, attached []gobizfly.AttachedVolume) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(disks))
+	for _, v := range disks {
+		disk := v.(map[string]interface{})
+		id := disk["id"].(string)
+		found := false
+		for _, volume := range attached {
+			if volume.ID == id && volume.AttachedType == attachTypeDataDisk {
+				found = true
+				break
+			}
 		}
-		// if the task is not ready, we need to wait for a moment
-		if !resp.Ready {
-			log.Println("[DEBUG] Cloud Server is not ready")
-			return nil, "", nil
+		if !found {
+			log.Printf("[WARN] Data disk %s is no longer attached to the server", id)
+			continue
 		}
-		// server is ready now, set ID for resourceData
-		d.SetId(resp.Result.ID)
-		err = resourceBizflyCloudServerRead(d, meta)
+		volume, err := client.CloudServer.Volumes().Get(ctx, id)
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
+		result = append(result, map[string]interface{}{
+			"id":                    volume.ID,
+			"size":                  volume.Size,
+			"volume_type":           volume.VolumeType,
+			"delete_on_termination": disk["delete_on_termination"],
+		})
+	}
+	return result, nil
+}
 
-		if attr, ok := d.GetOk(attribute); ok { // nolint
-			server, err := client.CloudServer.Get(context.Background(), resp.Result.ID)
-			if err != nil {
-				return nil, "", fmt.Errorf("error retrieving cloud server: %v", err)
+// updateServerDataDisks extends the data disks which grew, attaches the new
+// ones and detaches the ones removed from the end of the list. New disks are
+// attached one at a time, in order, so that they get their device names in
+// the order of the data_disk blocks.
+func updateServerDataDisks(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	serverID := d.Id()
+	o, n := d.GetChange("data_disk")
+	oldDisks, newDisks := o.([]interface{}), n.([]interface{})
+
+	for i := len(newDisks); i < len(oldDisks); i++ {
+		disk := oldDisks[i].(map[string]interface{})
+		volumeID := disk["id"].(string)
+		if _, err := client.CloudServer.Volumes().Detach(ctx, volumeID, serverID); err != nil {
+			return fmt.Errorf("error detaching data disk %s: %v", volumeID, err)
+		}
+		if err := waitForVolumeStatus(ctx, client, volumeID, "available", d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+		if disk["delete_on_termination"].(bool) {
+			if err := client.CloudServer.Volumes().Delete(ctx, volumeID); err != nil {
+				return fmt.Errorf("error deleting data disk %s: %v", volumeID, err)
 			}
-			_ = server // ensure variable is recognized as used
-			switch attr := attr.(type) {
-			case bool:
-				return &server, strconv.FormatBool(attr), nil
-			default:
-				return &server, attr.(string), nil
+		}
+	}
+
+	for i, v := range newDisks {
+		disk := v.(map[string]interface{})
+		if i < len(oldDisks) {
+			if disk["size"].(int) > oldDisks[i].(map[string]interface{})["size"].(int) {
+				task, err := client.CloudServer.Volumes().ExtendVolume(ctx, disk["id"].(string), disk["size"].(int))
+				if err != nil {
+					return fmt.Errorf("error extending data disk %s: %v", disk["id"], err)
+				}
+				if err := waitToExtendVolume(ctx, d, meta, task.TaskID); err != nil {
+					return fmt.Errorf("wait to check extend data disk error: %v", err)
+				}
 			}
+			continue
+		}
+		volume, err := client.CloudServer.Volumes().Create(ctx, &gobizfly.VolumeCreateRequest{
+			Name:             fmt.Sprintf("%s-datadisk-%d", d.Get("name").(string), i),
+			Size:             disk["size"].(int),
+			VolumeType:       disk["volume_type"].(string),
+			AvailabilityZone: d.Get("availability_zone").(string),
+			BillingPlan:      d.Get("billing_plan").(string),
+		})
+		if err != nil {
+			return fmt.Errorf("error creating data disk: %v", err)
+		}
+		disk["id"] = volume.ID
+		// keep track of the disk even if attaching it fails
+		_ = d.Set("data_disk", newDisks[:i+1])
+		if err := waitForVolumeStatus(ctx, client, volume.ID, "available", d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+		if _, err := client.CloudServer.Volumes().Attach(ctx, volume.ID, serverID); err != nil {
+			return fmt.Errorf("error attaching data disk %s: %v", volume.ID, err)
+		}
+		if err := waitForVolumeStatus(ctx, client, volume.ID, "in-use", d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
 		}
-		return nil, "", nil
 	}
+	_ = d.Set("data_disk", newDisks)
+	return nil
 }
 
-func updateServerStateRefreshFunc(d *schema.ResourceData, attribute string, meta interface{}, taskID string) resource.StateRefreshFunc {
-	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
-		// Get task result from cloud server API
-		resp, err := client.CloudServer.GetTask(context.Background(), taskID)
+func waitForVolumeStatus(ctx context.Context, client *gobizfly.Client, volumeID, status string, timeout time.Duration) error {
+	conf := waiter.Config{
+		Description: fmt.Sprintf("volume (%s) to be %s", volumeID, status),
+		Target:      []string{status},
+		Timeout:     timeout,
+		Delay:       3 * time.Second,
+	}
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		volume, err := client.CloudServer.Volumes().Get(ctx, volumeID)
 		if err != nil {
 			return nil, "", err
 		}
-		// if the task is not ready, we need to wait for a moment
-		if !resp.Ready {
-			log.Println("[DEBUG] Cloud Server is not ready")
-			return nil, "", nil
+		return volume, volume.Status, nil
+	})
+	return err
+}
+
+func waitforServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, taskID string) error {
+	client := meta.(*CombinedConfig).gobizflyClient()
+	conf := waiter.Config{
+		Description: fmt.Sprintf("server (%s) to be deleted", d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutDelete),
+		Delay:       10 * time.Second,
+	}
+	return conf.ForDeletion(ctx, func(ctx context.Context) (interface{}, string, error) {
+		server, err := client.CloudServer.Get(ctx, d.Id())
+		if err != nil {
+			return nil, "", err
 		}
-		err = resourceBizflyCloudServerRead(d, meta)
+		task, err := client.CloudServer.GetTask(ctx, taskID)
 		if err != nil {
 			return nil, "", err
 		}
-		if attr, ok := d.GetOk(attribute); ok { // nolint
-			server, err := client.CloudServer.Get(context.Background(), d.Id())
-			if err != nil {
-				return nil, "", fmt.Errorf("error retrieving cloud server: %v", err)
-			}
-			_ = server // ensure variable is recognized as used
-			switch attr := attr.(type) {
-			case bool:
-				return &server, strconv.FormatBool(attr), nil
-			default:
-				return &server, attr.(string), nil
-			}
+		if task.Ready {
+			return server, waiter.StatusDeleted, nil
 		}
-		return nil, "", nil
-	}
+		return server, server.Status, nil
+	})
 }
 
-func waitToDeleteServerRefreshFunc(d *schema.ResourceData, meta interface{}, taskID string) resource.StateRefreshFunc {
+func waitForServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, taskID string) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
+	if _, err := waitForServerTask(ctx, client, waiter.Config{
+		Description: fmt.Sprintf("server (%s) update task (%s) to complete", d.Id(), taskID),
+		Timeout:     d.Timeout(schema.TimeoutUpdate),
+		Delay:       20 * time.Second,
+	}, taskID); err != nil {
+		return err
+	}
+	return waitForServerStatus(ctx, client, waiter.Config{
+		Description: fmt.Sprintf("server (%s) to become active", d.Id()),
+		Pending:     []string{"HARD_REBOOT", "MIGRATING", "REBUILD", "RESIZE"},
+		Target:      []string{"ACTIVE"},
+		Timeout:     d.Timeout(schema.TimeoutUpdate),
+	}, d.Id())
+}
 
-		resp, err := client.CloudServer.GetTask(context.Background(), taskID)
+// waitForServerTask waits for a cloud server task to complete and returns it.
+func waitForServerTask(ctx context.Context, client *gobizfly.Client, conf waiter.Config, taskID string) (*gobizfly.ServerTaskResponse, error) {
+	result, err := conf.ForTask(ctx, func(ctx context.Context) (interface{}, bool, error) {
+		resp, err := client.CloudServer.GetTask(ctx, taskID)
 		if err != nil {
-			return nil, "false", err
+			return nil, false, err
 		}
-		server, err := client.CloudServer.Get(context.Background(), d.Id())
-		if errors.Is(err, gobizfly.ErrNotFound) {
-			return server, "true", nil
-		} else if err != nil {
-			return nil, "false", err
-		}
-		return server, strconv.FormatBool(resp.Ready), nil
+		return resp, resp.Ready, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return result.(*gobizfly.ServerTaskResponse), nil
 }
 
-func waitToExtendVolume(d *schema.ResourceData, meta interface{}, taskID string) (interface{}, error) {
-	log.Printf("[INFO] Waiting for volume with task id (%s) to be extend", d.Id())
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"false"},
-		Target:     []string{"true"},
-		Refresh:    extendVolumeRefreshFunc(meta, taskID),
-		Timeout:    600 * time.Second,
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	return stateConf.WaitForState()
+func waitForServerStatus(ctx context.Context, client *gobizfly.Client, conf waiter.Config, serverID string) error {
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		server, err := client.CloudServer.Get(ctx, serverID)
+		if err != nil {
+			return nil, "", fmt.Errorf("error retrieving cloud server: %w", err)
+		}
+		return server, server.Status, nil
+	})
+	return err
 }
 
-func extendVolumeRefreshFunc(meta interface{}, taskID string) resource.StateRefreshFunc {
+func waitToExtendVolume(ctx context.Context, d *schema.ResourceData, meta interface{}, taskID string) error {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	return func() (interface{}, string, error) {
+	_, err := waitForServerTask(ctx, client, waiter.Config{
+		Description: fmt.Sprintf("root disk of server (%s) to be extended", d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutUpdate),
+		Delay:       5 * time.Second,
+	}, taskID)
+	return err
+}
 
-		resp, err := client.CloudServer.GetTask(context.Background(), taskID)
-		if err != nil {
-			return nil, "false", err
-		}
-		return resp, strconv.FormatBool(resp.Ready), nil
+// serverState maps the status of a server to the values of the state argument.
+func serverState(status string) string {
+	switch status {
+	case "ACTIVE":
+		return "running"
+	case "SHUTOFF":
+		return "stopped"
+	default:
+		return status
 	}
 }
 
@@ -593,7 +837,11 @@ func formatFlavor(s string) string {
 	if strings.Contains(s, ".") {
 		return strings.Split(s, ".")[1]
 	}
-	return strings.Join(strings.Split(s, "_")[:2], "_")
+	parts := strings.Split(s, "_")
+	if len(parts) < 2 {
+		return s
+	}
+	return strings.Join(parts[:2], "_")
 }
 
 func flatternBizflyCloudVolumeIDs(volumeids []gobizfly.AttachedVolume) *schema.Set {
@@ -633,11 +881,11 @@ func leftDiff(left, right map[string]struct{}) map[string]struct{} {
 	return out
 }
 
-func attachFirewallsForPort(client *gobizfly.Client, portID string, firewallIDs []string) error {
+func attachFirewallsForPort(ctx context.Context, client *gobizfly.Client, portID string, firewallIDs []string) error {
 	if len(firewallIDs) == 0 {
 		return nil
 	}
-	_, err := client.CloudServer.NetworkInterfaces().Action(context.Background(), portID,
+	_, err := client.CloudServer.NetworkInterfaces().Action(ctx, portID,
 		&gobizfly.ActionNetworkInterfacePayload{
 			Action:         "add_firewall",
 			SecurityGroups: firewallIDs,
@@ -646,11 +894,11 @@ func attachFirewallsForPort(client *gobizfly.Client, portID string, firewallIDs
 
 }
 
-func detachFirewallsForPort(client *gobizfly.Client, portID string, firewallIDs []string) error {
+func detachFirewallsForPort(ctx context.Context, client *gobizfly.Client, portID string, firewallIDs []string) error {
 	if len(firewallIDs) == 0 {
 		return nil
 	}
-	_, err := client.CloudServer.NetworkInterfaces().Action(context.Background(), portID,
+	_, err := client.CloudServer.NetworkInterfaces().Action(ctx, portID,
 		&gobizfly.ActionNetworkInterfacePayload{
 			Action:         "remove_firewall",
 			SecurityGroups: firewallIDs,
@@ -658,8 +906,8 @@ func detachFirewallsForPort(client *gobizfly.Client, portID string, firewallIDs
 	return err
 }
 
-func attachServerForPort(client *gobizfly.Client, serverID, portID string) error {
-	_, err := client.CloudServer.NetworkInterfaces().Action(context.Background(), portID,
+func attachServerForPort(ctx context.Context, client *gobizfly.Client, serverID, portID string) error {
+	_, err := client.CloudServer.NetworkInterfaces().Action(ctx, portID,
 		&gobizfly.ActionNetworkInterfacePayload{
 			Action:   "attach_server",
 			ServerID: serverID,
@@ -667,8 +915,8 @@ func attachServerForPort(client *gobizfly.Client, serverID, portID string) error
 	return err
 }
 
-func detachServerForPort(client *gobizfly.Client, portID string) error {
-	_, err := client.CloudServer.NetworkInterfaces().Action(context.Background(), portID,
+func detachServerForPort(ctx context.Context, client *gobizfly.Client, portID string) error {
+	_, err := client.CloudServer.NetworkInterfaces().Action(ctx, portID,
 		&gobizfly.ActionNetworkInterfacePayload{
 			Action: "detach_server",
 		})
@@ -684,13 +932,13 @@ func checkIDInList(id string, IDs []string) bool {
 	return false
 }
 
-func enableIpv6ForServer(client *gobizfly.Client, serverID string) (wanIpv6 *gobizfly.CloudServerPublicNetworkInterface, err error) {
-	enableErr := client.CloudServer.EnableIPv6(context.Background(), serverID)
+func enableIpv6ForServer(ctx context.Context, client *gobizfly.Client, serverID string) (wanIpv6 *gobizfly.CloudServerPublicNetworkInterface, err error) {
+	enableErr := client.CloudServer.EnableIPv6(ctx, serverID)
 	if enableErr != nil {
 		err = fmt.Errorf("error enable ipv6 for server %s failed: %v", serverID, enableErr)
 		return
 	}
-	wanIps, wanIpsErr := client.CloudServer.PublicNetworkInterfaces().List(context.Background())
+	wanIps, wanIpsErr := client.CloudServer.PublicNetworkInterfaces().List(ctx)
 	if wanIpsErr != nil {
 		err = fmt.Errorf("error list wan ip failed: %v", wanIpsErr)
 		return
@@ -710,7 +958,7 @@ func enableIpv6ForServer(client *gobizfly.Client, serverID string) (wanIpv6 *gob
 	return
 }
 
-func updateFreeWantPort(d *schema.ResourceData, client *gobizfly.Client, field string) error {
+func updateFreeWantPort(ctx context.Context, d *schema.ResourceData, client *gobizfly.Client, field string) error {
 	oldPublicIP, newPublicIP := d.GetChange(field)
 	newPublicIPList := newPublicIP.([]interface{})
 	oldPublicIPList := oldPublicIP.([]interface{})
@@ -731,7 +979,7 @@ func updateFreeWantPort(d *schema.ResourceData, client *gobizfly.Client, field s
 	if len(oldPublicIPList) == 0 && len(newPublicIPList) == 1 {
 		if field == "default_public_ipv6" {
 			serverID := d.Id()
-			wanIpv6, err := enableIpv6ForServer(client, serverID)
+			wanIpv6, err := enableIpv6ForServer(ctx, client, serverID)
 			if err != nil {
 				return err
 			}
@@ -795,7 +1043,7 @@ func updateFreeWantPort(d *schema.ResourceData, client *gobizfly.Client, field s
 		wg.Add(1)
 		go func(portID string) {
 			defer wg.Done()
-			if err := client.CloudServer.NetworkInterfaces().Delete(context.Background(), portID); err != nil {
+			if err := client.CloudServer.NetworkInterfaces().Delete(ctx, portID); err != nil {
 				errChan <- fmt.Errorf("error detaching server for port %s: %v", portID, err)
 			}
 		}(portID)
@@ -804,7 +1052,7 @@ func updateFreeWantPort(d *schema.ResourceData, client *gobizfly.Client, field s
 		wg.Add(1)
 		go func(firewallID string) {
 			defer wg.Done()
-			if err := attachFirewallsForPort(client, freeWanID, []string{firewallID}); err != nil {
+			if err := attachFirewallsForPort(ctx, client, freeWanID, []string{firewallID}); err != nil {
 				errChan <- fmt.Errorf("error attaching firewall for port %s: %v", freeWanID, err)
 			}
 		}(firewallID)
@@ -813,7 +1061,7 @@ func updateFreeWantPort(d *schema.ResourceData, client *gobizfly.Client, field s
 		wg.Add(1)
 		go func(firewallID string) {
 			defer wg.Done()
-			if err := detachFirewallsForPort(client, freeWanID, []string{firewallID}); err != nil {
+			if err := detachFirewallsForPort(ctx, client, freeWanID, []string{firewallID}); err != nil {
 				errChan <- fmt.Errorf("error detaching firewall for port %s: %v", freeWanID, err)
 			}
 		}(firewallID)
@@ -822,7 +1070,7 @@ func updateFreeWantPort(d *schema.ResourceData, client *gobizfly.Client, field s
 		wg.Add(1)
 		go func(portID string) {
 			defer wg.Done()
-			if _, err := client.CloudServer.NetworkInterfaces().Action(context.Background(), portID,
+			if _, err := client.CloudServer.NetworkInterfaces().Action(ctx, portID,
 				&gobizfly.ActionNetworkInterfacePayload{
 					Action: "enable",
 				}); err != nil {
@@ -834,7 +1082,7 @@ func updateFreeWantPort(d *schema.ResourceData, client *gobizfly.Client, field s
 		wg.Add(1)
 		go func(portID string) {
 			defer wg.Done()
-			if _, err := client.CloudServer.NetworkInterfaces().Action(context.Background(), portID,
+			if _, err := client.CloudServer.NetworkInterfaces().Action(ctx, portID,
 				&gobizfly.ActionNetworkInterfacePayload{
 					Action: "disable",
 				}); err != nil {
@@ -849,8 +1097,8 @@ func updateFreeWantPort(d *schema.ResourceData, client *gobizfly.Client, field s
 	return nil
 }
 
-func getServerRootDisk(client *gobizfly.Client, serverID string) (*gobizfly.Volume, error) {
-	volumes, err := client.CloudServer.Volumes().List(context.Background(), nil)
+func getServerRootDisk(ctx context.Context, client *gobizfly.Client, serverID string) (*gobizfly.Volume, error) {
+	volumes, err := client.CloudServer.Volumes().List(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("list volume failed: %+v", err)
 	}
@@ -869,7 +1117,7 @@ func getServerRootDisk(client *gobizfly.Client, serverID string) (*gobizfly.Volu
 	return nil, fmt.Errorf("rootdisk of server %s not found", serverID)
 }
 
-func changeServerNetworkInterfaces(d *schema.ResourceData, client *gobizfly.Client) error {
+func changeServerNetworkInterfaces(ctx context.Context, d *schema.ResourceData, client *gobizfly.Client) error {
 	serverID := d.Id()
 	oldNetworkInterfaces, newNetworkInterfaces := d.GetChange("network_interfaces")
 	oldNetworkInterfaceMap := parseNetworkInterfaces(oldNetworkInterfaces)
@@ -877,7 +1125,7 @@ func changeServerNetworkInterfaces(d *schema.ResourceData, client *gobizfly.Clie
 	fmt.Printf("Old network interfaces: %+v", oldNetworkInterfaceMap)
 	fmt.Printf("New network interfaces: %+v", newNetworkInterfaceMap)
 	// handle change network interfaces
-	return changeAttachedPort(client, serverID, oldNetworkInterfaceMap, newNetworkInterfaceMap)
+	return changeAttachedPort(ctx, client, serverID, oldNetworkInterfaceMap, newNetworkInterfaceMap)
 }
 
 type ServerNetworkInterfaceConfig struct {
@@ -899,9 +1147,8 @@ func parseNetworkInterfaces(networkInterfaces interface{}) (networkInterfaceMap
 	return
 }
 
-func changeAttachedPort(client *gobizfly.Client, serverID string, oldNetworkInterfaceMap, newNetworkInterfaceMap map[string]ServerNetworkInterfaceConfig) error {
+func changeAttachedPort(ctx context.Context, client *gobizfly.Client, serverID string, oldNetworkInterfaceMap, newNetworkInterfaceMap map[string]ServerNetworkInterfaceConfig) error {
 	var wg sync.WaitGroup
-	ctx := context.Background()
 	// Handle attach network interfaces to the server
 	// Handle enable and disable for network interfaces
 	errLen := len(newNetworkInterfaceMap) + len(oldNetworkInterfaceMap)
@@ -913,10 +1160,10 @@ func changeAttachedPort(client *gobizfly.Client, serverID string, oldNetworkInte
 		go func(id string, oldPort, newPort ServerNetworkInterfaceConfig) {
 			defer wg.Done()
 			if !ok {
-				if err := attachServerForPort(client, serverID, id); err != nil {
+				if err := attachServerForPort(ctx, client, serverID, id); err != nil {
 					errChan <- fmt.Errorf("error attach network interface %s for server %s: %v", id, serverID, err)
 				}
-				if _, err := waitToAttachPort(client, id); err != nil {
+				if err := waitToAttachPort(ctx, client, id); err != nil {
 					log.Printf("[WARN] waiting to attach port %s for server %s: %v", id, serverID, err)
 					oldPort.Enabled = false
 				} else {
@@ -949,7 +1196,7 @@ func changeAttachedPort(client *gobizfly.Client, serverID string, oldNetworkInte
 		wg.Add(1)
 		go func(id string) {
 			defer wg.Done()
-			if err := detachServerForPort(client, oldID); err != nil {
+			if err := detachServerForPort(ctx, client, oldID); err != nil {
 				errChan <- fmt.Errorf("error detach network interface %s error: %v", oldID, err)
 			}
 		}(oldID)
@@ -962,28 +1209,21 @@ func changeAttachedPort(client *gobizfly.Client, serverID string, oldNetworkInte
 	return nil
 }
 
-func waitToAttachPort(client *gobizfly.Client, portID string) (interface{}, error) {
-	log.Printf("[INFO] Waiting for attach port %s", portID)
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"false"},
-		Target:     []string{"true"},
-		Refresh:    attachPortRefreshFunc(client, portID),
-		Timeout:    30 * time.Second,
-		Delay:      3 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	return stateConf.WaitForState()
-}
-
-func attachPortRefreshFunc(client *gobizfly.Client, portID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		resp, err := client.CloudServer.NetworkInterfaces().Get(context.Background(), portID)
+func waitToAttachPort(ctx context.Context, client *gobizfly.Client, portID string) error {
+	conf := waiter.Config{
+		Description: fmt.Sprintf("port %s to be attached", portID),
+		Target:      []string{"ACTIVE"},
+		Timeout:     30 * time.Second,
+		Delay:       3 * time.Second,
+	}
+	_, err := conf.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		resp, err := client.CloudServer.NetworkInterfaces().Get(ctx, portID)
 		if err != nil {
-			return nil, "false", err
+			return nil, "", err
 		}
-		enablePort := isEnablePort(resp.Status)
-		return resp, strconv.FormatBool(enablePort), nil
-	}
+		return resp, resp.Status, nil
+	})
+	return err
 }
 
 func isEnablePort(status string) bool {
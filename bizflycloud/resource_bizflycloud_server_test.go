@@ -21,12 +21,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -39,9 +41,9 @@ func TestAccBizflyCloudServer_Basic(t *testing.T) {
 	var server gobizfly.Server
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudServerDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudServerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudServerBasic_config(rInt),
@@ -148,3 +150,284 @@ resource "bizflycloud_server" "foobar" {
 }
 `, rInt)
 }
+
+func TestBizflyCloudServer_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudServer()
+
+	config := map[string]interface{}{
+		"name":                  "server-foo",
+		"flavor_name":           "2c_2g",
+		"category":              "premium",
+		"os_type":               "image",
+		"os_id":                 "image-1",
+		"root_disk_size":        20,
+		"root_disk_volume_type": "SSD",
+		"availability_zone":     "HN1",
+		"default_public_ipv4":   []interface{}{map[string]interface{}{"enabled": true}},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	if !strings.HasPrefix(d.Id(), "server-") {
+		t.Fatalf("expected server ID to replace the task ID, got %s", d.Id())
+	}
+	testCheckResourceDataAttr(t, d, "state", "running")
+	testCheckResourceDataAttr(t, d, "root_disk_size", 20)
+	testCheckResourceDataAttr(t, d, "os_id", "image-1")
+	testCheckResourceDataAttr(t, d, "console_url", "")
+	if ip := d.Get("default_public_ipv4.0.ip_address").(string); ip == "" {
+		t.Error("expected default public ipv4 address to be set")
+	}
+	api.mu.Lock()
+	if actions := api.serverActions[d.Id()]; len(actions) != 0 {
+		t.Errorf("expected no console to be requested without fetch_console_url, got %v", actions)
+	}
+	api.mu.Unlock()
+
+	config["flavor_name"] = "4c_4g"
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "flavor_name", "4c_4g")
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckResourceDataAttr(t, imported, "name", "server-foo")
+
+	testResourceDelete(t, r, d, meta)
+	if _, err := meta.gobizflyClient().CloudServer.Get(context.Background(), d.Id()); !errors.Is(err, gobizfly.ErrNotFound) {
+		t.Errorf("expected server %s to be deleted, got: %v", d.Id(), err)
+	}
+}
+
+func TestBizflyCloudServer_FakeAPIDeletedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudServer()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name":                  "server-gone",
+		"flavor_name":           "2c_2g",
+		"category":              "premium",
+		"os_type":               "image",
+		"os_id":                 "image-1",
+		"root_disk_size":        20,
+		"root_disk_volume_type": "SSD",
+		"availability_zone":     "HN1",
+	}, meta)
+
+	api.mu.Lock()
+	api.deleteServer(d.Id(), nil)
+	api.mu.Unlock()
+
+	testCheckResourceGone(t, r, d, meta)
+}
+
+func TestBizflyCloudServer_FakeAPIRebuild(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudServer()
+
+	config := map[string]interface{}{
+		"name":                    "server-rebuild",
+		"flavor_name":             "2c_2g",
+		"category":                "premium",
+		"os_type":                 "image",
+		"os_id":                   "image-1",
+		"root_disk_size":          20,
+		"root_disk_volume_type":   "SSD",
+		"availability_zone":       "HN1",
+		"default_public_ipv4":     []interface{}{map[string]interface{}{"enabled": true}},
+		"rebuild_on_image_change": true,
+	}
+	d := testResourceCreate(t, r, config, meta)
+	serverID := d.Id()
+	publicIP := d.Get("default_public_ipv4.0.ip_address").(string)
+
+	api.mu.Lock()
+	api.servers[serverID].AttachedVolumes = append(api.servers[serverID].AttachedVolumes, gobizfly.AttachedVolume{
+		ID:           "volume-data",
+		AttachedType: attachTypeDataDisk,
+	})
+	api.mu.Unlock()
+
+	config["os_id"] = "image-2"
+	d = testResourceUpdate(t, r, d, config, meta)
+	if d.Id() != serverID {
+		t.Errorf("expected server %s to be rebuilt in place, got %s", serverID, d.Id())
+	}
+	testCheckResourceDataAttr(t, d, "os_id", "image-2")
+	testCheckResourceDataAttr(t, d, "volume_ids.#", 1)
+	testCheckResourceDataAttr(t, d, "default_public_ipv4.0.ip_address", publicIP)
+	if n := api.requestCount("DELETE", "/cloud_server/servers/"); n != 0 {
+		t.Errorf("expected the server not to be deleted, got %d delete requests", n)
+	}
+
+	// rebuilding from a snapshot is rejected when planning, before the
+	// rename of the same plan is applied
+	config["os_type"] = "snapshot"
+	config["os_id"] = "snapshot-1"
+	config["name"] = "server-renamed"
+	if _, err := r.Diff(testContext(), d.State(), terraform.NewResourceConfigRaw(config), meta); err == nil || !strings.Contains(err.Error(), "only image is supported") {
+		t.Errorf("expected the rebuild from a snapshot to be rejected when planning, got %v", err)
+	}
+}
+
+func TestBizflyCloudServer_FakeAPIRebootAndState(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudServer()
+
+	config := map[string]interface{}{
+		"name":                  "server-reboot",
+		"flavor_name":           "2c_2g",
+		"category":              "premium",
+		"os_type":               "image",
+		"os_id":                 "image-1",
+		"root_disk_size":        20,
+		"root_disk_volume_type": "SSD",
+		"availability_zone":     "HN1",
+		"reboot_trigger":        "1",
+		"fetch_console_url":     true,
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "console_url", "https://console.example.vn/vnc_auto.html?token="+d.Id())
+
+	config["reboot_trigger"] = "2"
+	config["reboot_type"] = "hard"
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "status", "ACTIVE")
+
+	config["state"] = "stopped"
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "state", "stopped")
+	testCheckResourceDataAttr(t, d, "console_url", "")
+
+	// a stopped server is not rebooted, the apply warns instead
+	config["reboot_trigger"] = "3"
+	diff, err := r.Diff(testContext(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning reboot: %v", err)
+	}
+	_, diags := r.Apply(testContext(), d.State(), diff, meta)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning that the stopped server is not rebooted, got %v", diags)
+	}
+
+	api.mu.Lock()
+	var reboots []string
+	for _, action := range api.serverActions[d.Id()] {
+		if strings.HasSuffix(action, "_reboot") {
+			reboots = append(reboots, action)
+		}
+	}
+	api.mu.Unlock()
+	if len(reboots) != 1 || reboots[0] != "hard_reboot" {
+		t.Errorf("expected a single hard reboot, got %v", reboots)
+	}
+}
+
+func TestBizflyCloudServer_FakeAPIDataDisks(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudServer()
+
+	dataDisk := func(size int, deleteOnTermination bool) map[string]interface{} {
+		return map[string]interface{}{
+			"size":                  size,
+			"volume_type":           "SSD",
+			"delete_on_termination": deleteOnTermination,
+		}
+	}
+	config := map[string]interface{}{
+		"name":                  "server-disks",
+		"flavor_name":           "2c_2g",
+		"category":              "premium",
+		"os_type":               "image",
+		"os_id":                 "image-1",
+		"root_disk_size":        20,
+		"root_disk_volume_type": "SSD",
+		"availability_zone":     "HN1",
+		"data_disk":             []interface{}{dataDisk(50, true), dataDisk(30, false)},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "data_disk.#", 2)
+	testCheckResourceDataAttr(t, d, "data_disk.0.size", 50)
+	testCheckResourceDataAttr(t, d, "data_disk.1.size", 30)
+	testCheckResourceDataAttr(t, d, "data_disk.1.volume_type", "SSD")
+	if n := api.requestCount("POST", "/cloud_server/volumes"); n != 0 {
+		t.Errorf("expected the data disks to be created with the server, got %d volume requests", n)
+	}
+	keptDiskID := d.Get("data_disk.1.id").(string)
+
+	config["data_disk"] = []interface{}{dataDisk(60, true), dataDisk(30, false), dataDisk(10, true), dataDisk(15, true)}
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "data_disk.#", 4)
+	testCheckResourceDataAttr(t, d, "data_disk.0.size", 60)
+	testCheckResourceDataAttr(t, d, "data_disk.1.id", keptDiskID)
+	api.mu.Lock()
+	attached := api.servers[d.Id()].AttachedVolumes
+	if len(attached) != 5 || attached[3].ID != d.Get("data_disk.2.id") || attached[4].ID != d.Get("data_disk.3.id") {
+		t.Errorf("expected the new data disks to be attached in order, got %+v", attached)
+	}
+	api.mu.Unlock()
+	removedDiskID := d.Get("data_disk.3.id").(string)
+
+	config["data_disk"] = []interface{}{dataDisk(60, true), dataDisk(30, false), dataDisk(10, true)}
+	d = testResourceUpdate(t, r, d, config, meta)
+	testCheckResourceDataAttr(t, d, "data_disk.#", 3)
+	api.mu.Lock()
+	if _, ok := api.volumes[removedDiskID]; ok {
+		t.Errorf("expected removed data disk %s to be deleted", removedDiskID)
+	}
+	api.mu.Unlock()
+
+	testResourceDelete(t, r, d, meta)
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if len(api.volumes) != 1 || api.volumes[keptDiskID] == nil {
+		t.Errorf("expected only data disk %s to be kept, got %d volumes", keptDiskID, len(api.volumes))
+	}
+}
+
+func TestBizflyCloudServer_FakeAPIDataDisksKeptByDefault(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudServer()
+
+	config := map[string]interface{}{
+		"name":                  "server-disks",
+		"flavor_name":           "2c_2g",
+		"category":              "premium",
+		"os_type":               "image",
+		"os_id":                 "image-1",
+		"root_disk_size":        20,
+		"root_disk_volume_type": "SSD",
+		"availability_zone":     "HN1",
+		"data_disk": []interface{}{
+			map[string]interface{}{"size": 50, "volume_type": "SSD"},
+			map[string]interface{}{"size": 30, "volume_type": "SSD"},
+		},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckResourceDataAttr(t, d, "data_disk.1.delete_on_termination", false)
+	removedDiskID := d.Get("data_disk.1.id").(string)
+
+	config["data_disk"] = config["data_disk"].([]interface{})[:1]
+	d = testResourceUpdate(t, r, d, config, meta)
+	api.mu.Lock()
+	if _, ok := api.volumes[removedDiskID]; !ok {
+		t.Errorf("expected removed data disk %s to be detached and kept", removedDiskID)
+	}
+	api.mu.Unlock()
+
+	testResourceDelete(t, r, d, meta)
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if len(api.volumes) != 2 {
+		t.Errorf("expected both data disks to be kept, got %d volumes", len(api.volumes))
+	}
+}
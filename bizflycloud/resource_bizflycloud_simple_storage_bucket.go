@@ -2,22 +2,22 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudSimpleStorageBucket() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudSimpleStorageBucketCreate,
-		Read:   resourceBizflyCloudSimpleStorageBucketRead,
-		Delete: resourceBizflyCloudSimpleStorageBucketDelete,
+		CreateContext: resourceBizflyCloudSimpleStorageBucketCreate,
+		ReadContext:   resourceBizflyCloudSimpleStorageBucketRead,
+		DeleteContext: resourceBizflyCloudSimpleStorageBucketDelete,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -41,7 +41,7 @@ func resourceBizflyCloudSimpleStorageBucket() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudSimpleStorageBucketCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	bcr := gobizfly.BucketCreateRequest{
 		Name:                d.Get("name").(string),
@@ -49,19 +49,19 @@ func resourceBizflyCloudSimpleStorageBucketCreate(d *schema.ResourceData, meta i
 		Acl:                 "private",
 		DefaultStorageClass: d.Get("default_storage_class").(string),
 	}
-	ss, err := client.CloudSimpleStorage.Create(context.Background(), &bcr)
+	ss, err := client.CloudSimpleStorage.Create(ctx, &bcr)
 	if err != nil {
-		return fmt.Errorf("error when creating simple store bucket: %v", err)
+		return diag.Errorf("error when creating simple store bucket: %v", err)
 	}
 	d.SetId(ss.Name)
-	return resourceBizflyCloudSimpleStorageBucketRead(d, meta)
+	return resourceBizflyCloudSimpleStorageBucketRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudSimpleStorageBucketRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	simpleStores, err := client.CloudSimpleStorage.List(context.Background(), &gobizfly.ListOptions{})
+	simpleStores, err := client.CloudSimpleStorage.List(ctx, &gobizfly.ListOptions{})
 	if err != nil {
-		return fmt.Errorf("error retrieving simple store bucket: %v", err)
+		return diag.Errorf("error retrieving simple store bucket: %v", err)
 	}
 	for _, simpleStore := range simpleStores {
 		if simpleStore.Name == d.Id() {
@@ -70,16 +70,19 @@ func resourceBizflyCloudSimpleStorageBucketRead(d *schema.ResourceData, meta int
 			_ = d.Set("created_at", simpleStore.CreatedAt)
 			_ = d.Set("num_objects", simpleStore.NumObjects)
 			_ = d.Set("size_kb", simpleStore.SizeKb)
+			return nil
 		}
 	}
+	log.Printf("[WARN] Simple storage bucket (%s) is not found", d.Id())
+	d.SetId("")
 	return nil
 }
 
-func resourceBizflyCloudSimpleStorageBucketDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudSimpleStorage.Delete(context.Background(), d.Id())
+	err := client.CloudSimpleStorage.Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting simple store bucket: %v", err)
+		return diag.Errorf("error deleting simple store bucket: %v", err)
 	}
 	return nil
 }
@@ -2,26 +2,27 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudSimpleStorageBucketAcl() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudSimpleStorageBucketAclUpdate,
-		Read:   resourceBizflyCloudSimpleStorageBucketAclRead,
-		Delete: func(d *schema.ResourceData, meta interface{}) error {
+		CreateContext: resourceBizflyCloudSimpleStorageBucketAclUpdate,
+		ReadContext:   resourceBizflyCloudSimpleStorageBucketAclRead,
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 			log.Printf("[INFO] Delete operation is not supported for ACL. Ignoring delete request.")
 			return nil
 		},
-		Update: resourceBizflyCloudSimpleStorageBucketAclUpdate,
+		UpdateContext: resourceBizflyCloudSimpleStorageBucketAclUpdate,
 		Schema: map[string]*schema.Schema{
 			"bucket_name": {
 				Type:     schema.TypeString,
@@ -39,30 +40,35 @@ func resourceBizflyCloudSimpleStorageBucketAcl() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudSimpleStorageBucketAclUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketAclUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if d.HasChange("acl") {
 		client := meta.(*CombinedConfig).gobizflyClient()
 		bucketName := d.Get("bucket_name").(string)
 		acl := d.Get("acl").(string)
 
-		_, err := client.CloudSimpleStorage.UpdateAcl(context.Background(), acl, bucketName)
+		_, err := client.CloudSimpleStorage.UpdateAcl(ctx, acl, bucketName)
 		if err != nil {
-			return fmt.Errorf("error updating simple store ACL: %v", err)
+			return diag.Errorf("error updating simple store ACL: %v", err)
 		}
 		d.SetId(bucketName)
 	}
-	return resourceBizflyCloudSimpleStorageBucketAclRead(d, meta)
+	return resourceBizflyCloudSimpleStorageBucketAclRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudSimpleStorageBucketAclRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketAclRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	paramListBucketInfo := gobizfly.ParamListWithBucketNameInfo{
 		Acl:        "acl",
 		BucketName: d.Get("bucket_name").(string),
 	}
-	dataBuckets, err := client.CloudSimpleStorage.ListWithBucketNameInfo(context.Background(), paramListBucketInfo)
+	dataBuckets, err := client.CloudSimpleStorage.ListWithBucketNameInfo(ctx, paramListBucketInfo)
 	if err != nil {
-		return fmt.Errorf("error when reading simple store Acl: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Simple storage bucket for ACL (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error when reading simple store Acl: %v", err)
 	}
 
 	_ = d.Set("owner", dataBuckets.Acl.Owner)
@@ -2,26 +2,27 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudSimpleStorageBucketCors() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudSimpleStorageBucketCorsUpdate,
-		Read:   resourceBizflyCloudSimpleStorageBucketCorsRead,
-		Delete: func(d *schema.ResourceData, meta interface{}) error {
+		CreateContext: resourceBizflyCloudSimpleStorageBucketCorsUpdate,
+		ReadContext:   resourceBizflyCloudSimpleStorageBucketCorsRead,
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 			log.Printf("[INFO] Delete operation is not supported for CORS. Ignoring delete request.")
 			return nil
 		},
-		Update: resourceBizflyCloudSimpleStorageBucketCorsUpdate,
+		UpdateContext: resourceBizflyCloudSimpleStorageBucketCorsUpdate,
 		Schema: map[string]*schema.Schema{
 			"bucket_name": {
 				Type:     schema.TypeString,
@@ -61,7 +62,7 @@ func resourceBizflyCloudSimpleStorageBucketCors() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudSimpleStorageBucketCorsUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketCorsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 
 	rulesData := d.Get("rules").(*schema.Set).List()
@@ -82,13 +83,13 @@ func resourceBizflyCloudSimpleStorageBucketCorsUpdate(d *schema.ResourceData, me
 		BucketName: d.Get("bucket_name").(string),
 	}
 
-	_, err := client.CloudSimpleStorage.UpdateCors(context.Background(), &paramCors)
+	_, err := client.CloudSimpleStorage.UpdateCors(ctx, &paramCors)
 	if err != nil {
-		return fmt.Errorf("error updating simple store CORS: %v", err)
+		return diag.Errorf("error updating simple store CORS: %v", err)
 	}
 
 	d.SetId(d.Get("bucket_name").(string))
-	return resourceBizflyCloudSimpleStorageBucketCorsRead(d, meta)
+	return resourceBizflyCloudSimpleStorageBucketCorsRead(ctx, d, meta)
 }
 
 func convertInterfaceSliceToStringSlice(input []interface{}) []string {
@@ -99,15 +100,20 @@ func convertInterfaceSliceToStringSlice(input []interface{}) []string {
 	return output
 }
 
-func resourceBizflyCloudSimpleStorageBucketCorsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketCorsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	paramListBucketInfo := gobizfly.ParamListWithBucketNameInfo{
 		Cors:       "cors",
 		BucketName: d.Get("bucket_name").(string),
 	}
-	dataBucket, err := client.CloudSimpleStorage.ListWithBucketNameInfo(context.Background(), paramListBucketInfo)
+	dataBucket, err := client.CloudSimpleStorage.ListWithBucketNameInfo(ctx, paramListBucketInfo)
 	if err != nil {
-		return fmt.Errorf("error when reading simple store Cors: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Simple storage bucket for CORS (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error when reading simple store Cors: %v", err)
 	}
 	d.SetId(dataBucket.Bucket.Name)
 
@@ -2,22 +2,22 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudSimpleStorageAccessKey() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudSimpleStorageKeyCreate,
-		Read:   resourceBizflyCloudSimpleStorageKeyRead,
-		Delete: resourceBizflyCloudSimpleStorageKeyDelete,
+		CreateContext: resourceBizflyCloudSimpleStorageKeyCreate,
+		ReadContext:   resourceBizflyCloudSimpleStorageKeyRead,
+		DeleteContext: resourceBizflyCloudSimpleStorageKeyDelete,
 		Schema: map[string]*schema.Schema{
 			"subuser_id": {
 				Type:     schema.TypeString,
@@ -30,9 +30,10 @@ func resourceBizflyCloudSimpleStorageAccessKey() *schema.Resource {
 				ForceNew: true,
 			},
 			"secret_key": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 		},
 		Timeouts: &schema.ResourceTimeout{
@@ -41,41 +42,44 @@ func resourceBizflyCloudSimpleStorageAccessKey() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudSimpleStorageKeyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	kcr := gobizfly.KeyCreateRequest{
 		SubuserId: d.Get("subuser_id").(string),
 		AccessKey: d.Get("access_key").(string),
 		SecretKey: d.Get("secret_key").(string),
 	}
-	_, err := client.CloudSimpleStorage.SimpleStorageKey().CreateAccessKey(context.Background(), &kcr)
+	_, err := client.CloudSimpleStorage.SimpleStorageKey().CreateAccessKey(ctx, &kcr)
 	if err != nil {
-		return fmt.Errorf("error when creating simple store key: %v", err)
+		return diag.Errorf("error when creating simple store key: %v", err)
 	}
 	d.SetId(kcr.AccessKey)
-	return resourceBizflyCloudSimpleStorageKeyRead(d, meta)
+	return resourceBizflyCloudSimpleStorageKeyRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudSimpleStorageKeyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	keys, err := client.CloudSimpleStorage.SimpleStorageKey().ListAccessKey(context.Background(), &gobizfly.ListOptions{})
+	keys, err := client.CloudSimpleStorage.SimpleStorageKey().ListAccessKey(ctx, &gobizfly.ListOptions{})
 	if err != nil {
-		return fmt.Errorf("error retrieving simple store key: %v", err)
+		return diag.Errorf("error retrieving simple store key: %v", err)
 	}
 	for _, key := range keys {
 		if key.AccessKey == d.Id() {
 			_ = d.Set("name", key.AccessKey)
 			_ = d.Set("location", key.User)
+			return nil
 		}
 	}
+	log.Printf("[WARN] Simple storage access key (%s) is not found", d.Id())
+	d.SetId("")
 	return nil
 }
 
-func resourceBizflyCloudSimpleStorageKeyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudSimpleStorage.SimpleStorageKey().DeleteAccessKey(context.Background(), d.Id())
+	err := client.CloudSimpleStorage.SimpleStorageKey().DeleteAccessKey(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting simple store key: %v", err)
+		return diag.Errorf("error deleting simple store key: %v", err)
 	}
 	return nil
 }
@@ -2,26 +2,27 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudSimpleStorageBucketVersioning() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudSimpleStorageBucketVersioningUpdate,
-		Read:   resourceBizflyCloudSimpleStorageBucketVersioningRead,
-		Delete: func(d *schema.ResourceData, meta interface{}) error {
+		CreateContext: resourceBizflyCloudSimpleStorageBucketVersioningUpdate,
+		ReadContext:   resourceBizflyCloudSimpleStorageBucketVersioningRead,
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 			log.Printf("[INFO] Delete operation is not supported for versioning. Ignoring delete request.")
 			return nil
 		},
-		Update: resourceBizflyCloudSimpleStorageBucketVersioningUpdate,
+		UpdateContext: resourceBizflyCloudSimpleStorageBucketVersioningUpdate,
 		Schema: map[string]*schema.Schema{
 			"bucket_name": {
 				Type:     schema.TypeString,
@@ -39,35 +40,40 @@ func resourceBizflyCloudSimpleStorageBucketVersioning() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudSimpleStorageBucketVersioningUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketVersioningUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if d.HasChange("versioning") {
 		client := meta.(*CombinedConfig).gobizflyClient()
 		bucketName := d.Get("bucket_name").(string)
 		versioning := d.Get("versioning").(bool)
 
-		_, err := client.CloudSimpleStorage.UpdateVersioning(context.Background(), versioning, bucketName)
+		_, err := client.CloudSimpleStorage.UpdateVersioning(ctx, versioning, bucketName)
 		if err != nil {
-			return fmt.Errorf("error updating simple store versioning: %v", err)
+			return diag.Errorf("error updating simple store versioning: %v", err)
 		}
 		d.SetId(bucketName)
 	}
-	return resourceBizflyCloudSimpleStorageBucketVersioningRead(d, meta)
+	return resourceBizflyCloudSimpleStorageBucketVersioningRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudSimpleStorageBucketVersioningRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketVersioningRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	paramListBucketInfo := gobizfly.ParamListWithBucketNameInfo{
 		Versioning: "versioning",
 		BucketName: d.Get("bucket_name").(string),
 	}
-	dataBucket, err := client.CloudSimpleStorage.ListWithBucketNameInfo(context.Background(), paramListBucketInfo)
+	dataBucket, err := client.CloudSimpleStorage.ListWithBucketNameInfo(ctx, paramListBucketInfo)
 	if err != nil {
-		return fmt.Errorf("error when reading simple store Verioning: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Simple storage bucket for versioning (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error when reading simple store Verioning: %v", err)
 	}
 	versioningEnabled := dataBucket.Versioning.Status == "Enabled"
 
 	if err = d.Set("versioning", versioningEnabled); err != nil {
-		return fmt.Errorf("error setting versioning state: %v", err)
+		return diag.Errorf("error setting versioning state: %v", err)
 	}
 	return nil
 }
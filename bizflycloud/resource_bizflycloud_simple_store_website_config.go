@@ -2,26 +2,27 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudSimpleStorageBucketWebsiteConfig() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudSimpleStorageBucketWebsiteConfigUpdate,
-		Read:   resourceBizflyCloudSimpleStorageBucketWebsiteConfigRead,
-		Delete: func(d *schema.ResourceData, meta interface{}) error {
+		CreateContext: resourceBizflyCloudSimpleStorageBucketWebsiteConfigUpdate,
+		ReadContext:   resourceBizflyCloudSimpleStorageBucketWebsiteConfigRead,
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 			log.Printf("[INFO] Delete operation is not supported for web config. Ignoring delete request.")
 			return nil
 		},
-		Update: resourceBizflyCloudSimpleStorageBucketWebsiteConfigUpdate,
+		UpdateContext: resourceBizflyCloudSimpleStorageBucketWebsiteConfigUpdate,
 		Schema: map[string]*schema.Schema{
 			"bucket_name": {
 				Type:     schema.TypeString,
@@ -43,7 +44,7 @@ func resourceBizflyCloudSimpleStorageBucketWebsiteConfig() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudSimpleStorageBucketWebsiteConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketWebsiteConfigUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if d.HasChange("error") {
 		client := meta.(*CombinedConfig).gobizflyClient()
 
@@ -52,28 +53,33 @@ func resourceBizflyCloudSimpleStorageBucketWebsiteConfigUpdate(d *schema.Resourc
 			Error:      d.Get("error").(string),
 			BucketName: d.Get("bucket_name").(string),
 		}
-		_, err := client.CloudSimpleStorage.UpdateWebsiteConfig(context.Background(), &ParamUpdateWebConfig)
+		_, err := client.CloudSimpleStorage.UpdateWebsiteConfig(ctx, &ParamUpdateWebConfig)
 		if err != nil {
-			return fmt.Errorf("error updating simple store website config: %v", err)
+			return diag.Errorf("error updating simple store website config: %v", err)
 		}
 		d.SetId(d.Get("bucket_name").(string))
 	}
-	return resourceBizflyCloudSimpleStorageBucketWebsiteConfigRead(d, meta)
+	return resourceBizflyCloudSimpleStorageBucketWebsiteConfigRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudSimpleStorageBucketWebsiteConfigRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSimpleStorageBucketWebsiteConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	paramListBucketInfo := gobizfly.ParamListWithBucketNameInfo{
 		WebsiteConfig: "website_config",
 		BucketName:    d.Get("bucket_name").(string),
 	}
-	dataBucket, err := client.CloudSimpleStorage.ListWithBucketNameInfo(context.Background(), paramListBucketInfo)
+	dataBucket, err := client.CloudSimpleStorage.ListWithBucketNameInfo(ctx, paramListBucketInfo)
 	if err != nil {
-		return fmt.Errorf("error when reading simple store website config: %v", err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Simple storage bucket for website config (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error when reading simple store website config: %v", err)
 	}
 
 	if err = d.Set("index", dataBucket.WebsiteConfig.Index); err != nil {
-		return fmt.Errorf("error setting website config state: %v", err)
+		return diag.Errorf("error setting website config state: %v", err)
 	}
 	return nil
 }
@@ -19,17 +19,18 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudSSHKey() *schema.Resource {
 	return &schema.Resource{
-		Create:        resourceBizflyCloudSSHKeyCreate,
-		Read:          resourceBizflyCloudSSHKeyRead,
-		Delete:        resourceBizflyCloudSSHKeyDelete,
+		CreateContext: resourceBizflyCloudSSHKeyCreate,
+		ReadContext:   resourceBizflyCloudSSHKeyRead,
+		DeleteContext: resourceBizflyCloudSSHKeyDelete,
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -48,30 +49,30 @@ func resourceBizflyCloudSSHKey() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceBizflyCloudSSHKeyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSSHKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	resp, err := client.CloudServer.SSHKeys().Create(context.Background(), &gobizfly.SSHKeyCreateRequest{
+	resp, err := client.CloudServer.SSHKeys().Create(ctx, &gobizfly.SSHKeyCreateRequest{
 		Name:      d.Get("name").(string),
 		PublicKey: d.Get("public_key").(string),
 	})
 	if err != nil {
-		return fmt.Errorf("error creating SSH key: %v", err)
+		return diag.Errorf("error creating SSH key: %v", err)
 	}
 	d.SetId(resp.Name)
 	_ = d.Set("finger_print", resp.FingerPrint)
 	return nil
 }
 
-func resourceBizflyCloudSSHKeyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	sshkeys, err := client.CloudServer.SSHKeys().List(context.Background(), &gobizfly.ListOptions{})
+	sshkeys, err := client.CloudServer.SSHKeys().List(ctx, &gobizfly.ListOptions{})
 	if err != nil {
-		return fmt.Errorf("error retrieving ssh keys: %v", err)
+		return diag.Errorf("error retrieving ssh keys: %v", err)
 	}
 	for _, sshkey := range sshkeys {
 		if sshkey.SSHKeyPair.Name == d.Id() {
@@ -79,16 +80,19 @@ func resourceBizflyCloudSSHKeyRead(d *schema.ResourceData, meta interface{}) err
 			_ = d.Set("name", sshkey.SSHKeyPair.Name)
 			_ = d.Set("public_key", sshkey.SSHKeyPair.PublicKey)
 			_ = d.Set("fingerprint", sshkey.SSHKeyPair.FingerPrint)
+			return nil
 		}
 	}
+	log.Printf("[WARN] SSH key (%s) is not found", d.Id())
+	d.SetId("")
 	return nil
 }
 
-func resourceBizflyCloudSSHKeyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudSSHKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	_, err := client.CloudServer.SSHKeys().Delete(context.Background(), d.Id())
+	_, err := client.CloudServer.SSHKeys().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting ssh key: %v", err)
+		return diag.Errorf("error deleting ssh key: %v", err)
 	}
 	return nil
 }
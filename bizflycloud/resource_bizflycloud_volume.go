@@ -25,18 +25,20 @@ import (
 	"time"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudVolume() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudVolumeCreate,
-		Read:   resourceBizflyCloudVolumeRead,
-		Update: resourceBizflyCloudVolumeUpdate,
-		Delete: resourceBizflyCloudVolumeDelete,
+		CreateContext: resourceBizflyCloudVolumeCreate,
+		ReadContext:   resourceBizflyCloudVolumeRead,
+		UpdateContext: resourceBizflyCloudVolumeUpdate,
+		DeleteContext: resourceBizflyCloudVolumeDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 		},
@@ -86,7 +88,7 @@ func resourceBizflyCloudVolume() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudVolumeCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	vcr := &gobizfly.VolumeCreateRequest{
 		Name:             d.Get("name").(string),
@@ -96,28 +98,28 @@ func resourceBizflyCloudVolumeCreate(d *schema.ResourceData, meta interface{}) e
 		AvailabilityZone: d.Get("availability_zone").(string),
 	}
 	log.Printf("[DEBUG] Create Volume configuration #{vcr}")
-	volume, err := client.CloudServer.Volumes().Create(context.Background(), vcr)
+	volume, err := client.CloudServer.Volumes().Create(ctx, vcr)
 	if err != nil {
-		return fmt.Errorf("error creating volume: %v", err)
+		return diag.Errorf("error creating volume: %v", err)
 	}
 	d.SetId(volume.ID)
-	err = resourceBizflyCloudVolumeRead(d, meta)
+	err = diagnosticsError(resourceBizflyCloudVolumeRead(ctx, d, meta))
 	if err != nil {
-		return fmt.Errorf("error retrieving volume: %v", err)
+		return diag.Errorf("error retrieving volume: %v", err)
 	}
 	return nil
 }
 
-func resourceBizflyCloudVolumeRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	volume, err := client.CloudServer.Volumes().Get(context.Background(), d.Id())
+	volume, err := client.CloudServer.Volumes().Get(ctx, d.Id())
 	if err != nil {
 		if errors.Is(err, gobizfly.ErrNotFound) {
 			log.Printf("[WARN] VOlume (%s) is not found", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error retrieving volume: %v", err)
+		return diag.Errorf("error retrieving volume: %v", err)
 	}
 	_ = d.Set("name", volume.Name)
 	_ = d.Set("size", volume.Size)
@@ -131,28 +133,39 @@ func resourceBizflyCloudVolumeRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceBizflyCloudVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	if d.HasChange("size") {
 		// resize volume
 		// TODO check state of extending task
-		_, err := client.CloudServer.Volumes().ExtendVolume(context.Background(), d.Id(), d.Get("size").(int))
+		_, err := client.CloudServer.Volumes().ExtendVolume(ctx, d.Id(), d.Get("size").(int))
 		if err != nil {
 			if errors.Is(err, gobizfly.ErrNotFound) {
 				log.Printf("[WARN] Volume is not found %s", d.Id())
+				diags := diag.Diagnostics{{
+					Severity: diag.Warning,
+					Summary:  "volume not found",
+					Detail:   fmt.Sprintf("volume %s was not found while extending it and has been removed from the state", d.Id()),
+				}}
 				d.SetId("")
-				return nil
+				return diags
 			}
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "error extending volume",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("size"),
+			}}
 		}
 	}
 	return nil
 }
 
-func resourceBizflyCloudVolumeDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudServer.Volumes().Delete(context.Background(), d.Id())
+	err := client.CloudServer.Volumes().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error delete volume: %v", err)
+		return diag.Errorf("error delete volume: %v", err)
 	}
 	return nil
 }
@@ -2,47 +2,56 @@ package bizflycloud
 
 import (
 	"context"
+	"errors"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudVolumeAttachment() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create: resourceBizflyCloudVolumeAttachmentCreate,
-		Read:   resourceBizflyCloudVolumeAttachmentRead,
-		Delete: resourceBizflyCloudVolumeAttachmentDelete,
-		Schema: resourceBizflyCloudVolumeAttachmentSchema(),
+		CreateContext: resourceBizflyCloudVolumeAttachmentCreate,
+		ReadContext:   resourceBizflyCloudVolumeAttachmentRead,
+		DeleteContext: resourceBizflyCloudVolumeAttachmentDelete,
+		Schema:        resourceBizflyCloudVolumeAttachmentSchema(),
 	}
 }
 
-func resourceBizflyCloudVolumeAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	volumeID := d.Get("volume_id").(string)
 	serverID := d.Get("server_id").(string)
 	log.Printf("[INFO] Attaching volume %s to server %s", volumeID, serverID)
-	_, err := client.CloudServer.Volumes().Attach(context.Background(), volumeID, serverID)
+	_, err := client.CloudServer.Volumes().Attach(ctx, volumeID, serverID)
 	if err != nil {
 		log.Printf("[ERROR] Error attaching volume %s to server %s: %v", volumeID, serverID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(volumeID)
-	return resourceBizflyCloudVolumeAttachmentRead(d, meta)
+	return resourceBizflyCloudVolumeAttachmentRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudVolumeAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	volumeID := d.Id()
-	volume, err := client.CloudServer.Volumes().Get(context.Background(), volumeID)
+	volume, err := client.CloudServer.Volumes().Get(ctx, volumeID)
 	if err != nil {
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Volume (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[ERROR] Error reading volume %s: %v", volumeID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	if len(volume.Attachments) == 0 {
-		log.Printf("[ERROR] Volume %s is not attached to any server", volumeID)
+		log.Printf("[WARN] Volume %s is not attached to any server", volumeID)
+		d.SetId("")
 		return nil
 	} else {
 		_ = d.Set("server_id", volume.Attachments[0].ServerID)
@@ -51,14 +60,14 @@ func resourceBizflyCloudVolumeAttachmentRead(d *schema.ResourceData, meta interf
 	return nil
 }
 
-func resourceBizflyCloudVolumeAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	volumeID := d.Id()
 	serverID := d.Get("server_id").(string)
-	_, err := client.CloudServer.Volumes().Detach(context.Background(), serverID, volumeID)
+	_, err := client.CloudServer.Volumes().Detach(ctx, serverID, volumeID)
 	if err != nil {
 		log.Printf("[ERROR] Error detaching volume %s from server %s: %v", volumeID, serverID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
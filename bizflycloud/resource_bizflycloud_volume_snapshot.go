@@ -19,20 +19,22 @@ package bizflycloud
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudVolumeSnapshot() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Create:        resourceBizflyCloudVolumeSnapshotCreate,
-		Read:          resourceBizflyCloudVolumeSnapshotRead,
-		Delete:        resourceBizflyCloudVolumeSnapshotDelete,
+		CreateContext: resourceBizflyCloudVolumeSnapshotCreate,
+		ReadContext:   resourceBizflyCloudVolumeSnapshotRead,
+		DeleteContext: resourceBizflyCloudVolumeSnapshotDelete,
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -77,27 +79,32 @@ func resourceBizflyCloudVolumeSnapshot() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudVolumeSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	scr := gobizfly.SnapshotCreateRequest{
 		Name:     d.Get("name").(string),
 		VolumeID: d.Get("volume_id").(string),
 		Force:    true,
 	}
-	snapshot, err := client.CloudServer.Snapshots().Create(context.Background(), &scr)
+	snapshot, err := client.CloudServer.Snapshots().Create(ctx, &scr)
 	if err != nil {
-		return fmt.Errorf("error creating snapshot: %v", err)
+		return diag.Errorf("error creating snapshot: %v", err)
 	}
 	d.SetId(snapshot.ID)
 	_ = d.Set("volume_id", snapshot.VolumeID)
-	return resourceBizflyCloudVolumeSnapshotRead(d, meta)
+	return resourceBizflyCloudVolumeSnapshotRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudVolumeSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	snapshot, err := client.CloudServer.Snapshots().Get(context.Background(), d.Id())
+	snapshot, err := client.CloudServer.Snapshots().Get(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error retrieving snapshot %s: %v", d.Id(), err)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			log.Printf("[WARN] Snapshot (%s) is not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving snapshot %s: %v", d.Id(), err)
 	}
 	_ = d.Set("name", snapshot.Name)
 	_ = d.Set("size", snapshot.Size)
@@ -112,11 +119,11 @@ func resourceBizflyCloudVolumeSnapshotRead(d *schema.ResourceData, meta interfac
 	return nil
 }
 
-func resourceBizflyCloudVolumeSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVolumeSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudServer.Snapshots().Delete(context.Background(), d.Id())
+	err := client.CloudServer.Snapshots().Delete(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting volume snapshot %s: %v", d.Id(), err)
+		return diag.Errorf("error deleting volume snapshot %s: %v", d.Id(), err)
 	}
 	return nil
 }
@@ -24,9 +24,9 @@ import (
 	"testing"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -39,9 +39,9 @@ func TestAccBizflyCloudVolume_Basic(t *testing.T) {
 	var volume gobizfly.Volume
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudVolumeDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudVolumeDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudVolumeBasic_config(rInt),
@@ -138,3 +138,50 @@ resource "bizflycloud_volume" "foobar" {
 }
 `, rInt)
 }
+
+func TestBizflyCloudVolume_FakeAPI(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudVolume()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name":              "foo",
+		"size":              20,
+		"type":              "HDD",
+		"category":          "premium",
+		"availability_zone": "HN1",
+	}, meta)
+	testCheckResourceDataAttr(t, d, "name", "foo")
+	testCheckResourceDataAttr(t, d, "size", 20)
+	testCheckResourceDataAttr(t, d, "status", "available")
+
+	imported := testResourceImport(t, r, d.Id(), meta)
+	testCheckResourceDataAttr(t, imported, "type", "HDD")
+
+	testResourceDelete(t, r, d, meta)
+	if _, err := meta.gobizflyClient().CloudServer.Volumes().Get(context.Background(), d.Id()); !errors.Is(err, gobizfly.ErrNotFound) {
+		t.Errorf("expected volume %s to be deleted, got: %v", d.Id(), err)
+	}
+}
+
+func TestBizflyCloudVolume_FakeAPIDeletedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMeta(t)
+	r := resourceBizflyCloudVolume()
+
+	d := testResourceCreate(t, r, map[string]interface{}{
+		"name":              "gone",
+		"size":              20,
+		"type":              "HDD",
+		"category":          "premium",
+		"availability_zone": "HN1",
+	}, meta)
+
+	api.mu.Lock()
+	delete(api.volumes, d.Id())
+	api.mu.Unlock()
+
+	testCheckResourceGone(t, r, d, meta)
+}
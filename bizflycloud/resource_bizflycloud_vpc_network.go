@@ -5,31 +5,40 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudVPCNetwork() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBizflyCloudVPCNetworkCreate,
-		Read:   resourceBizflyCloudVPCNetworkRead,
-		Update: resourceBizflyCloudVPCNetworkUpdate,
-		Delete: resourceBizflyCloudVPCNetworkDelete,
+		CreateContext: resourceBizflyCloudVPCNetworkCreate,
+		ReadContext:   resourceBizflyCloudVPCNetworkRead,
+		UpdateContext: resourceBizflyCloudVPCNetworkUpdate,
+		DeleteContext: resourceBizflyCloudVPCNetworkDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 1,
 		Schema:        resourceVPCNetworkSchema(),
+		CustomizeDiff: resourceTagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Read: schema.DefaultTimeout(10 * time.Minute),
 		},
 	}
 }
 
+// vpcNetworkUpdatePayload is gobizfly.UpdateVPCPayload with the tags of the
+// VPC network, which gobizfly does not send.
+type vpcNetworkUpdatePayload struct {
+	gobizfly.UpdateVPCPayload
+	Tags []string `json:"tags"`
+}
+
 func VPCRequestBuilder(d *schema.ResourceData) gobizfly.UpdateVPCPayload {
 	vpcOpts := gobizfly.UpdateVPCPayload{}
 	if v, ok := d.GetOk("name"); ok {
@@ -47,7 +56,7 @@ func VPCRequestBuilder(d *schema.ResourceData) gobizfly.UpdateVPCPayload {
 	return vpcOpts
 }
 
-func resourceBizflyCloudVPCNetworkCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVPCNetworkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	cvp := &gobizfly.CreateVPCPayload{
 		Name:        d.Get("name").(string),
@@ -55,29 +64,34 @@ func resourceBizflyCloudVPCNetworkCreate(d *schema.ResourceData, meta interface{
 		CIDR:        d.Get("cidr").(string),
 		IsDefault:   d.Get("is_default").(bool),
 	}
-	network, err := client.CloudServer.VPCNetworks().Create(context.Background(), cvp)
+	network, err := client.CloudServer.VPCNetworks().Create(ctx, cvp)
 	if err != nil {
-		return fmt.Errorf("error when create vpc network: %v", err)
+		return diag.Errorf("error when create vpc network: %v", err)
 	}
 	d.SetId(network.ID)
-	return resourceBizflyCloudVPCNetworkRead(d, meta)
+	if tags := expandTagsAll(d, meta); len(tags) > 0 {
+		if err := updateVPCNetwork(ctx, client, d, tags); err != nil {
+			return diag.Errorf("error when tag vpc network %s: %v", d.Id(), err)
+		}
+	}
+	return resourceBizflyCloudVPCNetworkRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudVPCNetworkRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVPCNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	var vpc *gobizfly.VPCNetwork
-	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
 		var err error
 
 		log.Printf("[DEBUG] Reading vpc: %s", d.Id())
-		vpc, err = client.CloudServer.VPCNetworks().Get(context.Background(), d.Id())
+		vpc, err = client.CloudServer.VPCNetworks().Get(ctx, d.Id())
 
 		// Retry on any API "not found" errors, but only on new resources.
 		if d.IsNewResource() && errors.Is(err, gobizfly.ErrNotFound) {
-			return resource.RetryableError(err)
+			return retry.RetryableError(err)
 		}
 		if err != nil {
-			return resource.NonRetryableError(err)
+			return retry.NonRetryableError(err)
 		}
 		return nil
 	})
@@ -91,12 +105,12 @@ func resourceBizflyCloudVPCNetworkRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if err != nil {
-		return fmt.Errorf("error read vpc network VPC %s: %w", d.Id(), err)
+		return diag.Errorf("error read vpc network VPC %s: %v", d.Id(), err)
 	}
 
 	// Prevent panics.
 	if vpc == nil {
-		return fmt.Errorf("error read vpc network (%s): empty response", d.Id())
+		return diag.Errorf("error read vpc network (%s): empty response", d.Id())
 	}
 
 	d.SetId(vpc.ID)
@@ -108,50 +122,58 @@ func resourceBizflyCloudVPCNetworkRead(d *schema.ResourceData, meta interface{})
 	_ = d.Set("created_at", vpc.CreatedAt)
 	_ = d.Set("updated_at", vpc.UpdatedAt)
 	_ = d.Set("cidr", vpc.Subnets[0].CIDR)
+	setTags(d, meta, vpc.Tags)
 	_ = d.Set("mtu", vpc.MTU)
 
 	if err := d.Set("availability_zones", readAvailabilityZones(vpc.AvailabilityZones)); err != nil {
-		return fmt.Errorf("error setting availability_zones: %w", err)
+		return diag.Errorf("error setting availability_zones: %v", err)
 	}
 
 	if err := d.Set("subnets", readSubnets(vpc.Subnets)); err != nil {
-		return fmt.Errorf("error setting subnets: %w", err)
+		return diag.Errorf("error setting subnets: %v", err)
 	}
 
 	return nil
 }
 
-func resourceBizflyCloudVPCNetworkUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVPCNetworkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	vpcOpts := VPCRequestBuilder(d)
-	network, err := client.CloudServer.VPCNetworks().Update(context.Background(), d.Id(), &vpcOpts)
-	if err != nil {
-		return fmt.Errorf("error when update vpc network: %s, %v", d.Id(), err)
+	if err := updateVPCNetwork(ctx, client, d, expandTagsAll(d, meta)); err != nil {
+		return diag.Errorf("error when update vpc network: %s, %v", d.Id(), err)
 	}
-	d.SetId(network.ID)
-	return resourceBizflyCloudVPCNetworkRead(d, meta)
+	return resourceBizflyCloudVPCNetworkRead(ctx, d, meta)
+}
+
+// updateVPCNetwork is client.CloudServer.VPCNetworks().Update, also sending
+// the tags of the VPC network.
+func updateVPCNetwork(ctx context.Context, client *gobizfly.Client, d *schema.ResourceData, tags []string) error {
+	payload := &vpcNetworkUpdatePayload{
+		UpdateVPCPayload: VPCRequestBuilder(d),
+		Tags:             tags,
+	}
+	return updateResourceTags(ctx, client, http.MethodPut, serverServiceName, "/vpc-networks/"+d.Id(), payload)
 }
 
-func resourceBizflyCloudVPCNetworkDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudVPCNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	vpcID := d.Id()
-	if err := detachIgwOutOfVpc(client, vpcID); err != nil {
-		return err
+	if err := detachIgwOutOfVpc(ctx, client, vpcID); err != nil {
+		return diag.FromErr(err)
 	}
-	err := client.CloudServer.VPCNetworks().Delete(context.Background(), vpcID)
+	err := client.CloudServer.VPCNetworks().Delete(ctx, vpcID)
 	if err != nil && !errors.Is(err, gobizfly.ErrNotFound) {
-		return fmt.Errorf("error when delete vpc network: %v", err)
+		return diag.Errorf("error when delete vpc network: %v", err)
 	}
 	return nil
 }
 
-func detachIgwOutOfVpc(client *gobizfly.Client, vpcID string) error {
+func detachIgwOutOfVpc(ctx context.Context, client *gobizfly.Client, vpcID string) error {
 	var igwID *string
 	igwAttached := "true"
 	listOpts := gobizfly.ListVpcOpts{
 		IgwAttached: &igwAttached,
 	}
-	attachedVPCs, err := client.CloudServer.VPCNetworks().List(context.Background(), listOpts)
+	attachedVPCs, err := client.CloudServer.VPCNetworks().List(ctx, listOpts)
 	if err != nil {
 		return fmt.Errorf("error when list igw vpc networks [%v]: %v", vpcID, err)
 	}
@@ -165,7 +187,7 @@ func detachIgwOutOfVpc(client *gobizfly.Client, vpcID string) error {
 		return nil
 	}
 
-	detailIGW, err := client.CloudServer.InternetGateways().Get(context.Background(), *igwID)
+	detailIGW, err := client.CloudServer.InternetGateways().Get(ctx, *igwID)
 	if err != nil {
 		if errors.Is(err, gobizfly.ErrNotFound) {
 			return nil
@@ -177,7 +199,7 @@ func detachIgwOutOfVpc(client *gobizfly.Client, vpcID string) error {
 		Description: detailIGW.Description,
 		NetworkIDs:  []string{},
 	}
-	if _, err = client.CloudServer.InternetGateways().Update(context.Background(), *igwID, payload); err != nil {
+	if _, err = client.CloudServer.InternetGateways().Update(ctx, *igwID, payload); err != nil {
 		return fmt.Errorf("error when detach IGW [%v] out of vpc network [%v]: %v", *igwID, vpcID, err)
 	}
 	return nil
@@ -4,12 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -22,9 +23,9 @@ func TestAccBizflyCloudVPC(t *testing.T) {
 	var vpc gobizfly.VPCNetwork
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckBizflyCloudVPCNetworkDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckBizflyCloudVPCNetworkDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBizflyCloudVPCNetworkConfig(rInt),
@@ -104,3 +105,48 @@ resource "bizflycloud_vpc_network" "abc" {
 }
 `, rInt)
 }
+
+func TestBizflyCloudVPCNetwork_FakeAPITags(t *testing.T) {
+	t.Parallel()
+	api := newFakeBizflyAPI(t)
+	meta := api.providerMetaWithDefaultTags(t, "team=platform")
+	r := resourceBizflyCloudVPCNetwork()
+
+	config := map[string]interface{}{
+		"name": "vpc-tagged",
+		"cidr": "10.20.0.0/16",
+		"tags": []interface{}{"app=web"},
+	}
+	d := testResourceCreate(t, r, config, meta)
+	testCheckTags(t, d, "tags", "app=web")
+	testCheckTags(t, d, "tags_all", "app=web", "team=platform")
+	testCheckNoDiff(t, r, d, config, meta)
+	vpcTags := func() []string {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		return api.vpcs[d.Id()].Tags
+	}
+	if got := vpcTags(); !reflect.DeepEqual(got, []string{"app=web", "team=platform"}) {
+		t.Errorf("expected the VPC network to be created with the default tags, got %v", got)
+	}
+
+	config["tags"] = []interface{}{"app=api"}
+	d = testResourceUpdate(t, r, d, config, meta)
+	if got := vpcTags(); !reflect.DeepEqual(got, []string{"app=api", "team=platform"}) {
+		t.Errorf("expected the VPC network tags to be updated, got %v", got)
+	}
+	testCheckTags(t, d, "tags", "app=api")
+	testCheckNoDiff(t, r, d, config, meta)
+
+	// a change of the provider default tags alone updates the VPC network
+	meta = api.providerMetaWithDefaultTags(t, "team=data")
+	d = testResourceUpdate(t, r, d, config, meta)
+	if got := vpcTags(); !reflect.DeepEqual(got, []string{"app=api", "team=data"}) {
+		t.Errorf("expected the VPC network default tags to be updated, got %v", got)
+	}
+	testCheckTags(t, d, "tags_all", "app=api", "team=data")
+	testCheckNoDiff(t, r, d, config, meta)
+
+	testResourceDelete(t, r, d, meta)
+	testCheckResourceGone(t, r, d, meta)
+}
@@ -3,24 +3,25 @@ package bizflycloud
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/bizflycloud/gobizfly"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
 	"strings"
 	"time"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBizflyCloudWanIP() *schema.Resource {
 	return &schema.Resource{
-		Schema: resourceWanIPSchema(),
-		Create: resourceBizflyCloudWanIPCreate,
-		Read:   resourceBizflyCloudWanIPRead,
-		Update: resourceBizflyCloudWanIPUpdate,
-		Delete: resourceBizflyCloudWanIPDelete,
+		Schema:        resourceWanIPSchema(),
+		CreateContext: resourceBizflyCloudWanIPCreate,
+		ReadContext:   resourceBizflyCloudWanIPRead,
+		UpdateContext: resourceBizflyCloudWanIPUpdate,
+		DeleteContext: resourceBizflyCloudWanIPDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 1,
 		Timeouts: &schema.ResourceTimeout{
@@ -29,39 +30,39 @@ func resourceBizflyCloudWanIP() *schema.Resource {
 	}
 }
 
-func resourceBizflyCloudWanIPCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudWanIPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	createPayload := &gobizfly.CreatePublicNetworkInterfacePayload{
 		Name:             d.Get("name").(string),
 		AvailabilityZone: d.Get("availability_zone").(string),
 	}
-	wanIP, err := client.CloudServer.PublicNetworkInterfaces().Create(context.Background(), createPayload)
+	wanIP, err := client.CloudServer.PublicNetworkInterfaces().Create(ctx, createPayload)
 	if err != nil {
-		return fmt.Errorf("error when creating wan ip: %s", err)
+		return diag.Errorf("error when creating wan ip: %s", err)
 	}
 	d.SetId(wanIP.ID)
 	firewallIDs := readStringArray(d.Get("firewall_ids").(*schema.Set).List())
-	if err := attachFirewallsForPort(client, wanIP.ID, firewallIDs); err != nil {
-		return fmt.Errorf("error when attaching firewalls: %s", err)
+	if err := attachFirewallsForPort(ctx, client, wanIP.ID, firewallIDs); err != nil {
+		return diag.Errorf("error when attaching firewalls: %s", err)
 	}
-	return resourceBizflyCloudWanIPRead(d, meta)
+	return resourceBizflyCloudWanIPRead(ctx, d, meta)
 }
 
-func resourceBizflyCloudWanIPRead(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudWanIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	var wanIP *gobizfly.CloudServerPublicNetworkInterface
 
-	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
 		var err error
 		log.Printf("[DEBUG] Reading WAN IP : %s", d.Id())
-		wanIP, err = client.CloudServer.PublicNetworkInterfaces().Get(context.Background(), d.Id())
+		wanIP, err = client.CloudServer.PublicNetworkInterfaces().Get(ctx, d.Id())
 
 		// Retry on any API "not found" errors, but only on new resources.
 		if d.IsNewResource() && errors.Is(err, gobizfly.ErrNotFound) {
-			return resource.RetryableError(err)
+			return retry.RetryableError(err)
 		}
 		if err != nil {
-			return resource.NonRetryableError(err)
+			return retry.NonRetryableError(err)
 		}
 		return nil
 	})
@@ -73,12 +74,12 @@ func resourceBizflyCloudWanIPRead(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	if err != nil {
-		return fmt.Errorf("error read WAN IP network %s: %w", d.Id(), err)
+		return diag.Errorf("error read WAN IP network %s: %v", d.Id(), err)
 	}
 
 	// Prevent panics.
 	if wanIP == nil {
-		return fmt.Errorf("error read WAN IP (%s): empty response", d.Id())
+		return diag.Errorf("error read WAN IP (%s): empty response", d.Id())
 	}
 	d.SetId(wanIP.ID)
 	_ = d.Set("name", wanIP.Name)
@@ -93,19 +94,20 @@ func resourceBizflyCloudWanIPRead(d *schema.ResourceData, meta interface{}) erro
 	_ = d.Set("bandwidth", wanIP.Bandwidth)
 	_ = d.Set("availability_zone", wanIP.AvailabilityZone)
 	_ = d.Set("server_id", wanIP.DeviceID)
+	_ = d.Set("tags", wanIP.Tags)
 	return nil
 }
 
-func resourceBizflyCloudWanIPDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudWanIPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
-	err := client.CloudServer.PublicNetworkInterfaces().Delete(context.Background(), d.Id())
+	err := client.CloudServer.PublicNetworkInterfaces().Delete(ctx, d.Id())
 	if err != nil && !strings.Contains(err.Error(), "Resource not found") {
-		return fmt.Errorf("error when deleting WAN IP: %s", err)
+		return diag.Errorf("error when deleting WAN IP: %s", err)
 	}
 	return nil
 }
 
-func resourceBizflyCloudWanIPUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceBizflyCloudWanIPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CombinedConfig).gobizflyClient()
 	if d.HasChange("attached_server") {
 		serverID := d.Get("attached_server").(string)
@@ -113,18 +115,18 @@ func resourceBizflyCloudWanIPUpdate(d *schema.ResourceData, meta interface{}) er
 			updatePayload := &gobizfly.ActionPublicNetworkInterfacePayload{
 				Action: "detach_server",
 			}
-			err := client.CloudServer.PublicNetworkInterfaces().Action(context.Background(), d.Id(), updatePayload)
+			err := client.CloudServer.PublicNetworkInterfaces().Action(ctx, d.Id(), updatePayload)
 			if err != nil {
-				return fmt.Errorf("error when detaching server: %s", err)
+				return diag.Errorf("error when detaching server: %s", err)
 			}
 		} else {
 			updatePayload := &gobizfly.ActionPublicNetworkInterfacePayload{
 				Action:   "attach_server",
 				ServerID: serverID,
 			}
-			err := client.CloudServer.PublicNetworkInterfaces().Action(context.Background(), d.Id(), updatePayload)
+			err := client.CloudServer.PublicNetworkInterfaces().Action(ctx, d.Id(), updatePayload)
 			if err != nil {
-				return fmt.Errorf("error when attaching server: %s", err)
+				return diag.Errorf("error when attaching server: %s", err)
 			}
 		}
 	}
@@ -134,16 +136,16 @@ func resourceBizflyCloudWanIPUpdate(d *schema.ResourceData, meta interface{}) er
 			updatePayload := &gobizfly.ActionPublicNetworkInterfacePayload{
 				Action: "convert_to_paid",
 			}
-			err := client.CloudServer.PublicNetworkInterfaces().Action(context.Background(), d.Id(), updatePayload)
+			err := client.CloudServer.PublicNetworkInterfaces().Action(ctx, d.Id(), updatePayload)
 			if err != nil {
-				return fmt.Errorf("error when converting to paid: %s", err)
+				return diag.Errorf("error when converting to paid: %s", err)
 			}
 		}
 	}
 	if d.HasChange("firewall_ids") {
-		if err := updateFirewallForNetworkInterface(d, client, d.Id()); err != nil {
-			return fmt.Errorf("error when update firewall for network interface: %s, %v", d.Id(), err)
+		if err := updateFirewallForNetworkInterface(ctx, d, client, d.Id()); err != nil {
+			return diag.Errorf("error when update firewall for network interface: %s, %v", d.Id(), err)
 		}
 	}
-	return resourceBizflyCloudWanIPRead(d, meta)
+	return resourceBizflyCloudWanIPRead(ctx, d, meta)
 }
@@ -0,0 +1,181 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// defaultRetryableStatusCodes are the responses retried when the provider
+// configuration does not list any.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryTransport retries requests which failed with a retryable status code,
+// and idempotent requests which failed with a transport error. Requests which
+// are not idempotent, such as creating a server, are only retried on
+// responses which say that the request was not processed. It waits with an
+// exponential backoff between attempts, or for as long as the Retry-After
+// header of the response asks for, up to the maximum wait.
+type retryTransport struct {
+	base        http.RoundTripper
+	maxRetries  int
+	waitMin     time.Duration
+	waitMax     time.Duration
+	statusCodes map[int]bool
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(base http.RoundTripper, maxRetries int, waitMin, waitMax time.Duration, statusCodes []int) *retryTransport {
+	codes := make(map[int]bool, len(statusCodes))
+	for _, code := range statusCodes {
+		codes[code] = true
+	}
+	return &retryTransport{
+		base:        base,
+		maxRetries:  maxRetries,
+		waitMin:     waitMin,
+		waitMax:     waitMax,
+		statusCodes: codes,
+		sleep:       sleepContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+		resp, err := t.base.RoundTrip(r)
+		if attempt >= t.maxRetries || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[WARN] %s %s failed: %v, retrying in %s (%d/%d)",
+				req.Method, req.URL.Path, err, wait, attempt+1, t.maxRetries)
+		} else {
+			log.Printf("[WARN] %s %s returned %s, retrying in %s (%d/%d)",
+				req.Method, req.URL.Path, resp.Status, wait, attempt+1, t.maxRetries)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body has been consumed and cannot be sent again.
+		return false
+	}
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		return idempotent(req.Method)
+	}
+	if !t.statusCodes[resp.StatusCode] {
+		return false
+	}
+	if idempotent(req.Method) {
+		return true
+	}
+	// A gateway error or timeout does not tell whether the API processed the
+	// request, and sending it again may create a second server or volume.
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. Retry-After, in
+// seconds or as an HTTP date, takes precedence over the exponential backoff,
+// but neither waits longer than the maximum wait.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if v := resp.Header.Get("Retry-After"); v != "" {
+			if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+				return t.capWait(time.Duration(seconds) * time.Second)
+			}
+			if date, err := http.ParseTime(v); err == nil {
+				if wait := time.Until(date); wait > 0 {
+					return t.capWait(wait)
+				}
+				return 0
+			}
+		}
+	}
+	wait := t.waitMin
+	for i := 0; i < attempt && wait < t.waitMax; i++ {
+		wait *= 2
+	}
+	return t.capWait(wait)
+}
+
+func (t *retryTransport) capWait(wait time.Duration) time.Duration {
+	if wait > t.waitMax {
+		return t.waitMax
+	}
+	return wait
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
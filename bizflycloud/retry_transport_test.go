@@ -0,0 +1,186 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizflycloud/gobizfly"
+)
+
+// testRetryServer answers with the given statuses in turn, then with 200 OK.
+// Every request body is recorded.
+func testRetryServer(t *testing.T, statuses []int, header http.Header) (*httptest.Server, *[]string) {
+	t.Helper()
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(statuses) == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(statuses[0])
+		statuses = statuses[1:]
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+func testRetryTransport(maxRetries int, waits *[]time.Duration) *retryTransport {
+	rt := newRetryTransport(http.DefaultTransport, maxRetries, time.Second, 5*time.Second, defaultRetryableStatusCodes)
+	rt.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return rt
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name       string
+		method     string
+		statuses   []int
+		header     http.Header
+		maxRetries int
+		wantStatus int
+		wantWaits  []time.Duration
+	}{
+		{
+			name:       "exponential backoff",
+			method:     http.MethodPut,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusTooManyRequests, http.StatusGatewayTimeout},
+			maxRetries: 5,
+			wantStatus: http.StatusOK,
+			wantWaits:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second},
+		},
+		{
+			name:       "retry after",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusTooManyRequests},
+			header:     http.Header{"Retry-After": []string{"3"}},
+			maxRetries: 3,
+			wantStatus: http.StatusOK,
+			wantWaits:  []time.Duration{3 * time.Second},
+		},
+		{
+			name:       "retry after capped",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusServiceUnavailable},
+			header:     http.Header{"Retry-After": []string{"3600"}},
+			maxRetries: 3,
+			wantStatus: http.StatusOK,
+			wantWaits:  []time.Duration{5 * time.Second},
+		},
+		{
+			name:       "gateway error not retried for post",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusBadGateway, http.StatusGatewayTimeout},
+			maxRetries: 3,
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:       "gives up",
+			method:     http.MethodPut,
+			statuses:   []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			maxRetries: 2,
+			wantStatus: http.StatusBadGateway,
+			wantWaits:  []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:       "not retryable",
+			method:     http.MethodPut,
+			statuses:   []int{http.StatusInternalServerError},
+			maxRetries: 3,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, bodies := testRetryServer(t, tc.statuses, tc.header)
+			var waits []time.Duration
+			client := &http.Client{Transport: testRetryTransport(tc.maxRetries, &waits)}
+
+			req, err := http.NewRequest(tc.method, srv.URL, strings.NewReader(`{"name":"test"}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+			if !reflect.DeepEqual(waits, tc.wantWaits) {
+				t.Errorf("expected waits %v, got %v", tc.wantWaits, waits)
+			}
+			if len(*bodies) != len(tc.wantWaits)+1 {
+				t.Errorf("expected %d attempts, got %d", len(tc.wantWaits)+1, len(*bodies))
+			}
+			for i, body := range *bodies {
+				if body != `{"name":"test"}` {
+					t.Errorf("attempt %d: expected the request body to be replayed, got %q", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransport_FakeAPI(t *testing.T) {
+	api := newFakeBizflyAPI(t)
+	config := Config{
+		APIEndpoint:  api.URL + "/api",
+		AuthMethod:   authMethodPassword,
+		Email:        fakeAPIEmail,
+		Password:     fakeAPIPassword,
+		RegionName:   "HaNoi",
+		ProjectID:    fakeAPIProjectID,
+		MaxRetries:   3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("error configuring client: %v", err)
+	}
+
+	api.failNext(http.StatusServiceUnavailable, 2)
+	if _, err := meta.gobizflyClient().DNS.CreateZone(context.Background(), &gobizfly.CreateZonePayload{Name: "example.com"}); err != nil {
+		t.Fatalf("expected the request to be retried, got %v", err)
+	}
+	if got := api.requestCount("POST", "/dns/zones"); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+
+	api.failNext(http.StatusServiceUnavailable, 4)
+	if _, err := meta.gobizflyClient().DNS.ListZones(context.Background(), &gobizfly.ListOptions{}); err == nil {
+		t.Fatal("expected an error once the retries are exhausted")
+	}
+}
@@ -20,7 +20,7 @@ package bizflycloud
 import (
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataAutoScalingGroupSchema() map[string]*schema.Schema {
@@ -52,7 +52,6 @@ func dataAutoScalingGroupSchema() map[string]*schema.Schema {
 		"load_balancers": {
 			Type:     schema.TypeList,
 			Computed: true,
-			MaxItems: 1,
 			Elem: &schema.Resource{
 				Schema: dataAutoScalingLoadBalancerInfoSchema(),
 			},
@@ -312,7 +311,6 @@ func dataLaunchConfigurationSchema() map[string]*schema.Schema {
 		"os": {
 			Type:     schema.TypeList,
 			Computed: true,
-			MaxItems: 1,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"create_from": {
@@ -337,7 +335,6 @@ func dataLaunchConfigurationSchema() map[string]*schema.Schema {
 		"rootdisk": {
 			Type:     schema.TypeList,
 			Computed: true,
-			MaxItems: 1,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"delete_on_termination": {
@@ -365,8 +362,9 @@ func dataLaunchConfigurationSchema() map[string]*schema.Schema {
 		},
 
 		"user_data": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
 		},
 	}
 }
@@ -527,9 +525,10 @@ func resourceLaunchConfigurationSchema() map[string]*schema.Schema {
 		},
 
 		"user_data": {
-			Type:     schema.TypeString,
-			Optional: true,
-			ForceNew: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			ForceNew:  true,
+			Sensitive: true,
 		},
 	}
 }
@@ -1,12 +1,15 @@
 package bizflycloud
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func resourceCDNSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"domain": {
 			Type:     schema.TypeString,
 			Required: true,
+			ForceNew: true,
 		},
 		"domain_cdn": {
 			Type:     schema.TypeString,
@@ -45,3 +48,47 @@ func resourceCDNSchema() map[string]*schema.Schema {
 		},
 	}
 }
+
+func dataCDNSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"domain": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"domain", "domain_id"},
+		},
+		"domain_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"domain_cdn": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"origin": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"upstream_addrs": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"upstream_host": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"upstream_proto": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
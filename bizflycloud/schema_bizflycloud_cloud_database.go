@@ -18,28 +18,10 @@
 package bizflycloud
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-func dataElemCloudDatabaseDataStore() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"type": {
-			Type:     schema.TypeString,
-			Computed: true,
-		},
-		"name": {
-			Type:     schema.TypeString,
-			Computed: true,
-		},
-		"version_id": {
-			Type:     schema.TypeString,
-			Computed: true,
-		},
-	}
-
-}
-
 func dataCloudDatabaseDatastoreSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"id": {
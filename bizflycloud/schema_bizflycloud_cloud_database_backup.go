@@ -18,7 +18,7 @@
 package bizflycloud
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataCloudDatabaseBackupSchema() map[string]*schema.Schema {
@@ -19,8 +19,8 @@ package bizflycloud
 
 import (
 	"github.com/YakDriver/regexache"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCloudDatabaseBackupScheduleSchema() map[string]*schema.Schema {
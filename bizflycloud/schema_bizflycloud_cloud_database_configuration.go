@@ -18,7 +18,7 @@
 package bizflycloud
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCloudDatabaseConfigurationSchema() map[string]*schema.Schema {
@@ -30,7 +30,7 @@ func resourceCloudDatabaseConfigurationSchema() map[string]*schema.Schema {
 		"datastore": {
 			Type:     schema.TypeMap,
 			Required: true,
-			Elem:     &schema.Resource{Schema: dataElemCloudDatabaseDataStore()},
+			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
 		"name": {
 			Type:     schema.TypeString,
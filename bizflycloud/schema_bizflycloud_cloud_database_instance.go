@@ -20,8 +20,10 @@ package bizflycloud
 import (
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataCloudDatabaseInstanceSchema() map[string]*schema.Schema {
@@ -38,7 +40,7 @@ func dataCloudDatabaseInstanceSchema() map[string]*schema.Schema {
 		"datastore": {
 			Type:     schema.TypeMap,
 			Computed: true,
-			Elem:     &schema.Resource{Schema: dataElemCloudDatabaseDataStore()},
+			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
 		"dns": {
 			Computed: true,
@@ -153,32 +155,11 @@ func resourceCloudDatabaseInstanceSchema() map[string]*schema.Schema {
 		},
 
 		"datastore": {
-			Type:     schema.TypeMap,
-			Required: true,
-			ForceNew: true,
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"type": {
-						Type:     schema.TypeString,
-						Required: true,
-						ValidateFunc: validation.StringInSlice([]string{
-							"MariaDB",
-							"MongoDB",
-							"MySQL",
-							"Postgres",
-							"Redis",
-						}, false),
-					},
-					"name": {
-						Type:     schema.TypeString,
-						Required: true,
-					},
-					"version_id": {
-						Type:     schema.TypeString,
-						Required: true,
-					},
-				},
-			},
+			Type:             schema.TypeMap,
+			Required:         true,
+			ForceNew:         true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			ValidateDiagFunc: validateCloudDatabaseDatastore,
 		},
 		"network_ids": {
 			Type:     schema.TypeSet,
@@ -337,3 +318,29 @@ func resourceCloudDatabaseInstanceSchema() map[string]*schema.Schema {
 		},
 	}
 }
+
+// validateCloudDatabaseDatastore checks that the datastore map holds the type,
+// name and version_id keys and that type is a supported database engine.
+func validateCloudDatabaseDatastore(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	datastore := v.(map[string]interface{})
+	for _, key := range []string{"type", "name", "version_id"} {
+		if _, ok := datastore[key]; !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("%q is required in datastore", key),
+				AttributePath: path,
+			})
+		}
+	}
+	if t, ok := datastore["type"]; ok {
+		diags = append(diags, validation.ToDiagFunc(validation.StringInSlice([]string{
+			"MariaDB",
+			"MongoDB",
+			"MySQL",
+			"Postgres",
+			"Redis",
+		}, false))(t, path.IndexString("type"))...)
+	}
+	return diags
+}
@@ -18,7 +18,7 @@
 package bizflycloud
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataCloudDatabaseNodeSchema() map[string]*schema.Schema {
@@ -34,7 +34,7 @@ func dataCloudDatabaseNodeSchema() map[string]*schema.Schema {
 		"datastore": {
 			Computed: true,
 			Type:     schema.TypeMap,
-			Elem:     &schema.Resource{Schema: dataElemCloudDatabaseDataStore()},
+			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
 		"description": {
 			Type:     schema.TypeString,
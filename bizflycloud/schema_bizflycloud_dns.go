@@ -1,16 +1,22 @@
 package bizflycloud
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"github.com/bizflycloud/terraform-provider-bizflycloud/constants"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
 
 func resourceDNSSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"name": {
 			Type:     schema.TypeString,
 			Required: true,
+			ForceNew: true,
 		},
 		"required": {
 			Type:     schema.TypeBool,
 			Optional: true,
+			ForceNew: true,
 		},
 		"description": {
 			Type:     schema.TypeString,
@@ -33,17 +39,21 @@ func resourceDNSSchema() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"ttl": {
-			Type:     schema.TypeInt,
-			Computed: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"active": {
 			Type:     schema.TypeBool,
 			Optional: true,
+			Computed: true,
 		},
 		"nameserver": {
 			Type:     schema.TypeList,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 			Optional: true,
+			Computed: true,
 		},
 		"record_set": {
 			Type:     schema.TypeList,
@@ -71,7 +81,292 @@ func dataRecordSetInfoSchema() map[string]*schema.Schema {
 		},
 		"ttl": {
 			Computed: true,
+			Type:     schema.TypeInt,
+		},
+	}
+}
+
+func resourceDNSRecordSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"zone_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(constants.ValidDNSRecordTypes, false),
+		},
+		"ttl": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      300,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"data": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MinItems:     1,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			AtLeastOneOf: []string{"data", "routing_policy"},
+		},
+		"routing_policy": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: dnsRoutingPolicySchema(),
+			},
+		},
+		"priority": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"weight": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+		},
+		"tenant_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"updated_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func dnsRoutingPolicySchema() map[string]*schema.Schema {
+	policies := []string{"routing_policy.0.geo", "routing_policy.0.weighted", "routing_policy.0.failover"}
+	return map[string]*schema.Schema{
+		"geo": {
+			Type:         schema.TypeSet,
+			Optional:     true,
+			ExactlyOneOf: policies,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"region": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(constants.ValidDNSRoutingRegions, false),
+					},
+					"data": {
+						Type:     schema.TypeList,
+						Required: true,
+						MinItems: 1,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"weighted": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			ExactlyOneOf: policies,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"weight": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntBetween(0, 100),
+					},
+				},
+			},
+		},
+		"failover": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: policies,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"primary": {
+						Type:     schema.TypeList,
+						Required: true,
+						MinItems: 1,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"secondary": {
+						Type:     schema.TypeList,
+						Required: true,
+						MinItems: 1,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"health_check": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"protocol": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(constants.ValidDNSHealthCheckProtocols, false),
+					},
+					"port": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntBetween(1, 65535),
+					},
+					"path": {
+						Type:     schema.TypeString,
+						Optional: true,
+						Computed: true,
+					},
+					"host": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"ok_codes": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeInt},
+					},
+					"interval": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IntAtLeast(10),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataDNSZoneSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"id", "name"},
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"active": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"ttl": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"tenant_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"deleted": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"updated_at": {
 			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"nameserver": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"record_set": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: dataRecordSetInfoSchema(),
+			},
+		},
+	}
+}
+
+func dataDNSRecordsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"zone_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsValidRegExp,
+		},
+		"records": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"ttl": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"data": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"priority": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"weight": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"port": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+				},
+			},
 		},
 	}
 }
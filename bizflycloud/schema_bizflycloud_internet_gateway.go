@@ -1,6 +1,8 @@
 package bizflycloud
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func resourceInternetGatewaySchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -28,13 +30,8 @@ func resourceInternetGatewaySchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
-		"tags": {
-			Type: schema.TypeList,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
-			},
-			Computed: true,
-		},
+		"tags":     tagsSchema(),
+		"tags_all": tagsAllSchema(),
 		"availability_zones": {
 			Type: schema.TypeList,
 			Elem: &schema.Schema{
@@ -1,8 +1,8 @@
 package bizflycloud
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type ServerNetworkInterface struct {
@@ -73,8 +73,14 @@ func resourceServerSchema() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"user_data": {
-			Type:     schema.TypeString,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+		},
+		"rebuild_on_image_change": {
+			Type:     schema.TypeBool,
 			Optional: true,
+			Default:  false,
 		},
 		"network_plan": {
 			Type:     schema.TypeString,
@@ -116,6 +122,13 @@ func resourceServerSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 
+		"data_disk": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: resourceServerDataDiskSchema(),
+			},
+		},
 		"vpc_network_ids": {
 			Type:     schema.TypeSet,
 			Elem:     &schema.Schema{Type: schema.TypeString},
@@ -132,6 +145,49 @@ func resourceServerSchema() map[string]*schema.Schema {
 			Default:      "running",
 			ValidateFunc: validation.StringInSlice([]string{"running", "stopped"}, false),
 		},
+		"reboot_trigger": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"reboot_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "soft",
+			ValidateFunc: validation.StringInSlice([]string{"soft", "hard"}, false),
+		},
+		"fetch_console_url": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"console_url": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+	}
+}
+
+func resourceServerDataDiskSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"size": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"volume_type": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"delete_on_termination": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
 	}
 }
 
@@ -190,3 +246,76 @@ func resourceServerNetworkInterfaceSchema() map[string]*schema.Schema {
 		},
 	}
 }
+
+func dataServersSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"filter": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(serverFilterKeys, false),
+					},
+					"values": {
+						Type:     schema.TypeList,
+						Required: true,
+						MinItems: 1,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"regex": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+		"sort": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(serverSortKeys, false),
+					},
+					"direction": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "asc",
+						ValidateFunc: validation.StringInSlice([]string{"asc", "desc"}, false),
+					},
+				},
+			},
+		},
+		"servers": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: dataServerSchema(),
+			},
+		},
+	}
+}
+
+func dataServerSchema() map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema)
+	for _, key := range serverStringKeys {
+		s[key] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+	}
+	for _, key := range serverListKeys {
+		s[key] = &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		}
+	}
+	return s
+}
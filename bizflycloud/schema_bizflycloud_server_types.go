@@ -17,7 +17,9 @@
 
 package bizflycloud
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func dataSourceServerTypeSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -1,6 +1,8 @@
 package bizflycloud
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func resourceBizflyCloudVolumeAttachmentSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
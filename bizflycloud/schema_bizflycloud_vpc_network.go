@@ -1,6 +1,8 @@
 package bizflycloud
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func dataVPCNetworkSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -98,6 +100,8 @@ func resourceVPCNetworkSchema() map[string]*schema.Schema {
 				Schema: dataSubnetsInfoSchema(),
 			},
 		},
+		"tags":     tagsSchema(),
+		"tags_all": tagsAllSchema(),
 	}
 }
 
@@ -0,0 +1,156 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/bizflycloud/gobizfly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceTags is the body patching only the tags of a resource.
+type resourceTags struct {
+	Tags []string `json:"tags"`
+}
+
+func tagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// tagsValue returns the elements of a tags attribute, which is a set on most
+// resources and a list on the kubernetes ones.
+func tagsValue(v interface{}) []interface{} {
+	if set, ok := v.(*schema.Set); ok {
+		return set.List()
+	}
+	return v.([]interface{})
+}
+
+// mergeDefaultTags returns the provider default tags together with tags.
+func (c *CombinedConfig) mergeDefaultTags(tags []interface{}) *schema.Set {
+	merged := schema.NewSet(schema.HashString, tags)
+	for _, tag := range c.defaultTags {
+		merged.Add(tag)
+	}
+	return merged
+}
+
+// withDefaultTags returns the sorted tags to send to the API for a resource
+// configured with tags.
+func (c *CombinedConfig) withDefaultTags(tags []interface{}) []string {
+	merged := readStringArray(c.mergeDefaultTags(tags).List())
+	sort.Strings(merged)
+	return merged
+}
+
+// withoutDefaultTags returns the tags read from the API without those which
+// are only there as provider default tags. The configured tags come first, in
+// their configured order, so that a list of tags does not show a diff when the
+// API returns them in another order.
+func (c *CombinedConfig) withoutDefaultTags(configured []interface{}, tags []string) []interface{} {
+	read := schema.NewSet(schema.HashString, nil)
+	for _, tag := range tags {
+		read.Add(tag)
+	}
+	own := make([]interface{}, 0, len(tags))
+	seen := schema.NewSet(schema.HashString, nil)
+	for _, tag := range configured {
+		if read.Contains(tag) && !seen.Contains(tag) {
+			own = append(own, tag)
+			seen.Add(tag)
+		}
+	}
+	defaults := schema.NewSet(schema.HashString, nil)
+	for _, tag := range c.defaultTags {
+		defaults.Add(tag)
+	}
+	for _, tag := range tags {
+		if !seen.Contains(tag) && !defaults.Contains(tag) {
+			own = append(own, tag)
+			seen.Add(tag)
+		}
+	}
+	return own
+}
+
+// resourceTagsCustomizeDiff plans tags_all as the resource tags merged with
+// the provider default tags, so that a change of either shows in the plan.
+func resourceTagsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return planTagsAll(d, meta, "tags", "tags_all")
+}
+
+// planTagsAll plans tagsAllKey as the tags at tagsKey merged with the provider
+// default tags.
+func planTagsAll(d *schema.ResourceDiff, meta interface{}, tagsKey, tagsAllKey string) error {
+	if !d.NewValueKnown(tagsKey) {
+		return d.SetNewComputed(tagsAllKey)
+	}
+	tagsAll := meta.(*CombinedConfig).mergeDefaultTags(tagsValue(d.Get(tagsKey)))
+	if tagsAll.Equal(d.Get(tagsAllKey)) {
+		return nil
+	}
+	return d.SetNew(tagsAllKey, tagsAll.List())
+}
+
+// expandTagsAll returns the tags to send to the API for the resource.
+func expandTagsAll(d *schema.ResourceData, meta interface{}) []string {
+	return meta.(*CombinedConfig).withDefaultTags(tagsValue(d.Get("tags")))
+}
+
+// setTags sets tags_all to the tags returned by the API and tags to those of
+// them which are not only there as provider default tags.
+func setTags(d *schema.ResourceData, meta interface{}, tags []string) {
+	_ = d.Set("tags", meta.(*CombinedConfig).withoutDefaultTags(tagsValue(d.Get("tags")), tags))
+	_ = d.Set("tags_all", tags)
+}
+
+// patchResourceTags replaces the tags of a resource which keeps them in its
+// own body, by patching the resource at path with only its tags.
+func patchResourceTags(ctx context.Context, client *gobizfly.Client, serviceName, path string, tags []string) error {
+	return updateResourceTags(ctx, client, http.MethodPatch, serviceName, path, &resourceTags{Tags: tags})
+}
+
+// updateResourceTags sends body, which carries the tags of the resource, to
+// the update endpoint at path. It is used for the resources whose gobizfly
+// update payload has no tags.
+func updateResourceTags(ctx context.Context, client *gobizfly.Client, method, serviceName, path string, body interface{}) error {
+	req, err := client.NewRequest(ctx, method, serviceName, path, body)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
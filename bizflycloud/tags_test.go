@@ -0,0 +1,43 @@
+package bizflycloud
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testCheckTags(t *testing.T, d *schema.ResourceData, key string, expected ...string) {
+	t.Helper()
+	actual := readStringArray(d.Get(key).(*schema.Set).List())
+	sort.Strings(actual)
+	sort.Strings(expected)
+	if strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %s to be %v, got %v", key, expected, actual)
+	}
+}
+
+// testCheckNoTagsDiff checks that planning the same configuration again
+// does not show a change.
+func testCheckNoTagsDiff(t *testing.T, r *schema.Resource, d *schema.ResourceData, raw map[string]interface{}, meta interface{}) {
+	t.Helper()
+	diff, err := r.Diff(testContext(), d.State(), terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("error planning: %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes, got %v", diff.Attributes)
+	}
+}
+
+func TestCombinedConfig_withoutDefaultTags(t *testing.T) {
+	c := &CombinedConfig{defaultTags: []string{"team=platform", "env=prod"}}
+	configured := []interface{}{"b", "env=prod", "a"}
+	got := c.withoutDefaultTags(configured, []string{"a", "b", "c", "env=prod", "team=platform"})
+	if want := []interface{}{"b", "env=prod", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
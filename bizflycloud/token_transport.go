@@ -0,0 +1,206 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bizflycloud/gobizfly"
+)
+
+// tokenExpiryWindow is how long before its expiry a token is renewed.
+const tokenExpiryWindow = time.Minute
+
+// tokenTransport keeps the Keystone token used by the gobizfly client valid.
+// Tokens are renewed with the stored credentials shortly before they expire,
+// and requests rejected with 401 Unauthorized are re-authenticated and sent
+// once more, so that applies which outlive a token keep working.
+type tokenTransport struct {
+	base     http.RoundTripper
+	request  gobizfly.TokenCreateRequest
+	tokenURL string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newTokenTransport(base http.RoundTripper, request gobizfly.TokenCreateRequest) *tokenTransport {
+	return &tokenTransport{
+		base:    base,
+		request: request,
+	}
+}
+
+// setToken stores the token the client authenticated with initially.
+func (t *tokenTransport) setToken(tok *gobizfly.Token) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.storeToken(tok)
+}
+
+func (t *tokenTransport) storeToken(tok *gobizfly.Token) {
+	t.token = tok.KeystoneToken
+	t.expiresAt = time.Time{}
+	if expiresAt, err := time.Parse(time.RFC3339, tok.ExpiresAt); err == nil {
+		t.expiresAt = expiresAt
+	} else if tok.ExpiresAt != "" {
+		log.Printf("[WARN] Unable to parse token expiry %q: %v", tok.ExpiresAt, err)
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.isTokenRequest(req) {
+		return t.roundTripToken(req)
+	}
+	if req.Header.Get("X-Auth-Token") == "" {
+		return t.base.RoundTrip(req)
+	}
+
+	token, err := t.validToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.send(req, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// The body cannot be replayed, leave the error to the caller.
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	log.Printf("[INFO] Bizfly Cloud API rejected the token for %s %s, re-authenticating", req.Method, req.URL.Path)
+	token, err = t.renewToken(req.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(req, token)
+}
+
+func (t *tokenTransport) isTokenRequest(req *http.Request) bool {
+	if t.tokenURL == "" || req.Method != http.MethodPost {
+		return false
+	}
+	u := *req.URL
+	u.RawQuery = ""
+	return u.String() == t.tokenURL
+}
+
+// roundTripToken sends a token request as is. A 401 response is returned as
+// an error: gobizfly would otherwise answer it by requesting yet another token
+// with the same credentials, forever.
+func (t *tokenTransport) roundTripToken(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, _ := io.ReadAll(resp.Body)
+	return nil, fmt.Errorf("invalid credentials: %s", strings.TrimSpace(string(body)))
+}
+
+// send clones req with the given token and a fresh copy of its body.
+func (t *tokenTransport) send(req *http.Request, token string) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	r.Header.Set("X-Auth-Token", token)
+	return t.base.RoundTrip(r)
+}
+
+// validToken returns the current token, renewing it first if it is about to
+// expire.
+func (t *tokenTransport) validToken(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.expiresAt.IsZero() && time.Now().Add(tokenExpiryWindow).After(t.expiresAt) {
+		log.Printf("[INFO] Bizfly Cloud API token expires at %s, re-authenticating", t.expiresAt)
+		if err := t.createToken(ctx); err != nil {
+			return "", err
+		}
+	}
+	return t.token, nil
+}
+
+// renewToken replaces the rejected token, unless another request has already
+// done so in the meantime.
+func (t *tokenTransport) renewToken(ctx context.Context, rejected string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != rejected {
+		return t.token, nil
+	}
+	if err := t.createToken(ctx); err != nil {
+		return "", err
+	}
+	return t.token, nil
+}
+
+// createToken authenticates with the stored credentials. It must be called
+// with t.mu held.
+func (t *tokenTransport) createToken(ctx context.Context) error {
+	body, err := json.Marshal(t.request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Project-ID", t.request.ProjectID)
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return fmt.Errorf("error re-authenticating with Bizfly Cloud API: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusBadRequest {
+		buf, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error re-authenticating with Bizfly Cloud API: %s: %s",
+			resp.Status, strings.TrimSpace(string(buf)))
+	}
+	var tok gobizfly.Token
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return fmt.Errorf("error decoding Bizfly Cloud API token: %w", err)
+	}
+	t.storeToken(&tok)
+	return nil
+}
@@ -0,0 +1,139 @@
+// This file is part of terraform-provider-bizflycloud
+//
+// Copyright (C) 2021  Bizfly Cloud
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>
+
+package bizflycloud
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bizflycloud/gobizfly"
+)
+
+func TestTokenTransport_ReauthenticatesOnUnauthorized(t *testing.T) {
+	api := newFakeBizflyAPI(t)
+	client := api.providerMeta(t).gobizflyClient()
+	ctx := context.Background()
+	tokens := api.requestCount("POST", "/api/token")
+
+	api.expireTokens()
+	// A POST checks that the request body is replayed after re-authenticating.
+	zone, err := client.DNS.CreateZone(ctx, &gobizfly.CreateZonePayload{Name: "example.com"})
+	if err != nil {
+		t.Fatalf("error creating zone with an expired token: %v", err)
+	}
+	if zone.Name != "example.com" {
+		t.Errorf("expected zone example.com, got %q", zone.Name)
+	}
+	if got := api.requestCount("POST", "/api/token") - tokens; got != 1 {
+		t.Errorf("expected 1 new token, got %d", got)
+	}
+
+	if _, err := client.DNS.GetZone(ctx, zone.ID); err != nil {
+		t.Fatalf("error reading zone with the renewed token: %v", err)
+	}
+	if got := api.requestCount("POST", "/api/token") - tokens; got != 1 {
+		t.Errorf("expected the renewed token to be reused, got %d new tokens", got)
+	}
+}
+
+func TestTokenTransport_ConcurrentRequestsShareRenewal(t *testing.T) {
+	api := newFakeBizflyAPI(t)
+	client := api.providerMeta(t).gobizflyClient()
+	tokens := api.requestCount("POST", "/api/token")
+
+	api.expireTokens()
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.DNS.ListZones(context.Background(), &gobizfly.ListOptions{})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("error listing zones with an expired token: %v", err)
+		}
+	}
+	if got := api.requestCount("POST", "/api/token") - tokens; got != 1 {
+		t.Errorf("expected a single re-authentication, got %d", got)
+	}
+}
+
+func TestTokenTransport_RenewsBeforeExpiry(t *testing.T) {
+	api := newFakeBizflyAPI(t)
+	// Tokens expire within tokenExpiryWindow, so every request renews first.
+	api.tokenTTL = tokenExpiryWindow / 2
+	config := Config{
+		APIEndpoint:         api.URL + "/api",
+		AuthMethod:          authMethodApplicationCredential,
+		AppCredentialID:     fakeAPIAppCredentialID,
+		AppCredentialSecret: fakeAPIAppCredentialSecret,
+		RegionName:          "HaNoi",
+		ProjectID:           fakeAPIProjectID,
+	}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("error configuring client: %v", err)
+	}
+	tokens := api.requestCount("POST", "/api/token")
+
+	if _, err := meta.gobizflyClient().DNS.ListZones(context.Background(), &gobizfly.ListOptions{}); err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	if got := api.requestCount("POST", "/api/token") - tokens; got != 1 {
+		t.Errorf("expected the token to be renewed before the request, got %d new tokens", got)
+	}
+	if got := api.rejectedCount(); got != 0 {
+		t.Errorf("expected no rejected requests, got %d", got)
+	}
+}
+
+func TestTokenTransport_InvalidCredentials(t *testing.T) {
+	api := newFakeBizflyAPI(t)
+	config := Config{
+		APIEndpoint: api.URL + "/api",
+		AuthMethod:  authMethodPassword,
+		Email:       fakeAPIEmail,
+		Password:    "wrong-password",
+		RegionName:  "HaNoi",
+		ProjectID:   fakeAPIProjectID,
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := config.Client(context.Background())
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an authentication error")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("authenticating with invalid credentials did not return")
+	}
+	if got := api.requestCount("POST", "/api/token"); got != 1 {
+		t.Errorf("expected a single token request, got %d", got)
+	}
+}
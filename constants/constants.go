@@ -161,3 +161,50 @@ var (
 	ValidLbNetworkTypes  = []string{ExternalNetworkType, InternalNetworkType}
 	ValidLbTypes         = []string{LbSmallType, LbMediumType, LbLargeType}
 )
+
+// DNS
+const (
+	DNSRecordTypeA     = "A"
+	DNSRecordTypeAAAA  = "AAAA"
+	DNSRecordTypeCNAME = "CNAME"
+	DNSRecordTypeMX    = "MX"
+	DNSRecordTypeTXT   = "TXT"
+	DNSRecordTypeNS    = "NS"
+	DNSRecordTypePTR   = "PTR"
+	DNSRecordTypeSRV   = "SRV"
+)
+
+var (
+	ValidDNSRecordTypes = []string{
+		DNSRecordTypeA,
+		DNSRecordTypeAAAA,
+		DNSRecordTypeCNAME,
+		DNSRecordTypeMX,
+		DNSRecordTypeTXT,
+		DNSRecordTypeNS,
+		DNSRecordTypePTR,
+		DNSRecordTypeSRV,
+	}
+)
+
+// DNS routing policy
+const (
+	DNSRoutingRegionHN  = "HN"
+	DNSRoutingRegionHCM = "HCM"
+	DNSRoutingRegionSG  = "SG"
+	DNSRoutingRegionUSA = "USA"
+
+	DNSHealthCheckTCP  = "TCP"
+	DNSHealthCheckHTTP = "HTTP"
+)
+
+var (
+	ValidDNSRoutingRegions = []string{
+		DNSRoutingRegionHN,
+		DNSRoutingRegionHCM,
+		DNSRoutingRegionSG,
+		DNSRoutingRegionUSA,
+	}
+	ValidDNSHealthCheckProtocols = []string{DNSHealthCheckTCP, DNSHealthCheckHTTP}
+	ValidDNSRoutingRecordTypes   = []string{DNSRecordTypeA, DNSRecordTypeAAAA, DNSRecordTypeCNAME}
+)
@@ -22,4 +22,12 @@ resource "bizflycloud_cdn" "domain_com" {
     upstream_proto = "https"
     name = "origin-domain"
   }
-}
\ No newline at end of file
+}
+resource "bizflycloud_cdn_purge" "assets" {
+  domain_id = bizflycloud_cdn.domain_com.id
+  paths     = ["/assets/*"]
+
+  triggers = {
+    release = "v1"
+  }
+}
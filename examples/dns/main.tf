@@ -18,3 +18,11 @@ resource "bizflycloud_dns" "dns_zone" {
   name = "abc.xyz"
 }
 
+
+resource "bizflycloud_dns_record" "www" {
+  zone_id = bizflycloud_dns.dns_zone.id
+  name    = "www"
+  type    = "A"
+  ttl     = 300
+  data    = ["192.0.2.10"]
+}
@@ -0,0 +1,169 @@
+// Package waiter polls Bizfly Cloud API objects until an asynchronous
+// operation completes: a task becoming ready, an object reaching a
+// provisioning status, or an object being deleted.
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bizflycloud/gobizfly"
+)
+
+// DefaultPollInterval is the time between two polls when Config.PollInterval
+// is not set.
+const DefaultPollInterval = 3 * time.Second
+
+// Statuses reported by ForTask and ForDeletion.
+const (
+	StatusTaskPending = "PENDING"
+	StatusTaskReady   = "READY"
+	StatusDeleted     = "DELETED"
+)
+
+// StatusFunc fetches an object and returns it with its current status.
+type StatusFunc func(ctx context.Context) (result interface{}, status string, err error)
+
+// TaskFunc fetches an asynchronous task and reports whether it is ready.
+type TaskFunc func(ctx context.Context) (result interface{}, ready bool, err error)
+
+// Config describes how to wait for an operation.
+type Config struct {
+	// Description names what is waited for in logs and errors, for example
+	// "server abc to be created".
+	Description string
+	// Pending lists the statuses which keep the waiter polling. When empty,
+	// any status which is not a target one keeps it polling.
+	Pending []string
+	// Target lists the statuses which end the wait successfully.
+	Target []string
+	// Timeout bounds the whole wait, usually d.Timeout(...) of the resource.
+	// Zero means the wait is only bounded by the context.
+	Timeout time.Duration
+	// Delay is waited before the first poll.
+	Delay time.Duration
+	// PollInterval is waited between two polls.
+	PollInterval time.Duration
+}
+
+// TimeoutError is returned when the timeout expires before a target status
+// is reached.
+type TimeoutError struct {
+	Description string
+	LastStatus  string
+	Timeout     time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout while waiting for %s (last status: %s, timeout: %s)",
+		e.Description, displayStatus(e.LastStatus), e.Timeout)
+}
+
+// UnexpectedStatusError is returned when the object reaches a status which is
+// neither pending nor a target.
+type UnexpectedStatusError struct {
+	Description string
+	Status      string
+	Target      []string
+}
+
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %s while waiting for %s (expected: %s)",
+		displayStatus(e.Status), e.Description, strings.Join(e.Target, ", "))
+}
+
+// ForStatus polls refresh until it reports one of the target statuses, and
+// returns the last result.
+func (c Config) ForStatus(ctx context.Context, refresh StatusFunc) (interface{}, error) {
+	clock := clockFromContext(ctx)
+	deadline := clock.Now().Add(c.Timeout)
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	log.Printf("[INFO] Waiting for %s", c.Description)
+
+	lastStatus := ""
+	wait := c.Delay
+	for {
+		if wait > 0 {
+			if c.Timeout > 0 {
+				if remaining := deadline.Sub(clock.Now()); remaining < wait {
+					wait = remaining
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("waiting for %s (last status: %s): %w",
+					c.Description, displayStatus(lastStatus), ctx.Err())
+			case <-clock.After(wait):
+			}
+		}
+
+		result, status, err := refresh(ctx)
+		if err != nil {
+			return result, err
+		}
+		if status != lastStatus {
+			log.Printf("[DEBUG] Waiting for %s: status %s", c.Description, displayStatus(status))
+		}
+		lastStatus = status
+		if contains(c.Target, status) {
+			return result, nil
+		}
+		if len(c.Pending) > 0 && !contains(c.Pending, status) {
+			return result, &UnexpectedStatusError{Description: c.Description, Status: status, Target: c.Target}
+		}
+		if c.Timeout > 0 && !clock.Now().Before(deadline) {
+			return result, &TimeoutError{Description: c.Description, LastStatus: lastStatus, Timeout: c.Timeout}
+		}
+		wait = interval
+	}
+}
+
+// ForTask polls a task until it is ready, and returns its last result.
+func (c Config) ForTask(ctx context.Context, poll TaskFunc) (interface{}, error) {
+	c.Pending = []string{StatusTaskPending}
+	c.Target = []string{StatusTaskReady}
+	return c.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		result, ready, err := poll(ctx)
+		if err != nil || !ready {
+			return result, StatusTaskPending, err
+		}
+		return result, StatusTaskReady, nil
+	})
+}
+
+// ForDeletion polls refresh until the object is gone, that is until refresh
+// fails with gobizfly.ErrNotFound or reports one of the target statuses.
+func (c Config) ForDeletion(ctx context.Context, refresh StatusFunc) error {
+	c.Target = append(c.Target[:len(c.Target):len(c.Target)], StatusDeleted)
+	_, err := c.ForStatus(ctx, func(ctx context.Context) (interface{}, string, error) {
+		result, status, err := refresh(ctx)
+		if errors.Is(err, gobizfly.ErrNotFound) {
+			return nil, StatusDeleted, nil
+		}
+		return result, status, err
+	})
+	return err
+}
+
+func contains(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func displayStatus(status string) string {
+	if status == "" {
+		return "none"
+	}
+	return status
+}
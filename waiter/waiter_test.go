@@ -0,0 +1,179 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizflycloud/gobizfly"
+)
+
+// testStatuses returns a StatusFunc reporting the given statuses in turn and
+// the number of polls made so far.
+func testStatuses(statuses ...string) (StatusFunc, *int) {
+	polls := 0
+	return func(context.Context) (interface{}, string, error) {
+		status := statuses[len(statuses)-1]
+		if polls < len(statuses) {
+			status = statuses[polls]
+		}
+		polls++
+		return status, status, nil
+	}, &polls
+}
+
+func testContext() (context.Context, *FakeClock, time.Time) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	return WithClock(context.Background(), clock), clock, start
+}
+
+func TestForStatus(t *testing.T) {
+	ctx, clock, start := testContext()
+	refresh, polls := testStatuses("PENDING_CREATE", "PENDING_CREATE", "ACTIVE")
+	conf := Config{
+		Description:  "load balancer lb-1 to be active",
+		Pending:      []string{"PENDING_CREATE"},
+		Target:       []string{"ACTIVE"},
+		Timeout:      time.Minute,
+		Delay:        10 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+	result, err := conf.ForStatus(ctx, refresh)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "ACTIVE" {
+		t.Errorf("expected the last result, got %v", result)
+	}
+	if *polls != 3 {
+		t.Errorf("expected 3 polls, got %d", *polls)
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != 20*time.Second {
+		t.Errorf("expected a 10s delay and two 5s intervals, got %s", elapsed)
+	}
+}
+
+func TestForStatus_Timeout(t *testing.T) {
+	ctx, clock, start := testContext()
+	refresh, _ := testStatuses("BUILD")
+	conf := Config{
+		Description:  "server abc to be created",
+		Pending:      []string{"BUILD"},
+		Target:       []string{"ACTIVE"},
+		Timeout:      20 * time.Minute,
+		PollInterval: 7 * time.Second,
+	}
+	_, err := conf.ForStatus(ctx, refresh)
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected a TimeoutError, got %v", err)
+	}
+	if timeoutErr.LastStatus != "BUILD" {
+		t.Errorf("expected last status BUILD, got %q", timeoutErr.LastStatus)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "server abc to be created") || !strings.Contains(msg, "last status: BUILD") {
+		t.Errorf("unexpected error message %q", msg)
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != 20*time.Minute {
+		t.Errorf("expected to give up once the timeout expired, got %s", elapsed)
+	}
+}
+
+func TestForStatus_UnexpectedStatus(t *testing.T) {
+	ctx, _, _ := testContext()
+	refresh, _ := testStatuses("PENDING_UPDATE", "ERROR")
+	conf := Config{
+		Description: "pool p-1 to be active",
+		Pending:     []string{"PENDING_UPDATE"},
+		Target:      []string{"ACTIVE"},
+		Timeout:     time.Minute,
+	}
+	_, err := conf.ForStatus(ctx, refresh)
+	var statusErr *UnexpectedStatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != "ERROR" {
+		t.Fatalf("expected an UnexpectedStatusError for ERROR, got %v", err)
+	}
+}
+
+func TestForStatus_RefreshError(t *testing.T) {
+	ctx, _, _ := testContext()
+	refreshErr := errors.New("boom")
+	conf := Config{Description: "cluster c-1 to be provisioned", Target: []string{"ACTIVE"}, Timeout: time.Minute}
+	_, err := conf.ForStatus(ctx, func(context.Context) (interface{}, string, error) {
+		return nil, "", refreshErr
+	})
+	if !errors.Is(err, refreshErr) {
+		t.Fatalf("expected the refresh error, got %v", err)
+	}
+}
+
+func TestForStatus_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	conf := Config{
+		Description:  "volume v-1 to be extended",
+		Target:       []string{"available"},
+		Timeout:      time.Hour,
+		PollInterval: time.Hour,
+	}
+	_, err := conf.ForStatus(ctx, func(context.Context) (interface{}, string, error) {
+		cancel()
+		return nil, "extending", nil
+	})
+	if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), "last status: extending") {
+		t.Fatalf("expected a cancellation error reporting the last status, got %v", err)
+	}
+}
+
+func TestForTask(t *testing.T) {
+	ctx, _, _ := testContext()
+	polls := 0
+	conf := Config{Description: "task t-1 to complete", Timeout: time.Minute}
+	result, err := conf.ForTask(ctx, func(context.Context) (interface{}, bool, error) {
+		polls++
+		return polls, polls == 3, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != 3 {
+		t.Errorf("expected the result of the ready task, got %v", result)
+	}
+
+	_, err = conf.ForTask(ctx, func(context.Context) (interface{}, bool, error) {
+		return nil, false, nil
+	})
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.LastStatus != StatusTaskPending {
+		t.Fatalf("expected a TimeoutError with a pending task, got %v", err)
+	}
+}
+
+func TestForDeletion(t *testing.T) {
+	ctx, _, _ := testContext()
+	polls := 0
+	conf := Config{Description: "kafka cluster k-1 to be deleted", Pending: []string{"DELETING"}, Timeout: time.Minute}
+	err := conf.ForDeletion(ctx, func(context.Context) (interface{}, string, error) {
+		polls++
+		if polls < 3 {
+			return nil, "DELETING", nil
+		}
+		return nil, "", fmt.Errorf("cluster k-1: %w", gobizfly.ErrNotFound)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+
+	// A soft deleted object is gone once it reports a target status.
+	conf.Target = []string{"SOFT_DELETED"}
+	refresh, _ := testStatuses("DELETING", "SOFT_DELETED")
+	if err := conf.ForDeletion(ctx, refresh); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}